@@ -18,11 +18,16 @@ import (
 	"github.com/kyma-project/kyma/common/logging/tracing"
 	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
 
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/audit"
 	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/controller"
 	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/externalapi"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/limits"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/metrics"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/revocation"
 	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/validationproxy"
 	"github.com/oklog/run"
 	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -90,13 +95,69 @@ func main() {
 		time.Duration(options.cacheExpirationSeconds)*time.Second,
 		time.Duration(options.cacheCleanupIntervalSeconds)*time.Second,
 	)
+
+	limiter := limits.NewLimiter(limits.Limits{
+		RequestsPerSecond: options.defaultRequestsPerSecond,
+		MaxBodySize:       options.defaultMaxBodySize,
+	})
+	metrics.Register(prometheus.DefaultRegisterer)
+
 	idCache.OnEvicted(func(key string, i interface{}) {
+		limiter.Delete(key)
 		log.WithContext().
 			With("controller", "cache_janitor").
 			With("name", key).
 			Warnf("Deleted the application from the cache with values %v.", i)
 	})
 
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:             scheme,
+		MetricsBindAddress: "0",
+		SyncPeriod:         &options.syncPeriod,
+		ClientDisableCacheFor: []client.Object{
+			&v1alpha1.Application{},
+		},
+	})
+	if err != nil {
+		log.WithContext().Error("Unable to start manager: %s", err.Error())
+		os.Exit(1)
+	}
+	revocationConfigMap, err := options.revocationConfigMapName()
+	if err != nil {
+		log.WithContext().Error("Unable to read revocation ConfigMap name: %s", err.Error())
+		os.Exit(1)
+	}
+	revocations := revocation.NewList(revocation.Config{
+		ConfigMap:     revocationConfigMap,
+		CRL:           options.revocationCRL,
+		CRLIssuer:     options.revocationCRLIssuer,
+		RefreshPeriod: options.revocationRefreshPeriod,
+	}, mgr.GetAPIReader(), log)
+	if options.revocationConfigMap != "" || options.revocationCRL != "" {
+		if err = revocations.Refresh(context.Background()); err != nil {
+			log.WithContext().Warnf("Failed to load revoked client certificates, retrying in %v: %s", options.revocationRefreshPeriod, err.Error())
+		}
+		if err = mgr.Add(revocations); err != nil {
+			log.WithContext().Error("Unable to add revocation list refresher: %s", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	auditLog := audit.NewNopLogger()
+	if options.auditLogSink != "" {
+		sink, err := audit.OpenSink(options.auditLogSink)
+		if err != nil {
+			log.WithContext().Error("Unable to open audit log sink: %s", err.Error())
+			os.Exit(1)
+		}
+		defer sink.Close()
+
+		auditLog = audit.NewLogger(sink, audit.Config{
+			SampleRate:              options.auditLogSampleRate,
+			RedactedQueryParameters: options.auditLogRedactedQueryParameters(),
+		}, log)
+	}
+
 	proxyHandler := validationproxy.NewProxyHandler(
 		options.appNamePlaceholder,
 		options.eventingPathPrefixV1,
@@ -105,6 +166,9 @@ func main() {
 		options.eventingPublisherHost,
 		options.eventingDestinationPath,
 		idCache,
+		limiter,
+		revocations,
+		auditLog,
 		log)
 
 	tracingMiddleware := tracing.NewTracingMiddleware(proxyHandler.ProxyAppConnectorRequests)
@@ -119,19 +183,7 @@ func main() {
 		Addr:    fmt.Sprintf(":%d", options.externalAPIPort),
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:             scheme,
-		MetricsBindAddress: "0",
-		SyncPeriod:         &options.syncPeriod,
-		ClientDisableCacheFor: []client.Object{
-			&v1alpha1.Application{},
-		},
-	})
-	if err != nil {
-		log.WithContext().Error("Unable to start manager: %s", err.Error())
-		os.Exit(1)
-	}
-	if err = controller.NewController(log, mgr.GetClient(), idCache).SetupWithManager(mgr); err != nil {
+	if err = controller.NewController(log, mgr.GetClient(), idCache, limiter).SetupWithManager(mgr); err != nil {
 		log.WithContext().Error("Unable to create reconciler: %s", err.Error())
 		os.Exit(1)
 	}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/vrischmann/envconfig"
@@ -24,6 +25,15 @@ type args struct {
 	cacheExpirationSeconds      int
 	cacheCleanupIntervalSeconds int
 	syncPeriod                  time.Duration
+	defaultRequestsPerSecond    float64
+	defaultMaxBodySize          int64
+	revocationConfigMap         string
+	revocationCRL               string
+	revocationCRLIssuer         string
+	revocationRefreshPeriod     time.Duration
+	auditLogSink                string
+	auditLogSampleRate          float64
+	auditLogRedactedQuery       string
 }
 
 type config struct {
@@ -48,6 +58,15 @@ func parseOptions() (*options, error) {
 	cacheExpirationSeconds := flag.Int("cacheExpirationSeconds", 90, "Expiration time for client IDs stored in cache expressed in seconds")
 	cacheCleanupIntervalSeconds := flag.Int("cacheCleanupIntervalSeconds", 20, "Clean up interval controls how often the client IDs stored in cache are removed")
 	syncPeriod := flag.Duration("syncPeriod", 45*time.Second, "Sync period in seconds how often controller should periodically reconcile Application resource.")
+	defaultRequestsPerSecond := flag.Float64("defaultRequestsPerSecond", 0, "Requests-per-second limit of the Application without its own limit, 0 means no limit")
+	defaultMaxBodySize := flag.Int64("defaultMaxBodySize", 0, "Maximum request body size in bytes of the Application without its own limit, 0 means no limit")
+	revocationConfigMap := flag.String("revocationConfigMap", "", "Namespace and name of the ConfigMap with the revoked client certificates of the Applications, in the namespace/name format")
+	revocationCRL := flag.String("revocationCRL", "", "URL or path of the certificate revocation list of the CA issuing the client certificates")
+	revocationCRLIssuer := flag.String("revocationCRLIssuer", "", "Path of the PEM encoded certificate of the CA signing the certificate revocation list, required if revocationCRL is set")
+	revocationRefreshPeriod := flag.Duration("revocationRefreshPeriod", time.Minute, "Period of refreshing the revoked client certificates")
+	auditLogSink := flag.String("auditLogSink", "", "Sink of the audit log of the requests: stdout, stderr, or the path of the file. Empty disables the audit log")
+	auditLogSampleRate := flag.Float64("auditLogSampleRate", 1, "Fraction of the requests written to the audit log, from 0 to 1")
+	auditLogRedactedQuery := flag.String("auditLogRedactedQuery", "*", "Comma-separated names of the query parameters whose values are redacted in the audit log, * redacts all")
 
 	flag.Parse()
 
@@ -69,6 +88,15 @@ func parseOptions() (*options, error) {
 			cacheExpirationSeconds:      *cacheExpirationSeconds,
 			cacheCleanupIntervalSeconds: *cacheCleanupIntervalSeconds,
 			syncPeriod:                  *syncPeriod,
+			defaultRequestsPerSecond:    *defaultRequestsPerSecond,
+			defaultMaxBodySize:          *defaultMaxBodySize,
+			revocationConfigMap:         *revocationConfigMap,
+			revocationCRL:               *revocationCRL,
+			revocationCRLIssuer:         *revocationCRLIssuer,
+			revocationRefreshPeriod:     *revocationRefreshPeriod,
+			auditLogSink:                *auditLogSink,
+			auditLogSampleRate:          *auditLogSampleRate,
+			auditLogRedactedQuery:       *auditLogRedactedQuery,
 		},
 		config: c,
 	}, nil
@@ -81,16 +109,44 @@ func (o *options) String() string {
 		"--eventingDestinationPath=%s "+
 		"--appNamePlaceholder=%s "+
 		"--cacheExpirationSeconds=%d --cacheCleanupIntervalSeconds=%d "+
-		"--syncPeriod=%d APP_LOG_FORMAT=%s APP_LOG_LEVEL=%s KUBECONFIG=%s",
+		"--syncPeriod=%d "+
+		"--defaultRequestsPerSecond=%v --defaultMaxBodySize=%d "+
+		"--revocationConfigMap=%s --revocationCRL=%s --revocationCRLIssuer=%s --revocationRefreshPeriod=%v "+
+		"--auditLogSink=%s --auditLogSampleRate=%v --auditLogRedactedQuery=%s "+
+		"APP_LOG_FORMAT=%s APP_LOG_LEVEL=%s KUBECONFIG=%s",
 		o.proxyPort, o.externalAPIPort,
 		o.eventingPathPrefixV1, o.eventingPathPrefixV2, o.eventingPathPrefixEvents,
 		o.eventingPublisherHost, o.eventingDestinationPath,
 		o.appNamePlaceholder,
 		o.cacheExpirationSeconds, o.cacheCleanupIntervalSeconds,
-		o.syncPeriod, o.LogFormat, o.LogLevel, os.Getenv(clientcmd.RecommendedConfigPathEnvVar))
+		o.syncPeriod,
+		o.defaultRequestsPerSecond, o.defaultMaxBodySize,
+		o.revocationConfigMap, o.revocationCRL, o.revocationCRLIssuer, o.revocationRefreshPeriod,
+		o.auditLogSink, o.auditLogSampleRate, o.auditLogRedactedQuery,
+		o.LogFormat, o.LogLevel, os.Getenv(clientcmd.RecommendedConfigPathEnvVar))
 }
 
 func (o *options) validate() error {
+	if o.defaultRequestsPerSecond < 0 {
+		return fmt.Errorf("defaultRequestsPerSecond '%v' should not be negative", o.defaultRequestsPerSecond)
+	}
+	if o.defaultMaxBodySize < 0 {
+		return fmt.Errorf("defaultMaxBodySize '%d' should not be negative", o.defaultMaxBodySize)
+	}
+	if o.revocationConfigMap != "" {
+		if _, err := o.revocationConfigMapName(); err != nil {
+			return err
+		}
+	}
+	if o.revocationCRL != "" && o.revocationCRLIssuer == "" {
+		return fmt.Errorf("revocationCRLIssuer should be set to verify revocationCRL '%s'", o.revocationCRL)
+	}
+	if (o.revocationConfigMap != "" || o.revocationCRL != "") && o.revocationRefreshPeriod <= 0 {
+		return fmt.Errorf("revocationRefreshPeriod '%v' should be positive", o.revocationRefreshPeriod)
+	}
+	if o.auditLogSampleRate < 0 || o.auditLogSampleRate > 1 {
+		return fmt.Errorf("auditLogSampleRate '%v' should be between 0 and 1", o.auditLogSampleRate)
+	}
 	if o.appNamePlaceholder == "" {
 		return nil
 	}
@@ -108,3 +164,24 @@ func (o *options) validate() error {
 	}
 	return nil
 }
+
+func (o *options) revocationConfigMapName() (types.NamespacedName, error) {
+	if o.revocationConfigMap == "" {
+		return types.NamespacedName{}, nil
+	}
+	parts := strings.Split(o.revocationConfigMap, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return types.NamespacedName{}, fmt.Errorf("revocationConfigMap '%s' should be in the namespace/name format", o.revocationConfigMap)
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+func (o *options) auditLogRedactedQueryParameters() []string {
+	var parameters []string
+	for _, parameter := range strings.Split(o.auditLogRedactedQuery, ",") {
+		if parameter = strings.TrimSpace(parameter); parameter != "" {
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters
+}
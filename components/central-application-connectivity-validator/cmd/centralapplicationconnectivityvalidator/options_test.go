@@ -75,6 +75,76 @@ func TestOptionsValidation(t *testing.T) {
 				syncPeriod:               121 * time.Second,
 			},
 		},
+		{
+			name:  "negative defaultRequestsPerSecond",
+			valid: false,
+			args: args{
+				appNamePlaceholder:       "",
+				defaultRequestsPerSecond: -1,
+			},
+		},
+		{
+			name:  "negative defaultMaxBodySize",
+			valid: false,
+			args: args{
+				appNamePlaceholder: "",
+				defaultMaxBodySize: -1,
+			},
+		},
+		{
+			name:  "revocation sources",
+			valid: true,
+			args: args{
+				appNamePlaceholder:      "",
+				revocationConfigMap:     "kyma-system/revoked-certificates",
+				revocationCRL:           "https://ca.example.com/ca.crl",
+				revocationCRLIssuer:     "/etc/crl-issuer/ca.crt",
+				revocationRefreshPeriod: time.Minute,
+			},
+		},
+		{
+			name:  "revocationCRL without revocationCRLIssuer",
+			valid: false,
+			args: args{
+				appNamePlaceholder:      "",
+				revocationCRL:           "https://ca.example.com/ca.crl",
+				revocationRefreshPeriod: time.Minute,
+			},
+		},
+		{
+			name:  "revocationConfigMap without namespace",
+			valid: false,
+			args: args{
+				appNamePlaceholder:      "",
+				revocationConfigMap:     "revoked-certificates",
+				revocationRefreshPeriod: time.Minute,
+			},
+		},
+		{
+			name:  "auditLogSampleRate greater than 1",
+			valid: false,
+			args: args{
+				appNamePlaceholder: "",
+				auditLogSampleRate: 1.5,
+			},
+		},
+		{
+			name:  "negative auditLogSampleRate",
+			valid: false,
+			args: args{
+				appNamePlaceholder: "",
+				auditLogSampleRate: -0.5,
+			},
+		},
+		{
+			name:  "non-positive revocationRefreshPeriod",
+			valid: false,
+			args: args{
+				appNamePlaceholder:  "",
+				revocationCRL:       "/etc/crl/ca.crl",
+				revocationCRLIssuer: "/etc/crl-issuer/ca.crt",
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
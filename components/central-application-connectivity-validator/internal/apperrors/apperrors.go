@@ -3,12 +3,14 @@ package apperrors
 import "fmt"
 
 const (
-	CodeInternal      = 1
-	CodeNotFound      = 2
-	CodeAlreadyExists = 3
-	CodeWrongInput    = 4
-	CodeForbidden     = 5
-	CodeBadRequest    = 6
+	CodeInternal        = 1
+	CodeNotFound        = 2
+	CodeAlreadyExists   = 3
+	CodeWrongInput      = 4
+	CodeForbidden       = 5
+	CodeBadRequest      = 6
+	CodeTooManyRequests = 7
+	CodeTooLarge        = 8
 )
 
 type AppError interface {
@@ -49,6 +51,14 @@ func BadRequest(format string, a ...interface{}) AppError {
 	return errorf(CodeBadRequest, format, a...)
 }
 
+func TooManyRequests(format string, a ...interface{}) AppError {
+	return errorf(CodeTooManyRequests, format, a...)
+}
+
+func TooLarge(format string, a ...interface{}) AppError {
+	return errorf(CodeTooLarge, format, a...)
+}
+
 func (ae appError) Code() int {
 	return ae.code
 }
@@ -14,6 +14,8 @@ func TestAppError(t *testing.T) {
 		assert.Equal(t, CodeAlreadyExists, AlreadyExists("error").Code())
 		assert.Equal(t, CodeWrongInput, WrongInput("error").Code())
 		assert.Equal(t, CodeForbidden, Forbidden("error").Code())
+		assert.Equal(t, CodeTooManyRequests, TooManyRequests("error").Code())
+		assert.Equal(t, CodeTooLarge, TooLarge("error").Code())
 	})
 
 	t.Run("should create error with simple message", func(t *testing.T) {
@@ -0,0 +1,134 @@
+// Package audit contains the audit log of the requests of the external systems proxied by the validator
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma/common/logging/logger"
+)
+
+const (
+	// TargetLegacyEvents is the target of the requests forwarded to the legacy events endpoint of the Eventing Publisher Proxy
+	TargetLegacyEvents = "legacy_events"
+	// TargetCloudEvents is the target of the requests forwarded to the CloudEvents endpoint of the Eventing Publisher Proxy
+	TargetCloudEvents = "cloud_events"
+
+	// AllQueryParameters redacts the values of all query parameters
+	AllQueryParameters = "*"
+	// RedactedValue replaces the value of the redacted query parameter
+	RedactedValue = "REDACTED"
+)
+
+// Record describes one request of the external system. The optional fields are empty if the request was rejected before they were known
+type Record struct {
+	Time        time.Time `json:"time"`
+	Application string    `json:"application"`
+	// Subject is the subject of the client certificate matching the Application
+	Subject string `json:"subject,omitempty"`
+	// ClientID is the client ID of the Application matching the common name of the subject, empty if the Application has no client IDs
+	ClientID string `json:"clientId,omitempty"`
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	Query    string `json:"query,omitempty"`
+	// Target is empty if the request wasn't forwarded
+	Target        string  `json:"target,omitempty"`
+	Status        int     `json:"status"`
+	RequestBytes  int64   `json:"requestBytes"`
+	ResponseBytes int64   `json:"responseBytes"`
+	LatencyMillis float64 `json:"latencyMs"`
+}
+
+type Logger interface {
+	// Log writes the record if it's sampled
+	Log(record Record)
+}
+
+// Config holds the sampling and the redaction of the audit log
+type Config struct {
+	// SampleRate is the fraction of the requests written to the audit log, from 0 to 1
+	SampleRate float64
+	// RedactedQueryParameters are the names of the query parameters whose values aren't written, or AllQueryParameters
+	RedactedQueryParameters []string
+}
+
+type auditLogger struct {
+	sampleRate        float64
+	redactAll         bool
+	redactedQueryKeys map[string]struct{}
+	log               *logger.Logger
+
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	random  *rand.Rand
+}
+
+// NewLogger creates the logger writing the records to the writer as JSON lines
+func NewLogger(writer io.Writer, config Config, log *logger.Logger) Logger {
+	l := &auditLogger{
+		sampleRate:        config.SampleRate,
+		redactedQueryKeys: map[string]struct{}{},
+		log:               log,
+		encoder:           json.NewEncoder(writer),
+		random:            rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	for _, key := range config.RedactedQueryParameters {
+		if key == AllQueryParameters {
+			l.redactAll = true
+		}
+		l.redactedQueryKeys[key] = struct{}{}
+	}
+
+	return l
+}
+
+// NewNopLogger creates the logger which doesn't write the records
+func NewNopLogger() Logger {
+	return nopLogger{}
+}
+
+func (l *auditLogger) Log(record Record) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.sampleRate < 1 && l.random.Float64() >= l.sampleRate {
+		return
+	}
+
+	record.Query = l.redact(record.Query)
+	if err := l.encoder.Encode(record); err != nil {
+		l.log.WithContext().With("application", record.Application).Errorf("Failed to write audit log record: %s", err.Error())
+	}
+}
+
+// redact replaces the values of the redacted query parameters. The query which can't be parsed is redacted entirely
+func (l *auditLogger) redact(rawQuery string) string {
+	if rawQuery == "" || (!l.redactAll && len(l.redactedQueryKeys) == 0) {
+		return rawQuery
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return RedactedValue
+	}
+
+	for key, values := range query {
+		if _, found := l.redactedQueryKeys[key]; !found && !l.redactAll {
+			continue
+		}
+		for i := range values {
+			values[i] = RedactedValue
+		}
+	}
+
+	return query.Encode()
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(Record) {}
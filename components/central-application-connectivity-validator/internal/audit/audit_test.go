@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	log, err := logger.New(logger.TEXT, logger.ERROR)
+	require.NoError(t, err)
+
+	record := Record{
+		Time:        time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC),
+		Application: "commerce",
+		Method:      "POST",
+		Path:        "/commerce/events",
+		Query:       "token=secret&id=1&id=2",
+		Target:      TargetCloudEvents,
+		Status:      200,
+	}
+
+	t.Run("should write record as JSON line", func(t *testing.T) {
+		// given
+		var buffer bytes.Buffer
+		auditLogger := NewLogger(&buffer, Config{SampleRate: 1}, log)
+
+		// when
+		auditLogger.Log(record)
+		auditLogger.Log(record)
+
+		// then
+		lines := bytes.Split(bytes.TrimSpace(buffer.Bytes()), []byte("\n"))
+		require.Len(t, lines, 2)
+		var written Record
+		require.NoError(t, json.Unmarshal(lines[0], &written))
+		assert.Equal(t, record, written)
+	})
+
+	t.Run("should not write records which aren't sampled", func(t *testing.T) {
+		// given
+		var buffer bytes.Buffer
+		auditLogger := NewLogger(&buffer, Config{SampleRate: 0}, log)
+
+		// when
+		auditLogger.Log(record)
+
+		// then
+		assert.Empty(t, buffer.String())
+	})
+
+	for _, testCase := range []struct {
+		caseDescription string
+		redacted        []string
+		query           string
+		expectedQuery   string
+	}{
+		{
+			caseDescription: "should redact listed query parameters",
+			redacted:        []string{"token"},
+			query:           "token=secret&id=1&id=2",
+			expectedQuery:   "id=1&id=2&token=REDACTED",
+		},
+		{
+			caseDescription: "should redact all query parameters",
+			redacted:        []string{AllQueryParameters},
+			query:           "token=secret&id=1&id=2",
+			expectedQuery:   "id=REDACTED&id=REDACTED&token=REDACTED",
+		},
+		{
+			caseDescription: "should redact entire query which can't be parsed",
+			redacted:        []string{"token"},
+			query:           "token=%zz",
+			expectedQuery:   RedactedValue,
+		},
+		{
+			caseDescription: "should not change query without redacted parameters",
+			query:           "token=secret",
+			expectedQuery:   "token=secret",
+		},
+	} {
+		t.Run(testCase.caseDescription, func(t *testing.T) {
+			// given
+			var buffer bytes.Buffer
+			auditLogger := NewLogger(&buffer, Config{SampleRate: 1, RedactedQueryParameters: testCase.redacted}, log)
+			queried := record
+			queried.Query = testCase.query
+
+			// when
+			auditLogger.Log(queried)
+
+			// then
+			var written Record
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &written))
+			assert.Equal(t, testCase.expectedQuery, written.Query)
+		})
+	}
+}
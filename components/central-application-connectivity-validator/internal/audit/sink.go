@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	// SinkStdout writes the audit log to the standard output, separately from the logs of the validator written to the standard error
+	SinkStdout = "stdout"
+	// SinkStderr writes the audit log to the standard error, along with the logs of the validator
+	SinkStderr = "stderr"
+)
+
+// OpenSink opens the standard stream or appends to the file at the path
+func OpenSink(sink string) (io.WriteCloser, error) {
+	switch sink {
+	case SinkStdout:
+		return nopCloser{os.Stdout}, nil
+	case SinkStderr:
+		return nopCloser{os.Stderr}, nil
+	}
+
+	file, err := os.OpenFile(sink, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("while opening audit log file %s: %s", sink, err)
+	}
+	return file, nil
+}
+
+// nopCloser doesn't close the standard stream on shutdown
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/kyma-project/kyma/common/logging/logger"
 	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/limits"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/metrics"
 	gocache "github.com/patrickmn/go-cache"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -17,14 +19,16 @@ type CacheSync interface {
 type cacheSync struct {
 	client         client.Reader
 	appCache       *gocache.Cache
+	limiter        limits.Limiter
 	log            *logger.Logger
 	controllerName string
 }
 
-func NewCacheSync(log *logger.Logger, client client.Reader, appCache *gocache.Cache, controllerName string) CacheSync {
+func NewCacheSync(log *logger.Logger, client client.Reader, appCache *gocache.Cache, limiter limits.Limiter, controllerName string) CacheSync {
 	return &cacheSync{
 		client:         client,
 		appCache:       appCache,
+		limiter:        limiter,
 		log:            log,
 		controllerName: controllerName,
 	}
@@ -40,7 +44,7 @@ func (c *cacheSync) Sync(ctx context.Context, applicationName string) error {
 				With("name", applicationName).
 				Error("Unable to fetch application: %s", err.Error())
 		} else {
-			c.appCache.Delete(applicationName)
+			c.deleteApplication(applicationName)
 			c.log.WithContext().
 				With("controller", c.controllerName).
 				With("name", applicationName).
@@ -54,7 +58,7 @@ func (c *cacheSync) Sync(ctx context.Context, applicationName string) error {
 func (c *cacheSync) syncApplication(application *v1alpha1.Application) error {
 	key := application.Name
 	if !application.ObjectMeta.DeletionTimestamp.IsZero() {
-		c.appCache.Delete(key)
+		c.deleteApplication(key)
 		c.log.WithContext().
 			With("controller", c.controllerName).
 			With("name", application.Name).
@@ -62,6 +66,13 @@ func (c *cacheSync) syncApplication(application *v1alpha1.Application) error {
 		return nil
 	}
 
+	if err := c.limiter.Update(application.Name, application.Labels); err != nil {
+		c.log.WithContext().
+			With("controller", c.controllerName).
+			With("name", application.Name).
+			Warnf("Invalid limits of the application, default limits are used: %s", err.Error())
+	}
+
 	applicationClientIDs := c.getClientIDsFromResource(application)
 	c.appCache.Set(key, applicationClientIDs, gocache.DefaultExpiration)
 	c.log.WithContext().
@@ -71,6 +82,12 @@ func (c *cacheSync) syncApplication(application *v1alpha1.Application) error {
 	return nil
 }
 
+func (c *cacheSync) deleteApplication(applicationName string) {
+	c.appCache.Delete(applicationName)
+	c.limiter.Delete(applicationName)
+	metrics.DeleteApplication(applicationName)
+}
+
 func (c *cacheSync) getClientIDsFromResource(application *v1alpha1.Application) []string {
 	if application.Spec.CompassMetadata == nil {
 		return []string{}
@@ -13,10 +13,35 @@ import (
 	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
 	"github.com/kyma-project/kyma/components/application-operator/pkg/client/clientset/versioned/fake"
 	applicationconnectorv1alpha1 "github.com/kyma-project/kyma/components/application-operator/pkg/client/clientset/versioned/typed/applicationconnector/v1alpha1"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/limits"
 
 	"testing"
 )
 
+func TestCacheSync_Limits(t *testing.T) {
+	const applicationName = "my-app"
+
+	log, err := logger.New(logger.TEXT, logger.DEBUG)
+	require.NoError(t, err)
+	fc := NewFakeClient()
+	limiter := limits.NewLimiter(limits.Limits{MaxBodySize: 1024})
+	cacheSync := NewCacheSync(log, fc, cache.New(60*time.Second, 60*time.Second), limiter, "test-controller")
+
+	require.NoError(t, fc.Create(&v1alpha1.Application{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   applicationName,
+			Labels: map[string]string{limits.MaxBodySizeLabel: "2Ki"},
+		},
+	}))
+
+	// when
+	err = cacheSync.Sync(context.Background(), applicationName)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, int64(2048), limiter.MaxBodySize(applicationName))
+}
+
 func TestCacheSync(t *testing.T) {
 	const name = "my-app"
 	type setup func(t *testing.T, applicationName string, fc *fakeClient, cache *cache.Cache)
@@ -125,7 +150,7 @@ func TestCacheSync(t *testing.T) {
 				tc.setup(t, applicationName, fc, appCache)
 			}
 
-			cacheSync := NewCacheSync(log, fc, appCache, "test-controller")
+			cacheSync := NewCacheSync(log, fc, appCache, limits.NewLimiter(limits.Limits{}), "test-controller")
 			err = cacheSync.Sync(context.Background(), applicationName)
 			require.NoError(t, err)
 
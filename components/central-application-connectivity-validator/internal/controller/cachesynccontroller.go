@@ -5,6 +5,7 @@ import (
 
 	"github.com/kyma-project/kyma/common/logging/logger"
 	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/limits"
 	gocache "github.com/patrickmn/go-cache"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,9 +21,9 @@ type controller struct {
 	cacheSync CacheSync
 }
 
-func NewController(log *logger.Logger, client client.Client, appCache *gocache.Cache) Controller {
+func NewController(log *logger.Logger, client client.Client, appCache *gocache.Cache, limiter limits.Limiter) Controller {
 	return &controller{
-		cacheSync: NewCacheSync(log, client, appCache, "cache_sync_controller"),
+		cacheSync: NewCacheSync(log, client, appCache, limiter, "cache_sync_controller"),
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func NewHandler() http.Handler {
@@ -11,6 +12,7 @@ func NewHandler() http.Handler {
 	router := mux.NewRouter()
 
 	router.Path("/v1/health").Handler(NewHealthCheckHandler())
+	router.Path("/metrics").Handler(promhttp.Handler()).Methods(http.MethodGet)
 
 	return router
 }
@@ -25,6 +25,10 @@ func errorCodeToHttpStatus(code int) int {
 		return http.StatusForbidden
 	case apperrors.CodeBadRequest:
 		return http.StatusBadRequest
+	case apperrors.CodeTooManyRequests:
+		return http.StatusTooManyRequests
+	case apperrors.CodeTooLarge:
+		return http.StatusRequestEntityTooLarge
 	default:
 		return http.StatusInternalServerError
 	}
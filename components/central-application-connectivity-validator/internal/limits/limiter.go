@@ -0,0 +1,108 @@
+package limits
+
+import (
+	"math"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+type Limiter interface {
+	// Update sets the limits of the Application from its labels. If the labels are invalid, the default limits are set and the error is returned
+	Update(applicationName string, labels map[string]string) error
+	// Delete removes the limits and the state of the Application
+	Delete(applicationName string)
+	// Allow reports whether the request of the Application can be proxied without exceeding its requests-per-second limit
+	Allow(applicationName string) bool
+	// MaxBodySize returns the maximum request body size of the Application, or 0 if the size isn't limited
+	MaxBodySize(applicationName string) int64
+}
+
+type limiter struct {
+	defaults     Limits
+	mutex        sync.Mutex
+	applications map[string]*applicationLimiter
+}
+
+type applicationLimiter struct {
+	limits      Limits
+	rateLimiter *rate.Limiter
+}
+
+// NewLimiter creates the limiter which applies the defaults to the Applications without their own limits
+func NewLimiter(defaults Limits) Limiter {
+	return &limiter{
+		defaults:     defaults,
+		applications: map[string]*applicationLimiter{},
+	}
+}
+
+func (l *limiter) Update(applicationName string, labels map[string]string) error {
+	limits, err := FromLabels(labels, l.defaults)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	application, found := l.applications[applicationName]
+	if !found {
+		l.applications[applicationName] = newApplicationLimiter(limits)
+		return err
+	}
+
+	// the rate limiter is updated in place, so that the requests already counted aren't forgotten on every synchronization
+	if application.limits.RequestsPerSecond != limits.RequestsPerSecond {
+		application.rateLimiter.SetLimit(limit(limits.RequestsPerSecond))
+		application.rateLimiter.SetBurst(burst(limits.RequestsPerSecond))
+	}
+	application.limits = limits
+
+	return err
+}
+
+func (l *limiter) Delete(applicationName string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.applications, applicationName)
+}
+
+func (l *limiter) Allow(applicationName string) bool {
+	return l.get(applicationName).rateLimiter.Allow()
+}
+
+func (l *limiter) MaxBodySize(applicationName string) int64 {
+	return l.get(applicationName).limits.MaxBodySize
+}
+
+// get returns the limiter of the Application, creating it with the defaults if the Application wasn't updated yet
+func (l *limiter) get(applicationName string) *applicationLimiter {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	application, found := l.applications[applicationName]
+	if !found {
+		application = newApplicationLimiter(l.defaults)
+		l.applications[applicationName] = application
+	}
+
+	return application
+}
+
+func newApplicationLimiter(limits Limits) *applicationLimiter {
+	return &applicationLimiter{
+		limits:      limits,
+		rateLimiter: rate.NewLimiter(limit(limits.RequestsPerSecond), burst(limits.RequestsPerSecond)),
+	}
+}
+
+func limit(requestsPerSecond float64) rate.Limit {
+	if requestsPerSecond == 0 {
+		return rate.Inf
+	}
+	return rate.Limit(requestsPerSecond)
+}
+
+// burst allows the requests of one second to arrive at once, and at least one request for limits lower than one request per second
+func burst(requestsPerSecond float64) int {
+	return int(math.Min(math.Max(1, math.Ceil(requestsPerSecond)), math.MaxInt32))
+}
@@ -0,0 +1,88 @@
+package limits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	const applicationName = "commerce"
+
+	t.Run("should apply defaults to application without its own limits", func(t *testing.T) {
+		// given
+		limiter := NewLimiter(Limits{RequestsPerSecond: 2, MaxBodySize: 1024})
+
+		// then
+		assert.True(t, limiter.Allow(applicationName))
+		assert.True(t, limiter.Allow(applicationName))
+		assert.False(t, limiter.Allow(applicationName))
+		assert.Equal(t, int64(1024), limiter.MaxBodySize(applicationName))
+	})
+
+	t.Run("should not limit application when limits are disabled", func(t *testing.T) {
+		// given
+		limiter := NewLimiter(Limits{})
+
+		// then
+		for i := 0; i < 100; i++ {
+			assert.True(t, limiter.Allow(applicationName))
+		}
+		assert.Equal(t, int64(0), limiter.MaxBodySize(applicationName))
+	})
+
+	t.Run("should apply limits from labels", func(t *testing.T) {
+		// given
+		limiter := NewLimiter(Limits{})
+
+		// when
+		err := limiter.Update(applicationName, map[string]string{RequestsPerSecondLabel: "1", MaxBodySizeLabel: "10"})
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, limiter.Allow(applicationName))
+		assert.False(t, limiter.Allow(applicationName))
+		assert.Equal(t, int64(10), limiter.MaxBodySize(applicationName))
+		assert.True(t, limiter.Allow("other"), "limits of other applications should not change")
+	})
+
+	t.Run("should keep rate limiter state when limits are updated", func(t *testing.T) {
+		// given
+		limiter := NewLimiter(Limits{})
+		labels := map[string]string{RequestsPerSecondLabel: "1"}
+		assert.NoError(t, limiter.Update(applicationName, labels))
+		assert.True(t, limiter.Allow(applicationName))
+
+		// when
+		assert.NoError(t, limiter.Update(applicationName, labels))
+
+		// then
+		assert.False(t, limiter.Allow(applicationName))
+	})
+
+	t.Run("should apply defaults when labels are invalid", func(t *testing.T) {
+		// given
+		limiter := NewLimiter(Limits{MaxBodySize: 1024})
+
+		// when
+		err := limiter.Update(applicationName, map[string]string{MaxBodySizeLabel: "large"})
+
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, int64(1024), limiter.MaxBodySize(applicationName))
+	})
+
+	t.Run("should apply defaults after application is deleted", func(t *testing.T) {
+		// given
+		limiter := NewLimiter(Limits{})
+		assert.NoError(t, limiter.Update(applicationName, map[string]string{RequestsPerSecondLabel: "1"}))
+		assert.True(t, limiter.Allow(applicationName))
+
+		// when
+		limiter.Delete(applicationName)
+
+		// then
+		assert.True(t, limiter.Allow(applicationName))
+		assert.True(t, limiter.Allow(applicationName))
+	})
+}
@@ -0,0 +1,47 @@
+// Package limits contains the per-Application limits of the requests proxied by the validator
+package limits
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// RequestsPerSecondLabel is the label of the Application which overrides the default requests-per-second limit
+	RequestsPerSecondLabel = "connectivity-validator.kyma-project.io/requests-per-second"
+	// MaxBodySizeLabel is the label of the Application which overrides the default maximum request body size
+	MaxBodySizeLabel = "connectivity-validator.kyma-project.io/max-body-size"
+)
+
+// Limits holds the limits of the requests of the Application. The zero value of the limit means no limit
+type Limits struct {
+	RequestsPerSecond float64
+	MaxBodySize       int64
+}
+
+// FromLabels returns the defaults overridden by the labels of the Application.
+// If any of the labels is invalid, the defaults are returned along with the error
+func FromLabels(labels map[string]string, defaults Limits) (Limits, error) {
+	limits := defaults
+
+	if value, found := labels[RequestsPerSecondLabel]; found {
+		requestsPerSecond, err := strconv.ParseFloat(value, 64)
+		if err != nil || requestsPerSecond < 0 || math.IsInf(requestsPerSecond, 0) || math.IsNaN(requestsPerSecond) {
+			return defaults, fmt.Errorf("invalid value '%s' of label %s, expected non-negative number", value, RequestsPerSecondLabel)
+		}
+		limits.RequestsPerSecond = requestsPerSecond
+	}
+
+	if value, found := labels[MaxBodySizeLabel]; found {
+		maxBodySize, err := resource.ParseQuantity(value)
+		if err != nil || maxBodySize.Sign() < 0 {
+			return defaults, fmt.Errorf("invalid value '%s' of label %s, expected non-negative quantity", value, MaxBodySizeLabel)
+		}
+		limits.MaxBodySize = maxBodySize.Value()
+	}
+
+	return limits, nil
+}
@@ -0,0 +1,55 @@
+package limits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromLabels(t *testing.T) {
+	defaults := Limits{RequestsPerSecond: 10, MaxBodySize: 1024}
+
+	t.Run("should return defaults when labels are not set", func(t *testing.T) {
+		limits, err := FromLabels(map[string]string{"app": "commerce"}, defaults)
+
+		require.NoError(t, err)
+		assert.Equal(t, defaults, limits)
+	})
+
+	t.Run("should override defaults with labels", func(t *testing.T) {
+		limits, err := FromLabels(map[string]string{
+			RequestsPerSecondLabel: "0.5",
+			MaxBodySizeLabel:       "1Mi",
+		}, defaults)
+
+		require.NoError(t, err)
+		assert.Equal(t, Limits{RequestsPerSecond: 0.5, MaxBodySize: 1024 * 1024}, limits)
+	})
+
+	t.Run("should disable limits set to zero", func(t *testing.T) {
+		limits, err := FromLabels(map[string]string{
+			RequestsPerSecondLabel: "0",
+			MaxBodySizeLabel:       "0",
+		}, defaults)
+
+		require.NoError(t, err)
+		assert.Equal(t, Limits{}, limits)
+	})
+
+	for _, labels := range []map[string]string{
+		{RequestsPerSecondLabel: "fast"},
+		{RequestsPerSecondLabel: "-1"},
+		{RequestsPerSecondLabel: "NaN"},
+		{MaxBodySizeLabel: "large"},
+		{MaxBodySizeLabel: "-1Ki"},
+		{RequestsPerSecondLabel: "5", MaxBodySizeLabel: "large"},
+	} {
+		t.Run("should return defaults and error for invalid labels", func(t *testing.T) {
+			limits, err := FromLabels(labels, defaults)
+
+			assert.Error(t, err)
+			assert.Equal(t, defaults, limits)
+		})
+	}
+}
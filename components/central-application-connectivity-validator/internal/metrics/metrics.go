@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespace = "central_application_connectivity_validator"
+
+	applicationLabel = "application"
+	reasonLabel      = "reason"
+
+	// ReasonRateLimited is the reason of rejecting the request which exceeds the requests-per-second limit of the Application
+	ReasonRateLimited = "rate_limited"
+	// ReasonBodyTooLarge is the reason of rejecting the request whose body exceeds the maximum size of the Application
+	ReasonBodyTooLarge = "body_too_large"
+	// ReasonCertificateRevoked is the reason of rejecting the request with the revoked client certificate
+	ReasonCertificateRevoked = "certificate_revoked"
+)
+
+var (
+	// ProxiedRequests counts requests of the Application forwarded to the Eventing Publisher Proxy
+	ProxiedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "proxied_requests_total",
+		Help:      "Number of requests of the Application forwarded to the Eventing Publisher Proxy",
+	}, []string{applicationLabel})
+
+	// RejectedRequests counts requests of the Application rejected because they exceed its limits or use the revoked certificate
+	RejectedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rejected_requests_total",
+		Help:      "Number of requests of the Application rejected because they exceed its limits or use the revoked client certificate",
+	}, []string{applicationLabel, reasonLabel})
+
+	// RevocationListRefreshTimestamp reports the time of the last successful refresh of the revocation list
+	RevocationListRefreshTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "revocation_list_last_refresh_timestamp_seconds",
+		Help:      "Time of the last successful refresh of the revoked client certificates, in seconds since the Unix epoch",
+	})
+)
+
+// Register registers all validator metrics in the registerer
+func Register(registerer prometheus.Registerer) {
+	registerer.MustRegister(
+		ProxiedRequests,
+		RejectedRequests,
+		RevocationListRefreshTimestamp,
+	)
+}
+
+// RequestProxied counts the request of the Application forwarded to the Eventing Publisher Proxy
+func RequestProxied(applicationName string) {
+	ProxiedRequests.WithLabelValues(applicationName).Inc()
+}
+
+// RequestRejected counts the request of the Application rejected for the reason
+func RequestRejected(applicationName, reason string) {
+	RejectedRequests.WithLabelValues(applicationName, reason).Inc()
+}
+
+// DeleteApplication removes the metrics of the deleted Application
+func DeleteApplication(applicationName string) {
+	ProxiedRequests.DeleteLabelValues(applicationName)
+	RejectedRequests.DeleteLabelValues(applicationName, ReasonRateLimited)
+	RejectedRequests.DeleteLabelValues(applicationName, ReasonBodyTooLarge)
+	RejectedRequests.DeleteLabelValues(applicationName, ReasonCertificateRevoked)
+}
+
+// RevocationListRefreshed records the successful refresh of the revocation list
+func RevocationListRefreshed() {
+	RevocationListRefreshTimestamp.Set(float64(time.Now().Unix()))
+}
@@ -0,0 +1,105 @@
+package revocation
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	crlBlockType         = "X509 CRL"
+	certificateBlockType = "CERTIFICATE"
+)
+
+// loadCRL reads the PEM or DER encoded certificate revocation list from the URL or the file.
+// The CRL is rejected if it isn't signed by the configured CA or if its next update is overdue
+func (l *List) loadCRL(ctx context.Context) (map[string]struct{}, error) {
+	issuer, err := l.readCRLIssuer()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := l.readCRL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if block, _ := pem.Decode(data); block != nil && block.Type == crlBlockType {
+		data = block.Bytes
+	}
+
+	crl, err := x509.ParseRevocationList(data)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing CRL %s: %s", l.config.CRL, err)
+	}
+
+	if err := crl.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("while verifying CRL %s: %s", l.config.CRL, err)
+	}
+	if !crl.NextUpdate.IsZero() && time.Now().After(crl.NextUpdate) {
+		return nil, fmt.Errorf("CRL %s expired at %s", l.config.CRL, crl.NextUpdate.Format(time.RFC3339))
+	}
+
+	serials := make(map[string]struct{}, len(crl.RevokedCertificates))
+	for _, revoked := range crl.RevokedCertificates {
+		serials[revoked.SerialNumber.Text(16)] = struct{}{}
+	}
+
+	return serials, nil
+}
+
+// readCRLIssuer reads the PEM encoded certificate of the CA signing the CRL on every refresh, so that the rotated CA is picked up
+func (l *List) readCRLIssuer() (*x509.Certificate, error) {
+	data, err := os.ReadFile(l.config.CRLIssuer)
+	if err != nil {
+		return nil, fmt.Errorf("while reading CRL issuer certificate: %s", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != certificateBlockType {
+		return nil, fmt.Errorf("while decoding CRL issuer certificate %s: no PEM encoded certificate found", l.config.CRLIssuer)
+	}
+
+	issuer, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing CRL issuer certificate %s: %s", l.config.CRLIssuer, err)
+	}
+	return issuer, nil
+}
+
+func (l *List) readCRL(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(l.config.CRL, "http://") && !strings.HasPrefix(l.config.CRL, "https://") {
+		data, err := os.ReadFile(l.config.CRL)
+		if err != nil {
+			return nil, fmt.Errorf("while reading CRL file: %s", err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.config.CRL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("while creating CRL request: %s", err)
+	}
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("while fetching CRL %s: %s", l.config.CRL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("while fetching CRL %s: unexpected status %s", l.config.CRL, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("while reading CRL %s: %s", l.config.CRL, err)
+	}
+	return data, nil
+}
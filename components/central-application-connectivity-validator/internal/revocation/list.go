@@ -0,0 +1,218 @@
+// Package revocation contains the list of revoked client certificates of the Applications
+package revocation
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma/common/logging/logger"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/metrics"
+)
+
+const (
+	fingerprintLength = 2 * 32 // hex-encoded SHA-256
+	crlFetchTimeout   = 30 * time.Second
+)
+
+// ClientCertificate identifies the client certificate forwarded in the X-Forwarded-Client-Cert header
+type ClientCertificate struct {
+	// Fingerprint is the hex-encoded SHA-256 hash of the certificate
+	Fingerprint string
+	// SerialNumber is nil if the certificate itself isn't forwarded
+	SerialNumber *big.Int
+}
+
+func (c ClientCertificate) String() string {
+	serialNumber := "unknown"
+	if c.SerialNumber != nil {
+		serialNumber = c.SerialNumber.Text(16)
+	}
+	return fmt.Sprintf("serial number %s, fingerprint %s", serialNumber, c.Fingerprint)
+}
+
+type Checker interface {
+	// IsRevoked reports whether the client certificate of the Application is revoked
+	IsRevoked(applicationName string, certificate ClientCertificate) bool
+}
+
+// Config holds the sources of the revoked certificates. Empty sources are disabled
+type Config struct {
+	// ConfigMap lists the revoked serial numbers and fingerprints under the name of the Application
+	ConfigMap types.NamespacedName
+	// CRL is the URL or the path of the certificate revocation list of the CA issuing the client certificates
+	CRL string
+	// CRLIssuer is the path of the PEM encoded certificate of the CA signing the certificate revocation list
+	CRLIssuer     string
+	RefreshPeriod time.Duration
+}
+
+// List is the revocation list refreshed periodically from the configured sources
+type List struct {
+	config     Config
+	reader     client.Reader
+	httpClient *http.Client
+	log        *logger.Logger
+
+	mutex        sync.RWMutex
+	applications map[string]revokedCertificates
+	crlSerials   map[string]struct{}
+}
+
+type revokedCertificates struct {
+	fingerprints map[string]struct{}
+	serials      map[string]struct{}
+}
+
+func NewList(config Config, reader client.Reader, log *logger.Logger) *List {
+	return &List{
+		config:       config,
+		reader:       reader,
+		httpClient:   &http.Client{Timeout: crlFetchTimeout},
+		log:          log,
+		applications: map[string]revokedCertificates{},
+		crlSerials:   map[string]struct{}{},
+	}
+}
+
+func (l *List) IsRevoked(applicationName string, certificate ClientCertificate) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	revoked := l.applications[applicationName]
+	if certificate.Fingerprint != "" {
+		if _, found := revoked.fingerprints[normalizeHex(certificate.Fingerprint)]; found {
+			return true
+		}
+	}
+
+	if certificate.SerialNumber == nil {
+		return false
+	}
+
+	serialNumber := certificate.SerialNumber.Text(16)
+	if _, found := revoked.serials[serialNumber]; found {
+		return true
+	}
+	_, found := l.crlSerials[serialNumber]
+	return found
+}
+
+// Refresh reloads the revoked certificates. The certificates of the source which failed to load are kept
+func (l *List) Refresh(ctx context.Context) error {
+	var errs []string
+
+	if l.config.ConfigMap.Name != "" {
+		applications, err := l.loadConfigMap(ctx)
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			l.mutex.Lock()
+			l.applications = applications
+			l.mutex.Unlock()
+		}
+	}
+
+	if l.config.CRL != "" {
+		crlSerials, err := l.loadCRL(ctx)
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			l.mutex.Lock()
+			l.crlSerials = crlSerials
+			l.mutex.Unlock()
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("while refreshing revocation list: %s", strings.Join(errs, "; "))
+	}
+
+	metrics.RevocationListRefreshed()
+	return nil
+}
+
+// Start refreshes the revoked certificates periodically until the context is done
+func (l *List) Start(ctx context.Context) error {
+	ticker := time.NewTicker(l.config.RefreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := l.Refresh(ctx); err != nil {
+				l.log.WithContext().With("refresher", "revocation_list").Errorf("Failed to refresh, the previous revocation list is used: %s", err.Error())
+			}
+		}
+	}
+}
+
+func (l *List) loadConfigMap(ctx context.Context) (map[string]revokedCertificates, error) {
+	var configMap v1.ConfigMap
+	if err := l.reader.Get(ctx, l.config.ConfigMap, &configMap); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return map[string]revokedCertificates{}, nil
+		}
+		return nil, fmt.Errorf("while reading ConfigMap %s: %s", l.config.ConfigMap, err)
+	}
+
+	applications := make(map[string]revokedCertificates, len(configMap.Data))
+	for applicationName, data := range configMap.Data {
+		revoked, err := parseEntries(data)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing revoked certificates of application %s: %s", applicationName, err)
+		}
+		applications[applicationName] = revoked
+	}
+
+	return applications, nil
+}
+
+// parseEntries parses the serial numbers and the fingerprints separated with white spaces or commas.
+// The entry of 64 hex digits is the SHA-256 fingerprint, the shorter entry is the hex serial number
+func parseEntries(data string) (revokedCertificates, error) {
+	revoked := revokedCertificates{
+		fingerprints: map[string]struct{}{},
+		serials:      map[string]struct{}{},
+	}
+
+	entries := strings.FieldsFunc(data, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+
+	for _, entry := range entries {
+		value := normalizeHex(entry)
+		if len(value) == fingerprintLength {
+			if _, err := hex.DecodeString(value); err != nil {
+				return revokedCertificates{}, fmt.Errorf("invalid fingerprint %s", entry)
+			}
+			revoked.fingerprints[value] = struct{}{}
+			continue
+		}
+
+		serialNumber, ok := new(big.Int).SetString(value, 16)
+		if !ok {
+			return revokedCertificates{}, fmt.Errorf("invalid serial number %s", entry)
+		}
+		revoked.serials[serialNumber.Text(16)] = struct{}{}
+	}
+
+	return revoked, nil
+}
+
+// normalizeHex removes the colons and lowercases the hex value, so that the values printed by different tools are equal
+func normalizeHex(value string) string {
+	return strings.ToLower(strings.ReplaceAll(value, ":", ""))
+}
@@ -0,0 +1,229 @@
+package revocation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	applicationName = "commerce"
+	fingerprint     = "f4cf22fb633d4df500e371daf703d4b4d14a0ea9d69cd631f95f9e6ba840f8ad"
+)
+
+var configMapName = types.NamespacedName{Namespace: "kyma-system", Name: "revoked-certificates"}
+
+func TestList(t *testing.T) {
+	log, err := logger.New(logger.TEXT, logger.ERROR)
+	require.NoError(t, err)
+
+	t.Run("should revoke certificates listed for application", func(t *testing.T) {
+		// given
+		reader := &configMapReader{data: map[string]string{
+			applicationName: "F4:CF:22:FB:63:3D:4D:F5:00:E3:71:DA:F7:03:D4:B4:D1:4A:0E:A9:D6:9C:D6:31:F9:5F:9E:6B:A8:40:F8:AD\n0a1b, 2c",
+		}}
+		list := NewList(Config{ConfigMap: configMapName}, reader, log)
+
+		// when
+		err := list.Refresh(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, list.IsRevoked(applicationName, ClientCertificate{Fingerprint: fingerprint}))
+		assert.True(t, list.IsRevoked(applicationName, ClientCertificate{SerialNumber: big.NewInt(0xa1b)}))
+		assert.True(t, list.IsRevoked(applicationName, ClientCertificate{SerialNumber: big.NewInt(0x2c)}))
+		assert.False(t, list.IsRevoked(applicationName, ClientCertificate{Fingerprint: "abc", SerialNumber: big.NewInt(1)}))
+		assert.False(t, list.IsRevoked("other", ClientCertificate{Fingerprint: fingerprint}), "certificates of other applications should not be revoked")
+	})
+
+	t.Run("should not revoke certificates when ConfigMap does not exist", func(t *testing.T) {
+		// given
+		list := NewList(Config{ConfigMap: configMapName}, &configMapReader{}, log)
+
+		// when
+		err := list.Refresh(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, list.IsRevoked(applicationName, ClientCertificate{Fingerprint: fingerprint}))
+	})
+
+	t.Run("should keep previous certificates when ConfigMap is invalid", func(t *testing.T) {
+		// given
+		reader := &configMapReader{data: map[string]string{applicationName: fingerprint}}
+		list := NewList(Config{ConfigMap: configMapName}, reader, log)
+		require.NoError(t, list.Refresh(context.Background()))
+
+		// when
+		reader.data = map[string]string{applicationName: "not-a-serial-number"}
+		err := list.Refresh(context.Background())
+
+		// then
+		require.Error(t, err)
+		assert.True(t, list.IsRevoked(applicationName, ClientCertificate{Fingerprint: fingerprint}))
+	})
+
+	t.Run("should revoke certificates listed in CRL served over HTTP", func(t *testing.T) {
+		// given
+		ca := newTestCA(t)
+		crl := ca.createCRL(t, time.Now().Add(time.Hour), big.NewInt(0x1234))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(crl)
+		}))
+		defer server.Close()
+		list := NewList(Config{CRL: server.URL, CRLIssuer: ca.path}, nil, log)
+
+		// when
+		err := list.Refresh(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, list.IsRevoked(applicationName, ClientCertificate{SerialNumber: big.NewInt(0x1234)}))
+		assert.True(t, list.IsRevoked("other", ClientCertificate{SerialNumber: big.NewInt(0x1234)}))
+		assert.False(t, list.IsRevoked(applicationName, ClientCertificate{SerialNumber: big.NewInt(0x1235)}))
+	})
+
+	t.Run("should revoke certificates listed in PEM encoded CRL file", func(t *testing.T) {
+		// given
+		ca := newTestCA(t)
+		path := filepath.Join(t.TempDir(), "ca.crl")
+		crl := ca.createCRL(t, time.Now().Add(time.Hour), big.NewInt(0x1234))
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: crlBlockType, Bytes: crl}), 0600))
+		list := NewList(Config{CRL: path, CRLIssuer: ca.path}, nil, log)
+
+		// when
+		err := list.Refresh(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, list.IsRevoked(applicationName, ClientCertificate{SerialNumber: big.NewInt(0x1234)}))
+	})
+
+	t.Run("should keep previous CRL when new CRL is rejected", func(t *testing.T) {
+		ca := newTestCA(t)
+		testCases := []struct {
+			name     string
+			givenCRL []byte
+		}{
+			{
+				name:     "CRL signed by other CA",
+				givenCRL: newTestCA(t).createCRL(t, time.Now().Add(time.Hour), big.NewInt(0x1235)),
+			},
+			{
+				name:     "expired CRL",
+				givenCRL: ca.createCRL(t, time.Now().Add(-time.Minute), big.NewInt(0x1235)),
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				// given
+				crl := ca.createCRL(t, time.Now().Add(time.Hour), big.NewInt(0x1234))
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write(crl)
+				}))
+				defer server.Close()
+				list := NewList(Config{CRL: server.URL, CRLIssuer: ca.path}, nil, log)
+				require.NoError(t, list.Refresh(context.Background()))
+				crl = tc.givenCRL
+
+				// when
+				err := list.Refresh(context.Background())
+
+				// then
+				assert.Error(t, err)
+				assert.True(t, list.IsRevoked(applicationName, ClientCertificate{SerialNumber: big.NewInt(0x1234)}))
+				assert.False(t, list.IsRevoked(applicationName, ClientCertificate{SerialNumber: big.NewInt(0x1235)}))
+			})
+		}
+	})
+
+	t.Run("should return error when CRL cannot be fetched", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		list := NewList(Config{CRL: server.URL, CRLIssuer: newTestCA(t).path}, nil, log)
+
+		// when
+		err := list.Refresh(context.Background())
+
+		// then
+		assert.Error(t, err)
+	})
+}
+
+type configMapReader struct {
+	client.Reader
+	data map[string]string
+}
+
+func (r *configMapReader) Get(_ context.Context, key client.ObjectKey, obj client.Object) error {
+	if r.data == nil || key != configMapName {
+		return k8serrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+	}
+	obj.(*v1.ConfigMap).Data = r.data
+	return nil
+}
+
+// testCA signs the CRLs. Its PEM encoded certificate is written to the path
+type testCA struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	path        string
+}
+
+func newTestCA(t *testing.T) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCRLSign | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: certificateBlockType, Bytes: der}), 0600))
+	return testCA{certificate: certificate, key: key, path: path}
+}
+
+func (ca testCA) createCRL(t *testing.T, nextUpdate time.Time, serialNumber *big.Int) []byte {
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:              big.NewInt(1),
+		ThisUpdate:          nextUpdate.Add(-time.Hour),
+		NextUpdate:          nextUpdate,
+		RevokedCertificates: []pkix.RevokedCertificate{{SerialNumber: serialNumber, RevocationTime: time.Now().Add(-time.Hour)}},
+	}, ca.certificate, ca.key)
+	require.NoError(t, err)
+	return crl
+}
@@ -1,21 +1,27 @@
 package validationproxy
 
 import (
+	"bytes"
 	"crypto/x509/pkix"
+	"io"
 	"net/http"
 	"net/http/httputil"
-	"regexp"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/kyma-project/kyma/common/logging/logger"
 	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/audit"
 	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/httptools"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/limits"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/metrics"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/revocation"
 )
 
 const (
 	CertificateInfoHeader = "X-Forwarded-Client-Cert"
+	RetryAfterHeader      = "Retry-After"
 
 	handlerName = "validation_proxy_handler"
 )
@@ -41,7 +47,10 @@ type proxyHandler struct {
 
 	log *logger.Logger
 
-	cache Cache
+	cache       Cache
+	limiter     limits.Limiter
+	revocations revocation.Checker
+	auditLog    audit.Logger
 }
 
 func NewProxyHandler(
@@ -52,6 +61,9 @@ func NewProxyHandler(
 	eventingPublisherHost string,
 	eventingDestinationPath string,
 	cache Cache,
+	limiter limits.Limiter,
+	revocations revocation.Checker,
+	auditLog audit.Logger,
 	log *logger.Logger) *proxyHandler {
 	return &proxyHandler{
 		appNamePlaceholder:       appNamePlaceholder,
@@ -63,12 +75,31 @@ func NewProxyHandler(
 		legacyEventsProxy: createReverseProxy(log, eventingPublisherHost, withEmptyRequestHost, withEmptyXFwdClientCert, withHTTPScheme),
 		cloudEventsProxy:  createReverseProxy(log, eventingPublisherHost, withRewriteBaseURL(eventingDestinationPath), withEmptyRequestHost, withEmptyXFwdClientCert, withHTTPScheme),
 
-		cache: cache,
-		log:   log,
+		cache:       cache,
+		limiter:     limiter,
+		revocations: revocations,
+		auditLog:    auditLog,
+		log:         log,
 	}
 }
 
 func (ph *proxyHandler) ProxyAppConnectorRequests(w http.ResponseWriter, r *http.Request) {
+	record := &audit.Record{
+		Time:   time.Now(),
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+	}
+	recorder, body := newResponseRecorder(w), newBodyCounter(r)
+	w = recorder
+	defer func() {
+		record.Status = recorder.status
+		record.RequestBytes = body.count
+		record.ResponseBytes = recorder.bytes
+		record.LatencyMillis = float64(time.Since(record.Time).Microseconds()) / 1000
+		ph.auditLog.Log(*record)
+	}()
+
 	certInfoData := r.Header.Get(CertificateInfoHeader)
 	if certInfoData == "" {
 		httptools.RespondWithError(ph.log.WithTracing(r.Context()).With("handler", handlerName), w, apperrors.Internal("%s header not found", CertificateInfoHeader))
@@ -80,6 +111,7 @@ func (ph *proxyHandler) ProxyAppConnectorRequests(w http.ResponseWriter, r *http
 		httptools.RespondWithError(ph.log.WithTracing(r.Context()).With("handler", handlerName), w, apperrors.BadRequest("application name not specified"))
 		return
 	}
+	record.Application = applicationName
 
 	ph.log.WithTracing(r.Context()).With("handler", handlerName).With("application", applicationName).With("proxyPath", r.URL.Path).Infof("Proxying request for application...")
 
@@ -89,22 +121,79 @@ func (ph *proxyHandler) ProxyAppConnectorRequests(w http.ResponseWriter, r *http
 		return
 	}
 
-	subjects := extractSubjects(certInfoData)
+	certificates := validCertificates(extractCertificates(certInfoData), applicationClientIDs, applicationName)
 
-	if !hasValidSubject(subjects, applicationClientIDs, applicationName) {
+	if len(certificates) == 0 {
 		httptools.RespondWithError(ph.log.WithTracing(r.Context()).With("handler", handlerName).With("applicationName", applicationName), w, apperrors.Forbidden("no valid subject found"))
 		return
 	}
+	record.Subject = certificates[0].subject
+	if len(applicationClientIDs) > 0 {
+		record.ClientID = parseSubject(certificates[0].subject).CommonName
+	}
+
+	for _, c := range certificates {
+		if ph.revocations.IsRevoked(applicationName, c.certificate) {
+			metrics.RequestRejected(applicationName, metrics.ReasonCertificateRevoked)
+			httptools.RespondWithError(ph.log.WithTracing(r.Context()).With("handler", handlerName).With("applicationName", applicationName), w, apperrors.Forbidden("client certificate with %s is revoked", c.certificate))
+			return
+		}
+	}
 
-	reverseProxy, err := ph.mapRequestToProxy(r.URL.Path, applicationName)
+	reverseProxy, target, err := ph.mapRequestToProxy(r.URL.Path, applicationName)
 	if err != nil {
 		httptools.RespondWithError(ph.log.WithTracing(r.Context()).With("handler", handlerName).With("applicationName", applicationName), w, err)
 		return
 	}
+	record.Target = target
+
+	if !ph.limiter.Allow(applicationName) {
+		metrics.RequestRejected(applicationName, metrics.ReasonRateLimited)
+		w.Header().Set(RetryAfterHeader, "1")
+		httptools.RespondWithError(ph.log.WithTracing(r.Context()).With("handler", handlerName).With("applicationName", applicationName), w, apperrors.TooManyRequests("requests-per-second limit of application %s exceeded", applicationName))
+		return
+	}
+
+	if err := limitBody(r, ph.limiter.MaxBodySize(applicationName)); err != nil {
+		if err.Code() == apperrors.CodeTooLarge {
+			metrics.RequestRejected(applicationName, metrics.ReasonBodyTooLarge)
+		}
+		httptools.RespondWithError(ph.log.WithTracing(r.Context()).With("handler", handlerName).With("applicationName", applicationName), w, err)
+		return
+	}
 
+	metrics.RequestProxied(applicationName)
 	reverseProxy.ServeHTTP(w, r)
 }
 
+// limitBody rejects the request whose body exceeds the maximum size. The body of unknown length is read into memory up to the maximum size
+func limitBody(r *http.Request, maxBodySize int64) apperrors.AppError {
+	if maxBodySize == 0 || r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+
+	if r.ContentLength > maxBodySize {
+		return apperrors.TooLarge("request body of %d bytes exceeds the limit of %d bytes", r.ContentLength, maxBodySize)
+	}
+
+	// the server doesn't read more than Content-Length bytes, so only the body of unknown length needs to be checked
+	if r.ContentLength >= 0 {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return apperrors.BadRequest("while reading request body: %s", err)
+	}
+	if int64(len(body)) > maxBodySize {
+		return apperrors.TooLarge("request body exceeds the limit of %d bytes", maxBodySize)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	return nil
+}
+
 func (ph *proxyHandler) getCompassMetadataClientIDs(applicationName string) ([]string, apperrors.AppError) {
 	applicationClientIDs, found := ph.getClientIDsFromCache(applicationName)
 	if !found {
@@ -122,22 +211,23 @@ func (ph *proxyHandler) getClientIDsFromCache(applicationName string) ([]string,
 	return clientIDs.([]string), found
 }
 
-func (ph *proxyHandler) mapRequestToProxy(path string, applicationName string) (*httputil.ReverseProxy, apperrors.AppError) {
+// mapRequestToProxy returns the proxy and the audit log target of the request
+func (ph *proxyHandler) mapRequestToProxy(path string, applicationName string) (*httputil.ReverseProxy, string, apperrors.AppError) {
 	switch {
 	// legacy-events reaching /{application}/v1/events are routed to /{application}/v1/events endpoint of event-publisher-proxy
 	case strings.HasPrefix(path, ph.getApplicationPrefix(ph.eventingPathPrefixV1, applicationName)):
-		return ph.legacyEventsProxy, nil
+		return ph.legacyEventsProxy, audit.TargetLegacyEvents, nil
 
 	// cloud-events reaching /{application}/v2/events or /{application}/events are routed to /publish endpoint of event-publisher-proxy
 	case strings.HasPrefix(path, ph.getApplicationPrefix(ph.eventingPathPrefixV2, applicationName)):
-		return ph.cloudEventsProxy, nil
+		return ph.cloudEventsProxy, audit.TargetCloudEvents, nil
 
 	// cloud-events reaching /{application}/events are routed to /publish endpoint of event-publisher-proxy
 	case strings.HasPrefix(path, ph.getApplicationPrefix(ph.eventingPathPrefixEvents, applicationName)):
-		return ph.cloudEventsProxy, nil
+		return ph.cloudEventsProxy, audit.TargetCloudEvents, nil
 	}
 
-	return nil, apperrors.NotFound("could not determine destination host, requested resource not found")
+	return nil, "", apperrors.NotFound("could not determine destination host, requested resource not found")
 }
 
 func (ph *proxyHandler) getApplicationPrefix(path string, applicationName string) string {
@@ -147,18 +237,18 @@ func (ph *proxyHandler) getApplicationPrefix(path string, applicationName string
 	return path
 }
 
-func hasValidSubject(subjects, applicationClientIDs []string, appName string) bool {
+// validCertificates returns the client certificates whose subjects match the Application
+func validCertificates(certificates []clientCertificate, applicationClientIDs []string, appName string) []clientCertificate {
 	subjectValidator := newSubjectValidator(applicationClientIDs, appName)
 
-	for _, s := range subjects {
-		parsedSubject := parseSubject(s)
-
-		if subjectValidator(parsedSubject) {
-			return true
+	var valid []clientCertificate
+	for _, c := range certificates {
+		if subjectValidator(parseSubject(c.subject)) {
+			valid = append(valid, c)
 		}
 	}
 
-	return false
+	return valid
 }
 
 func newSubjectValidator(applicationClientIDs []string, appName string) func(subject pkix.Name) bool {
@@ -180,31 +270,6 @@ func newSubjectValidator(applicationClientIDs []string, appName string) func(sub
 	}
 }
 
-func extractSubjects(certInfoData string) []string {
-	var subjects []string
-
-	subjectRegex := regexp.MustCompile(`Subject="(.*?)"`)
-	subjectMatches := subjectRegex.FindAllStringSubmatch(certInfoData, -1)
-
-	for _, subjectMatch := range subjectMatches {
-		subject := get(subjectMatch, 1)
-
-		if subject != "" {
-			subjects = append(subjects, subject)
-		}
-	}
-
-	return subjects
-}
-
-func get(array []string, index int) string {
-	if len(array) > index {
-		return array[index]
-	}
-
-	return ""
-}
-
 func parseSubject(rawSubject string) pkix.Name {
 	subjectInfo := extractSubject(rawSubject)
 
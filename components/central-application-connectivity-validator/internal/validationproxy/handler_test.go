@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -20,6 +21,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	appconnv1alpha1 "github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/audit"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/limits"
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/revocation"
 )
 
 const (
@@ -201,6 +205,9 @@ func TestProxyHandler_ProxyAppConnectorRequests(t *testing.T) {
 				eventPublisherProxyHost,
 				eventingDestinationPathPublish,
 				idCache,
+				limits.NewLimiter(limits.Limits{}),
+				revocation.NewList(revocation.Config{}, nil, log),
+				audit.NewNopLogger(),
 				log)
 
 			t.Run("should proxy eventing V1 request when "+testCase.caseDescription, func(t *testing.T) {
@@ -295,6 +302,9 @@ func TestProxyHandler_ProxyAppConnectorRequests(t *testing.T) {
 				eventingPublisherHost,
 				eventingDestinationPathPublish,
 				idCache,
+				limits.NewLimiter(limits.Limits{}),
+				revocation.NewList(revocation.Config{}, nil, log),
+				audit.NewNopLogger(),
 				log)
 
 			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/v1/metadata/services", testCase.application.Name), nil)
@@ -331,6 +341,9 @@ func TestProxyHandler_ProxyAppConnectorRequests(t *testing.T) {
 			eventingPublisherHost,
 			eventingDestinationPathPublish,
 			idCache,
+			limits.NewLimiter(limits.Limits{}),
+			revocation.NewList(revocation.Config{}, nil, log),
+			audit.NewNopLogger(),
 			log)
 
 		req, err := http.NewRequest(http.MethodGet, "/path", nil)
@@ -369,6 +382,9 @@ func TestProxyHandler_ProxyAppConnectorRequests(t *testing.T) {
 			eventingPublisherHost,
 			eventingDestinationPathPublish,
 			idCache,
+			limits.NewLimiter(limits.Limits{}),
+			revocation.NewList(revocation.Config{}, nil, log),
+			audit.NewNopLogger(),
 			log)
 
 		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/v1/bad/path", applicationMetaName), nil)
@@ -409,6 +425,9 @@ func TestProxyHandler_ProxyAppConnectorRequests(t *testing.T) {
 					eventingPublisherHost,
 					eventingDestinationPathPublish,
 					idCache,
+					limits.NewLimiter(limits.Limits{}),
+					revocation.NewList(revocation.Config{}, nil, log),
+					audit.NewNopLogger(),
 					log)
 				eventTitle := "my-event-1"
 
@@ -457,6 +476,9 @@ func TestProxyHandler_ProxyAppConnectorRequests(t *testing.T) {
 					eventPublisherProxyHost, // For a BEB enabled cluster requests to /v2 and /events should be forwarded to Event Publisher Proxy
 					eventingDestinationPathPublish,
 					idCache,
+					limits.NewLimiter(limits.Limits{}),
+					revocation.NewList(revocation.Config{}, nil, log),
+					audit.NewNopLogger(),
 					log)
 
 				eventPublisherProxyHandler.PathPrefix("/publish").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -501,6 +523,9 @@ func TestProxyHandler_ProxyAppConnectorRequests(t *testing.T) {
 					eventPublisherProxyHost, // For a BEB enabled cluster requests to /v2 and /events should be forwarded to Event Publisher Proxy
 					eventingDestinationPathPublish,
 					idCache,
+					limits.NewLimiter(limits.Limits{}),
+					revocation.NewList(revocation.Config{}, nil, log),
+					audit.NewNopLogger(),
 					log)
 
 				eventPublisherProxyHandler.Path("/publish").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -539,6 +564,182 @@ func TestProxyHandler_ProxyAppConnectorRequests(t *testing.T) {
 
 }
 
+func TestProxyHandler_Limits(t *testing.T) {
+	log, err := logger.New(logger.TEXT, logger.ERROR)
+	require.NoError(t, err)
+
+	certInfoHeader := `Hash=f4cf22fb633d4df500e371daf703d4b4d14a0ea9d69cd631f95f9e6ba840f8ad;Subject="CN=test-application,OU=OrgUnit,O=Organization,L=Waldorf,ST=Waldorf,C=DE";URI=`
+
+	var receivedBodies []string
+	eventPublisherProxyHandler := mux.NewRouter()
+	eventPublisherProxyHandler.PathPrefix("/{application}/v1/events").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		receivedBodies = append(receivedBodies, string(body))
+
+		w.WriteHeader(http.StatusOK)
+	})
+	eventPublisherProxyServer := httptest.NewServer(eventPublisherProxyHandler)
+	defer eventPublisherProxyServer.Close()
+	eventPublisherProxyHost := strings.TrimPrefix(eventPublisherProxyServer.URL, "http://")
+
+	newProxyHandler := func(limiter limits.Limiter) *proxyHandler {
+		idCache := cache.New(time.Minute, time.Minute)
+		idCache.Set(applicationName, []string{}, cache.NoExpiration)
+
+		return NewProxyHandler(
+			appNamePlaceholder,
+			eventingPathPrefixV1,
+			eventingPathPrefixV2,
+			eventingPathPrefixEvents,
+			eventPublisherProxyHost,
+			eventingDestinationPathPublish,
+			idCache,
+			limiter,
+			revocation.NewList(revocation.Config{}, nil, log),
+			audit.NewNopLogger(),
+			log)
+	}
+
+	newRequest := func(body io.Reader) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/v1/events", applicationName), body)
+		require.NoError(t, err)
+		req.Header.Set(CertificateInfoHeader, certInfoHeader)
+		return mux.SetURLVars(req, map[string]string{"application": applicationName})
+	}
+
+	t.Run("should return 429 when requests-per-second limit is exceeded", func(t *testing.T) {
+		// given
+		limiter := limits.NewLimiter(limits.Limits{})
+		require.NoError(t, limiter.Update(applicationName, map[string]string{limits.RequestsPerSecondLabel: "1"}))
+		proxyHandler := newProxyHandler(limiter)
+
+		first := httptest.NewRecorder()
+		proxyHandler.ProxyAppConnectorRequests(first, newRequest(strings.NewReader("event")))
+
+		// when
+		second := httptest.NewRecorder()
+		proxyHandler.ProxyAppConnectorRequests(second, newRequest(strings.NewReader("event")))
+
+		// then
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusTooManyRequests, second.Code)
+		assert.Equal(t, "1", second.Header().Get(RetryAfterHeader))
+	})
+
+	t.Run("should return 413 when body is larger than maximum size", func(t *testing.T) {
+		// given
+		proxyHandler := newProxyHandler(limits.NewLimiter(limits.Limits{MaxBodySize: 4}))
+		recorder := httptest.NewRecorder()
+
+		// when
+		proxyHandler.ProxyAppConnectorRequests(recorder, newRequest(strings.NewReader("event")))
+
+		// then
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	})
+
+	t.Run("should return 413 when body of unknown length is larger than maximum size", func(t *testing.T) {
+		// given
+		proxyHandler := newProxyHandler(limits.NewLimiter(limits.Limits{MaxBodySize: 4}))
+		req := newRequest(io.NopCloser(strings.NewReader("event")))
+		req.ContentLength = -1
+		recorder := httptest.NewRecorder()
+
+		// when
+		proxyHandler.ProxyAppConnectorRequests(recorder, req)
+
+		// then
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	})
+
+	t.Run("should proxy body of unknown length within maximum size", func(t *testing.T) {
+		// given
+		receivedBodies = nil
+		proxyHandler := newProxyHandler(limits.NewLimiter(limits.Limits{MaxBodySize: 5}))
+		req := newRequest(io.NopCloser(strings.NewReader("event")))
+		req.ContentLength = -1
+		recorder := httptest.NewRecorder()
+
+		// when
+		proxyHandler.ProxyAppConnectorRequests(recorder, req)
+
+		// then
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, []string{"event"}, receivedBodies)
+	})
+}
+
+type revocationsStub map[string]bool
+
+func (r revocationsStub) IsRevoked(applicationName string, certificate revocation.ClientCertificate) bool {
+	return r[applicationName+"/"+certificate.Fingerprint]
+}
+
+func TestProxyHandler_Revocation(t *testing.T) {
+	log, err := logger.New(logger.TEXT, logger.ERROR)
+	require.NoError(t, err)
+
+	const fingerprint = "f4cf22fb633d4df500e371daf703d4b4d14a0ea9d69cd631f95f9e6ba840f8ad"
+	certInfoHeader := `Hash=` + fingerprint + `;Subject="CN=test-application,OU=OrgUnit,O=Organization,L=Waldorf,ST=Waldorf,C=DE";URI=`
+
+	eventPublisherProxyHandler := mux.NewRouter()
+	eventPublisherProxyHandler.PathPrefix("/{application}/v1/events").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	eventPublisherProxyServer := httptest.NewServer(eventPublisherProxyHandler)
+	defer eventPublisherProxyServer.Close()
+	eventPublisherProxyHost := strings.TrimPrefix(eventPublisherProxyServer.URL, "http://")
+
+	for _, testCase := range []struct {
+		caseDescription string
+		revocations     revocationsStub
+		expectedStatus  int
+	}{
+		{
+			caseDescription: "should return 403 when client certificate is revoked",
+			revocations:     revocationsStub{applicationName + "/" + fingerprint: true},
+			expectedStatus:  http.StatusForbidden,
+		},
+		{
+			caseDescription: "should proxy request when certificate is revoked for other application",
+			revocations:     revocationsStub{"other-application/" + fingerprint: true},
+			expectedStatus:  http.StatusOK,
+		},
+	} {
+		t.Run(testCase.caseDescription, func(t *testing.T) {
+			// given
+			idCache := cache.New(time.Minute, time.Minute)
+			idCache.Set(applicationName, []string{}, cache.NoExpiration)
+
+			proxyHandler := NewProxyHandler(
+				appNamePlaceholder,
+				eventingPathPrefixV1,
+				eventingPathPrefixV2,
+				eventingPathPrefixEvents,
+				eventPublisherProxyHost,
+				eventingDestinationPathPublish,
+				idCache,
+				limits.NewLimiter(limits.Limits{}),
+				testCase.revocations,
+				audit.NewNopLogger(),
+				log)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/v1/events", applicationName), strings.NewReader("event"))
+			require.NoError(t, err)
+			req.Header.Set(CertificateInfoHeader, certInfoHeader)
+			req = mux.SetURLVars(req, map[string]string{"application": applicationName})
+			recorder := httptest.NewRecorder()
+
+			// when
+			proxyHandler.ProxyAppConnectorRequests(recorder, req)
+
+			// then
+			assert.Equal(t, testCase.expectedStatus, recorder.Code)
+		})
+	}
+}
+
 func TestProxyHandler_ReplaceAppNamePlaceholder(t *testing.T) {
 	log, err := logger.New(logger.TEXT, logger.ERROR)
 	if err != nil {
@@ -554,9 +755,98 @@ func TestProxyHandler_ReplaceAppNamePlaceholder(t *testing.T) {
 		"N/A",
 		"N/A",
 		idCache,
+		limits.NewLimiter(limits.Limits{}),
+		revocation.NewList(revocation.Config{}, nil, log),
+		audit.NewNopLogger(),
 		log)
 
 	assert.Equal(t, "/commerce-mock/v1/events", ph.getApplicationPrefix(ph.eventingPathPrefixV1, "commerce-mock"))
 	assert.Equal(t, "/commerce-mock/v2/events", ph.getApplicationPrefix(ph.eventingPathPrefixV2, "commerce-mock"))
 	assert.Equal(t, "/commerce-mock/events", ph.getApplicationPrefix(ph.eventingPathPrefixEvents, "commerce-mock"))
 }
+
+func TestProxyHandler_AuditLog(t *testing.T) {
+	log, err := logger.New(logger.TEXT, logger.ERROR)
+	require.NoError(t, err)
+
+	eventPublisherProxyHandler := mux.NewRouter()
+	eventPublisherProxyHandler.PathPrefix("/publish").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	eventPublisherProxyServer := httptest.NewServer(eventPublisherProxyHandler)
+	defer eventPublisherProxyServer.Close()
+	eventPublisherProxyHost := strings.TrimPrefix(eventPublisherProxyServer.URL, "http://")
+
+	for _, testCase := range []struct {
+		caseDescription string
+		certInfoHeader  string
+		expectedRecord  audit.Record
+	}{
+		{
+			caseDescription: "should write audit log record of proxied request",
+			certInfoHeader:  `Hash=f4cf22fb633d4df500e371daf703d4b4d14a0ea9d69cd631f95f9e6ba840f8ad;Subject="CN=test-application-id,OU=OrgUnit,O=Organization,L=Waldorf,ST=Waldorf,C=DE";URI=`,
+			expectedRecord: audit.Record{
+				Application:  applicationMetaName,
+				Subject:      "CN=test-application-id,OU=OrgUnit,O=Organization,L=Waldorf,ST=Waldorf,C=DE",
+				ClientID:     applicationID,
+				Method:       http.MethodPost,
+				Path:         "/test-application-meta/events",
+				Query:        "token=" + audit.RedactedValue,
+				Target:       audit.TargetCloudEvents,
+				Status:       http.StatusOK,
+				RequestBytes: int64(len("event")),
+			},
+		},
+		{
+			caseDescription: "should write audit log record of rejected request",
+			certInfoHeader:  `Hash=f4cf22fb633d4df500e371daf703d4b4d14a0ea9d69cd631f95f9e6ba840f8ad;Subject="CN=other-application,OU=OrgUnit,O=Organization,L=Waldorf,ST=Waldorf,C=DE";URI=`,
+			expectedRecord: audit.Record{
+				Application: applicationMetaName,
+				Method:      http.MethodPost,
+				Path:        "/test-application-meta/events",
+				Query:       "token=" + audit.RedactedValue,
+				Status:      http.StatusForbidden,
+			},
+		},
+	} {
+		t.Run(testCase.caseDescription, func(t *testing.T) {
+			// given
+			idCache := cache.New(time.Minute, time.Minute)
+			idCache.Set(applicationMetaName, []string{applicationID}, cache.NoExpiration)
+
+			var auditLog bytes.Buffer
+			proxyHandler := NewProxyHandler(
+				appNamePlaceholder,
+				eventingPathPrefixV1,
+				eventingPathPrefixV2,
+				eventingPathPrefixEvents,
+				eventPublisherProxyHost,
+				eventingDestinationPathPublish,
+				idCache,
+				limits.NewLimiter(limits.Limits{}),
+				revocation.NewList(revocation.Config{}, nil, log),
+				audit.NewLogger(&auditLog, audit.Config{SampleRate: 1, RedactedQueryParameters: []string{"token"}}, log),
+				log)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/events?token=secret", applicationMetaName), strings.NewReader("event"))
+			require.NoError(t, err)
+			req.Header.Set(CertificateInfoHeader, testCase.certInfoHeader)
+			req = mux.SetURLVars(req, map[string]string{"application": applicationMetaName})
+			recorder := httptest.NewRecorder()
+
+			// when
+			proxyHandler.ProxyAppConnectorRequests(recorder, req)
+
+			// then
+			var record audit.Record
+			require.NoError(t, json.Unmarshal(auditLog.Bytes(), &record))
+			assert.False(t, record.Time.IsZero())
+			assert.GreaterOrEqual(t, record.LatencyMillis, float64(0))
+			assert.Equal(t, int64(recorder.Body.Len()), record.ResponseBytes)
+			record.Time, record.LatencyMillis, record.ResponseBytes = time.Time{}, 0, 0
+			assert.Equal(t, testCase.expectedRecord, record)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package validationproxy
+
+import (
+	"io"
+	"net/http"
+)
+
+// responseRecorder remembers the status code and the size of the response for the audit log
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	r.status = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *responseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// bodyCounter counts the bytes of the request body read by the handler and the proxy
+type bodyCounter struct {
+	io.ReadCloser
+	count int64
+}
+
+// newBodyCounter replaces the body of the request with the counter. The empty body isn't replaced, so that it's still recognized as empty
+func newBodyCounter(r *http.Request) *bodyCounter {
+	counter := &bodyCounter{}
+	if r.Body != nil && r.Body != http.NoBody {
+		counter.ReadCloser = r.Body
+		r.Body = counter
+	}
+	return counter
+}
+
+func (c *bodyCounter) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.count += int64(n)
+	return n, err
+}
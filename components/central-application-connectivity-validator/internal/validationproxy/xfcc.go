@@ -0,0 +1,107 @@
+package validationproxy
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"net/url"
+	"strings"
+
+	"github.com/kyma-project/kyma/components/central-application-connectivity-validator/internal/revocation"
+)
+
+// clientCertificate is the element of the X-Forwarded-Client-Cert header describing the client certificate of one proxy hop
+type clientCertificate struct {
+	subject     string
+	certificate revocation.ClientCertificate
+}
+
+// extractCertificates returns the elements of the X-Forwarded-Client-Cert header with non-empty subjects.
+// The serial number is known only if the proxy forwards the certificate in the Cert field
+func extractCertificates(certInfoData string) []clientCertificate {
+	var certificates []clientCertificate
+
+	for _, element := range splitQuoted(certInfoData, ',') {
+		var certificate clientCertificate
+
+		for _, pair := range splitQuoted(element, ';') {
+			key, value, found := strings.Cut(pair, "=")
+			if !found {
+				continue
+			}
+
+			value = unquote(value)
+			switch key {
+			case "Subject":
+				certificate.subject = value
+			case "Hash":
+				certificate.certificate.Fingerprint = strings.ToLower(value)
+			case "Cert":
+				if forwarded, ok := parseCertificate(value); ok {
+					certificate.certificate = forwarded
+				}
+			}
+		}
+
+		if certificate.subject != "" {
+			certificates = append(certificates, certificate)
+		}
+	}
+
+	return certificates
+}
+
+// splitQuoted splits the value on the separator outside the quoted strings
+func splitQuoted(value string, separator rune) []string {
+	var parts []string
+	var quoted, escaped bool
+	start := 0
+
+	for i, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == separator && !quoted:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, value[start:])
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`)
+	}
+	return value
+}
+
+// parseCertificate parses the URL-encoded PEM certificate forwarded in the Cert field
+func parseCertificate(value string) (revocation.ClientCertificate, bool) {
+	decoded, err := url.PathUnescape(value)
+	if err != nil {
+		return revocation.ClientCertificate{}, false
+	}
+
+	block, _ := pem.Decode([]byte(decoded))
+	if block == nil {
+		return revocation.ClientCertificate{}, false
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return revocation.ClientCertificate{}, false
+	}
+
+	fingerprint := sha256.Sum256(certificate.Raw)
+	return revocation.ClientCertificate{
+		Fingerprint:  hex.EncodeToString(fingerprint[:]),
+		SerialNumber: certificate.SerialNumber,
+	}, true
+}
@@ -0,0 +1,79 @@
+package validationproxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractCertificates(t *testing.T) {
+	t.Run("should extract subjects and fingerprints of elements", func(t *testing.T) {
+		// given
+		certInfoData := `Hash=F4CF22FB633D4DF500E371DAF703D4B4D14A0EA9D69CD631F95F9E6BA840F8AD;Subject="CN=test-application,OU=OrgUnit,O=Organization,L=Waldorf,ST=Waldorf,C=DE";` +
+			`URI=,By=spiffe://cluster.local/ns/kyma-integration/sa/default;` +
+			`Hash=6d1f9f3a6ac94ff925841aeb9c15bb3323014e3da2c224ea7697698acf413226;Subject="";` +
+			`URI=spiffe://cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account`
+
+		// when
+		certificates := extractCertificates(certInfoData)
+
+		// then
+		require.Len(t, certificates, 1)
+		assert.Equal(t, "CN=test-application,OU=OrgUnit,O=Organization,L=Waldorf,ST=Waldorf,C=DE", certificates[0].subject)
+		assert.Equal(t, "f4cf22fb633d4df500e371daf703d4b4d14a0ea9d69cd631f95f9e6ba840f8ad", certificates[0].certificate.Fingerprint)
+		assert.Nil(t, certificates[0].certificate.SerialNumber)
+	})
+
+	t.Run("should extract serial number of forwarded certificate", func(t *testing.T) {
+		// given
+		der := createCertificate(t, big.NewInt(0x1a2b))
+		encoded := url.PathEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+		certInfoData := `Hash=abc;Cert="` + encoded + `";Subject="CN=test-application,O=Organization"`
+
+		// when
+		certificates := extractCertificates(certInfoData)
+
+		// then
+		require.Len(t, certificates, 1)
+		fingerprint := sha256.Sum256(der)
+		assert.Equal(t, hex.EncodeToString(fingerprint[:]), certificates[0].certificate.Fingerprint)
+		assert.Equal(t, big.NewInt(0x1a2b), certificates[0].certificate.SerialNumber)
+	})
+
+	t.Run("should keep escaped quotes in quoted value", func(t *testing.T) {
+		// when
+		certificates := extractCertificates(`Subject="CN=test-application,O=\"Organization, Inc\""`)
+
+		// then
+		require.Len(t, certificates, 1)
+		assert.Equal(t, `CN=test-application,O="Organization, Inc"`, certificates[0].subject)
+	})
+}
+
+func createCertificate(t *testing.T, serialNumber *big.Int) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: "test-application"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return der
+}
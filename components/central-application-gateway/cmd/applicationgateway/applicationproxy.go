@@ -7,10 +7,12 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/kyma-project/kyma/components/application-operator/pkg/client/clientset/versioned"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/accesscontrol"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf"
 	csrfClient "github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf/client"
 	csrfStrategy "github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf/strategy"
@@ -19,11 +21,14 @@ import (
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/applications"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/secrets"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/serviceapi"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metrics"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/proxy"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/watcher"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httptools"
 	"github.com/oklog/run"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
@@ -65,8 +70,25 @@ func main() {
 		os.Exit(1)
 	}
 
-	internalHandler := newInternalHandler(serviceDefinitionService, options)
-	internalHandlerForCompass := newInternalHandlerForCompass(serviceDefinitionService, options)
+	metrics.Register(prometheus.DefaultRegisterer)
+
+	accessController, err := newAccessController(options.accessControlRules, coreClientset)
+	if err != nil {
+		log.Fatalf("Unable to create access controller: %s", err.Error())
+	}
+
+	var cacheInvalidator *proxy.CacheInvalidator
+	var configWatcher *watcher.Watcher
+	if options.watchConfigChanges {
+		cacheInvalidator = proxy.NewCacheInvalidator()
+		configWatcher, err = newWatcher(k8sConfig, coreClientset, options.applicationSecretsNamespace, cacheInvalidator)
+		if err != nil {
+			log.Fatalf("Unable to create watcher of configuration changes: %s", err.Error())
+		}
+	}
+
+	internalHandler := newInternalHandler(serviceDefinitionService, accessController, cacheInvalidator, options)
+	internalHandlerForCompass := newInternalHandlerForCompass(serviceDefinitionService, accessController, cacheInvalidator, options)
 	externalHandler := externalapi.NewHandler()
 
 	if options.requestLogging {
@@ -99,6 +121,9 @@ func main() {
 	addHttpServerToRunGroup("external-api", &g, externalSrv)
 	addHttpServerToRunGroup("proxy-kyma-os", &g, internalSrv)
 	addHttpServerToRunGroup("proxy-kyma-mps", &g, internalSrvCompass)
+	if configWatcher != nil {
+		addWatcherToRunGroup(&g, configWatcher)
+	}
 	addInterruptSignalToRunGroup(&g)
 
 	err = g.Run()
@@ -128,6 +153,16 @@ func addHttpServerToRunGroup(name string, g *run.Group, srv *http.Server) {
 	})
 }
 
+func addWatcherToRunGroup(g *run.Group, w *watcher.Watcher) {
+	stopCh := make(chan struct{})
+	g.Add(func() error {
+		w.Run(stopCh)
+		return nil
+	}, func(error) {
+		close(stopCh)
+	})
+}
+
 func addInterruptSignalToRunGroup(g *run.Group) {
 	cancelInterrupt := make(chan struct{})
 	g.Add(func() error {
@@ -144,32 +179,74 @@ func addInterruptSignalToRunGroup(g *run.Group) {
 	})
 }
 
-func newInternalHandler(serviceDefinitionService metadata.ServiceDefinitionService, options *options) http.Handler {
+func newInternalHandler(serviceDefinitionService metadata.ServiceDefinitionService, accessController accesscontrol.Controller, cacheInvalidator *proxy.CacheInvalidator, options *options) http.Handler {
 	authStrategyFactory := newAuthenticationStrategyFactory(options.proxyTimeout)
 	csrfCl := newCSRFClient(options.proxyTimeout)
 	csrfTokenStrategyFactory := csrfStrategy.NewTokenStrategyFactory(csrfCl)
 
-	return proxy.New(serviceDefinitionService, authStrategyFactory, csrfTokenStrategyFactory, getProxyConfig(options))
+	return proxy.New(serviceDefinitionService, authStrategyFactory, csrfTokenStrategyFactory, getProxyConfig(options, accessController, cacheInvalidator))
 }
 
-func newInternalHandlerForCompass(serviceDefinitionService metadata.ServiceDefinitionService, options *options) http.Handler {
+func newInternalHandlerForCompass(serviceDefinitionService metadata.ServiceDefinitionService, accessController accesscontrol.Controller, cacheInvalidator *proxy.CacheInvalidator, options *options) http.Handler {
 	authStrategyFactory := newAuthenticationStrategyFactory(options.proxyTimeout)
 	csrfCl := newCSRFClient(options.proxyTimeout)
 	csrfTokenStrategyFactory := csrfStrategy.NewTokenStrategyFactory(csrfCl)
 
-	return proxy.NewForCompass(serviceDefinitionService, authStrategyFactory, csrfTokenStrategyFactory, getProxyConfig(options))
+	return proxy.NewForCompass(serviceDefinitionService, authStrategyFactory, csrfTokenStrategyFactory, getProxyConfig(options, accessController, cacheInvalidator))
 }
 
-func getProxyConfig(options *options) proxy.Config {
+func getProxyConfig(options *options, accessController accesscontrol.Controller, cacheInvalidator *proxy.CacheInvalidator) proxy.Config {
 	return proxy.Config{
 		ProxyTimeout:  options.proxyTimeout,
 		ProxyCacheTTL: options.proxyCacheTTL,
+		CircuitBreaker: proxy.CircuitBreakerConfig{
+			FailureRatio:   options.circuitBreakerFailureRatio,
+			MinRequests:    options.circuitBreakerMinRequests,
+			Window:         time.Duration(options.circuitBreakerWindow) * time.Second,
+			OpenDuration:   time.Duration(options.circuitBreakerOpenDuration) * time.Second,
+			HalfOpenProbes: options.circuitBreakerProbes,
+		},
+		Retry: proxy.RetryConfig{
+			MaxRetries:     options.retryMaxRetries,
+			InitialBackoff: time.Duration(options.retryInitialBackoff) * time.Millisecond,
+			MaxBackoff:     time.Duration(options.retryMaxBackoff) * time.Millisecond,
+			MaxBodySize:    int64(options.retryMaxBodySize) * 1024,
+		},
+		ResponseCache: proxy.ResponseCacheConfig{
+			MaxSize:      int64(options.responseCacheMaxSize) * 1024 * 1024,
+			MaxEntrySize: int64(options.responseCacheMaxEntrySize) * 1024,
+			Headers:      parseHeaderNames(options.responseCacheHeaders),
+		},
+		ValidateRequests:      options.validateRequests,
+		ValidationMaxBodySize: int64(options.validateRequestsMaxBodySize) * 1024,
+		AccessController:      accessController,
+		CacheInvalidator:      cacheInvalidator,
 	}
 }
 
+func parseHeaderNames(headers string) []string {
+	var names []string
+	for _, name := range strings.Split(headers, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// newAccessController creates the controller of callers allowed in the rules file. All callers are allowed if the file isn't set
+func newAccessController(rulesPath string, coreClientset kubernetes.Interface) (accesscontrol.Controller, error) {
+	if rulesPath == "" {
+		return nil, nil
+	}
+
+	return accesscontrol.NewController(rulesPath, coreClientset.AuthenticationV1().TokenReviews())
+}
+
 func newAuthenticationStrategyFactory(oauthClientTimeout int) authorization.StrategyFactory {
 	return authorization.NewStrategyFactory(authorization.FactoryConfiguration{
 		OAuthClientTimeout: oauthClientTimeout,
+		ObserveTokenFetch:  metrics.ObserveTokenFetch,
 	})
 }
 
@@ -197,6 +274,16 @@ func newApplicationRepository(config *restclient.Config) (applications.ServiceRe
 	return applications.NewServiceRepository(rei), nil
 }
 
+// newWatcher creates the watcher removing the cached proxies when the Application or its Secrets change
+func newWatcher(config *restclient.Config, coreClientset kubernetes.Interface, namespace string, cacheInvalidator *proxy.CacheInvalidator) (*watcher.Watcher, error) {
+	applicationClientset, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return watcher.New(applicationClientset, coreClientset, namespace, 0, cacheInvalidator), nil
+}
+
 func newSecretsRepository(coreClientset kubernetes.Interface, namespace string) secrets.Repository {
 	sei := coreClientset.CoreV1().Secrets(namespace)
 
@@ -16,6 +16,22 @@ type options struct {
 	proxyCacheTTL               int
 	kubeConfig                  string
 	apiServerURL                string
+	circuitBreakerFailureRatio  float64
+	circuitBreakerMinRequests   int
+	circuitBreakerWindow        int
+	circuitBreakerOpenDuration  int
+	circuitBreakerProbes        int
+	retryMaxRetries             int
+	retryInitialBackoff         int
+	retryMaxBackoff             int
+	retryMaxBodySize            int
+	responseCacheMaxSize        int
+	responseCacheMaxEntrySize   int
+	responseCacheHeaders        string
+	validateRequests            bool
+	validateRequestsMaxBodySize int
+	accessControlRules          string
+	watchConfigChanges          bool
 }
 
 func parseArgs() *options {
@@ -29,6 +45,22 @@ func parseArgs() *options {
 	proxyCacheTTL := flag.Int("proxyCacheTTL", 120, "TTL, in seconds, for proxy cache of Remote API information")
 	kubeConfig := flag.String("kubeConfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	apiServerURL := flag.String("apiServerURL", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	circuitBreakerFailureRatio := flag.Float64("circuitBreakerFailureRatio", 0.5, "Ratio of failed requests to the target API which opens its circuit breaker. 0 disables circuit breakers.")
+	circuitBreakerMinRequests := flag.Int("circuitBreakerMinRequests", 10, "Minimum number of requests to the target API in the window before the failure ratio is evaluated.")
+	circuitBreakerWindow := flag.Int("circuitBreakerWindow", 60, "Period, in seconds, in which requests to the target API are counted by its circuit breaker.")
+	circuitBreakerOpenDuration := flag.Int("circuitBreakerOpenDuration", 30, "Period, in seconds, in which requests to the target API are rejected before probing it again.")
+	circuitBreakerProbes := flag.Int("circuitBreakerProbes", 3, "Number of successful probe requests which close the circuit breaker of the target API.")
+	retryMaxRetries := flag.Int("retryMaxRetries", 2, "Maximum number of retries of idempotent requests which failed with a transport error or a 5xx response. 0 disables retries.")
+	retryInitialBackoff := flag.Int("retryInitialBackoff", 100, "Upper limit, in milliseconds, of the jittered delay before the first retry.")
+	retryMaxBackoff := flag.Int("retryMaxBackoff", 2000, "Maximum upper limit, in milliseconds, of the jittered delay between retries.")
+	retryMaxBodySize := flag.Int("retryMaxBodySize", 1024, "Maximum size, in kilobytes, of the request body kept in memory to repeat the request. Requests with larger or streamed bodies are not retried. 0 disables the limit.")
+	responseCacheMaxSize := flag.Int("responseCacheMaxSize", 0, "Maximum total size, in megabytes, of responses to GET and HEAD requests cached by the gateway. 0 disables the response cache.")
+	responseCacheMaxEntrySize := flag.Int("responseCacheMaxEntrySize", 512, "Maximum size, in kilobytes, of a single cached response.")
+	responseCacheHeaders := flag.String("responseCacheHeaders", "Accept,Accept-Encoding,Accept-Language", "Comma-separated list of request headers whose values are part of the response cache key.")
+	validateRequests := flag.Bool("validateRequests", false, "Flag for rejecting requests which don't match the OpenAPI specification of the target API.")
+	validateRequestsMaxBodySize := flag.Int("validateRequestsMaxBodySize", 1024, "Maximum size, in kilobytes, of the JSON request body read into memory to validate it. Larger requests are rejected.")
+	accessControlRules := flag.String("accessControlRules", "", "Path to the file with rules allowing callers to call the Application services. All callers are allowed if not set.")
+	watchConfigChanges := flag.Bool("watchConfigChanges", true, "Flag for removing cached proxies as soon as the Application or its Secrets change instead of after proxyCacheTTL.")
 
 	flag.Parse()
 
@@ -43,12 +75,34 @@ func parseArgs() *options {
 		proxyCacheTTL:               *proxyCacheTTL,
 		kubeConfig:                  *kubeConfig,
 		apiServerURL:                *apiServerURL,
+		circuitBreakerFailureRatio:  *circuitBreakerFailureRatio,
+		circuitBreakerMinRequests:   *circuitBreakerMinRequests,
+		circuitBreakerWindow:        *circuitBreakerWindow,
+		circuitBreakerOpenDuration:  *circuitBreakerOpenDuration,
+		circuitBreakerProbes:        *circuitBreakerProbes,
+		retryMaxRetries:             *retryMaxRetries,
+		retryInitialBackoff:         *retryInitialBackoff,
+		retryMaxBackoff:             *retryMaxBackoff,
+		retryMaxBodySize:            *retryMaxBodySize,
+		responseCacheMaxSize:        *responseCacheMaxSize,
+		responseCacheMaxEntrySize:   *responseCacheMaxEntrySize,
+		responseCacheHeaders:        *responseCacheHeaders,
+		validateRequests:            *validateRequests,
+		validateRequestsMaxBodySize: *validateRequestsMaxBodySize,
+		accessControlRules:          *accessControlRules,
+		watchConfigChanges:          *watchConfigChanges,
 	}
 }
 
 func (o *options) String() string {
 	return fmt.Sprintf("--externalAPIPort=%d --proxyPort=%d --proxyPortCompass=%d --applicationSecretsNamespace=%s --requestTimeout=%d --proxyTimeout=%d"+
-		" --requestLogging=%t --proxyCacheTTL=%d --kubeConfig=%s --apiServerURL=%s",
+		" --requestLogging=%t --proxyCacheTTL=%d --kubeConfig=%s --apiServerURL=%s"+
+		" --circuitBreakerFailureRatio=%g --circuitBreakerMinRequests=%d --circuitBreakerWindow=%d --circuitBreakerOpenDuration=%d --circuitBreakerProbes=%d"+
+		" --retryMaxRetries=%d --retryInitialBackoff=%d --retryMaxBackoff=%d --retryMaxBodySize=%d"+
+		" --responseCacheMaxSize=%d --responseCacheMaxEntrySize=%d --responseCacheHeaders=%s --validateRequests=%t --validateRequestsMaxBodySize=%d --accessControlRules=%s --watchConfigChanges=%t",
 		o.externalAPIPort, o.proxyPort, o.proxyPortCompass, o.applicationSecretsNamespace, o.requestTimeout, o.proxyTimeout,
-		o.requestLogging, o.proxyCacheTTL, o.kubeConfig, o.apiServerURL)
+		o.requestLogging, o.proxyCacheTTL, o.kubeConfig, o.apiServerURL,
+		o.circuitBreakerFailureRatio, o.circuitBreakerMinRequests, o.circuitBreakerWindow, o.circuitBreakerOpenDuration, o.circuitBreakerProbes,
+		o.retryMaxRetries, o.retryInitialBackoff, o.retryMaxBackoff, o.retryMaxBodySize,
+		o.responseCacheMaxSize, o.responseCacheMaxEntrySize, o.responseCacheHeaders, o.validateRequests, o.validateRequestsMaxBodySize, o.accessControlRules, o.watchConfigChanges)
 }
@@ -0,0 +1,67 @@
+// Package accesscontrol contains authentication and authorization of the workloads calling the Application services through the gateway
+package accesscontrol
+
+import (
+	"net/http"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metrics"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	log "github.com/sirupsen/logrus"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+)
+
+// Controller decides if the caller is allowed to call the service
+//
+//go:generate mockery --name=Controller
+type Controller interface {
+	// Authorize authenticates the caller of the request and checks if it is allowed to call the service.
+	// It removes the caller credentials from the request, so that they aren't sent to the target API
+	Authorize(r *http.Request, apiIdentifier model.APIIdentifier) apperrors.AppError
+}
+
+type controller struct {
+	authenticator *authenticator
+	rules         *rulesFile
+}
+
+// NewController creates the controller allowing callers listed in the rules file.
+// Service account tokens aren't accepted if tokenReviews is nil
+func NewController(rulesPath string, tokenReviews authenticationv1client.TokenReviewInterface) (Controller, error) {
+	rules, err := newRulesFile(rulesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &controller{
+		authenticator: newAuthenticator(tokenReviews),
+		rules:         rules,
+	}, nil
+}
+
+func (c *controller) Authorize(r *http.Request, apiIdentifier model.APIIdentifier) apperrors.AppError {
+	caller, err := c.authenticator.authenticate(r)
+	r.Header.Del(httpconsts.HeaderCallerToken)
+	r.Header.Del(httpconsts.HeaderXForwardedClientCert)
+
+	if err != nil {
+		if err.Code() != apperrors.CodeInternal {
+			c.deny(apiIdentifier, "unauthenticated caller", err)
+		}
+		return err
+	}
+
+	if !c.rules.get().allows(caller, apiIdentifier.Application, apiIdentifier.Service) {
+		err := apperrors.Forbidden("caller %s is not allowed to call service %s of application %s", caller, apiIdentifier.Service, apiIdentifier.Application)
+		c.deny(apiIdentifier, "caller "+caller.String(), err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *controller) deny(apiIdentifier model.APIIdentifier, caller string, err apperrors.AppError) {
+	log.Warnf("Access of %s to %s/%s/%s denied: %s", caller, apiIdentifier.Application, apiIdentifier.Service, apiIdentifier.Entry, err)
+	metrics.AccessDenied.With(metrics.APILabels(apiIdentifier.Application, apiIdentifier.Service, apiIdentifier.Entry)).Inc()
+}
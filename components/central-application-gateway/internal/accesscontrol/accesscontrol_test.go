@@ -0,0 +1,225 @@
+package accesscontrol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const rules = `
+rules:
+  - application: commerce
+    services: [orders]
+    callers:
+      - namespace: shop
+        serviceAccount: checkout
+  - application: marketing
+    callers:
+      - namespace: campaigns
+`
+
+const ordersXFCC = `By=spiffe://cluster.local/ns/kyma-system/sa/central-application-gateway;Hash=abc;Subject="CN=a,O=b";URI=spiffe://cluster.local/ns/shop/sa/checkout`
+
+func TestController_Authorize(t *testing.T) {
+	orders := model.APIIdentifier{Application: "commerce", Service: "orders"}
+
+	tokenReviews := 0
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		tokenReviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "checkout-token":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "system:serviceaccount:shop:checkout"}}
+		case "user-token":
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: "admin@example.com"}}
+		default:
+			review.Status = authenticationv1.TokenReviewStatus{Authenticated: false, Error: "token expired"}
+		}
+		return true, review, nil
+	})
+
+	controller, err := NewController(writeRules(t, rules), clientset.AuthenticationV1().TokenReviews())
+	require.NoError(t, err)
+
+	newRequest := func(header, value string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/commerce/orders", nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		return r
+	}
+
+	for _, testCase := range []struct {
+		description  string
+		request      *http.Request
+		api          model.APIIdentifier
+		expectedCode int
+	}{
+		{
+			description: "should allow caller identified by client certificate",
+			request:     newRequest(httpconsts.HeaderXForwardedClientCert, ordersXFCC),
+			api:         orders,
+		},
+		{
+			description: "should allow caller identified by service account token",
+			request:     newRequest(httpconsts.HeaderCallerToken, "Bearer checkout-token"),
+			api:         orders,
+		},
+		{
+			description: "should allow any service account in namespace",
+			request:     newRequest(httpconsts.HeaderXForwardedClientCert, "URI=spiffe://cluster.local/ns/campaigns/sa/mailer"),
+			api:         model.APIIdentifier{Application: "marketing", Service: "newsletters", Entry: "subscribe"},
+		},
+		{
+			description:  "should deny caller without identity",
+			request:      newRequest("", ""),
+			api:          orders,
+			expectedCode: apperrors.CodeUnauthorized,
+		},
+		{
+			description:  "should deny caller with invalid token",
+			request:      newRequest(httpconsts.HeaderCallerToken, "expired-token"),
+			api:          orders,
+			expectedCode: apperrors.CodeUnauthorized,
+		},
+		{
+			description:  "should deny caller whose token doesn't belong to service account",
+			request:      newRequest(httpconsts.HeaderCallerToken, "user-token"),
+			api:          orders,
+			expectedCode: apperrors.CodeUnauthorized,
+		},
+		{
+			description:  "should deny caller not allowed to call service",
+			request:      newRequest(httpconsts.HeaderXForwardedClientCert, ordersXFCC),
+			api:          model.APIIdentifier{Application: "commerce", Service: "payments"},
+			expectedCode: apperrors.CodeForbidden,
+		},
+		{
+			description:  "should deny caller from other namespace",
+			request:      newRequest(httpconsts.HeaderXForwardedClientCert, "URI=spiffe://cluster.local/ns/other/sa/checkout"),
+			api:          orders,
+			expectedCode: apperrors.CodeForbidden,
+		},
+		{
+			description:  "should deny caller of application without rules",
+			request:      newRequest(httpconsts.HeaderXForwardedClientCert, ordersXFCC),
+			api:          model.APIIdentifier{Application: "hr", Service: "employees"},
+			expectedCode: apperrors.CodeForbidden,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			err := controller.Authorize(testCase.request, testCase.api)
+
+			// then
+			if testCase.expectedCode == 0 {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedCode, err.Code())
+			}
+			assert.Empty(t, testCase.request.Header.Get(httpconsts.HeaderCallerToken), "caller token should not be forwarded")
+			assert.Empty(t, testCase.request.Header.Get(httpconsts.HeaderXForwardedClientCert), "client certificate should not be forwarded")
+		})
+	}
+
+	t.Run("should cache reviewed tokens", func(t *testing.T) {
+		// given
+		reviewsBefore := tokenReviews
+
+		// when
+		err := controller.Authorize(newRequest(httpconsts.HeaderCallerToken, "checkout-token"), orders)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, reviewsBefore, tokenReviews)
+	})
+}
+
+func TestController_ReloadRules(t *testing.T) {
+	// given
+	path := writeRules(t, rules)
+	accessController, err := NewController(path, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	rulesFile := accessController.(*controller).rules
+	rulesFile.now = func() time.Time { return now }
+
+	hr := model.APIIdentifier{Application: "hr", Service: "employees"}
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/hr/employees", nil)
+		r.Header.Set(httpconsts.HeaderXForwardedClientCert, ordersXFCC)
+		return r
+	}
+	require.Error(t, accessController.Authorize(newRequest(), hr))
+
+	// when
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules":[{"application":"hr","callers":[{"namespace":"shop"}]}]}`), 0600))
+	require.NoError(t, os.Chtimes(path, now.Add(time.Minute), now.Add(time.Minute)))
+	now = now.Add(reloadInterval)
+
+	// then
+	assert.NoError(t, accessController.Authorize(newRequest(), hr))
+}
+
+func TestNewController(t *testing.T) {
+	t.Run("should fail for invalid rules", func(t *testing.T) {
+		// when
+		_, err := NewController(writeRules(t, `{"rules":[{"callers":[{"namespace":"shop"}]}]}`), nil)
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("should fail for missing rules file", func(t *testing.T) {
+		// when
+		_, err := NewController(filepath.Join(t.TempDir(), "missing.yaml"), nil)
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestAuthenticateClientCertificate(t *testing.T) {
+	t.Run("should use identity of the closest client", func(t *testing.T) {
+		// given
+		xfcc := `By=spiffe://cluster.local/ns/a/sa/b;URI=spiffe://cluster.local/ns/first/sa/hop,` + ordersXFCC
+
+		// when
+		caller, err := authenticateClientCertificate(xfcc)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Caller{Namespace: "shop", ServiceAccount: "checkout"}, caller)
+	})
+
+	t.Run("should fail for certificate without SPIFFE identity", func(t *testing.T) {
+		// when
+		_, err := authenticateClientCertificate(`Hash=abc;Subject="CN=client"`)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeUnauthorized, err.Code())
+	})
+}
+
+func writeRules(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
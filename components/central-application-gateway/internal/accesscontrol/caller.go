@@ -0,0 +1,160 @@
+package accesscontrol
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	gocache "github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+)
+
+const (
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	tokenReviewTimeout           = 10 * time.Second
+	tokenCacheTTL                = time.Minute
+)
+
+// Caller is the workload identified by its Kubernetes service account
+type Caller struct {
+	Namespace      string
+	ServiceAccount string
+}
+
+func (c Caller) String() string {
+	return c.Namespace + "/" + c.ServiceAccount
+}
+
+// authenticator identifies the caller by the service account token or by the client certificate forwarded by Istio
+type authenticator struct {
+	tokenReviews authenticationv1client.TokenReviewInterface
+	tokenCache   *gocache.Cache
+}
+
+func newAuthenticator(tokenReviews authenticationv1client.TokenReviewInterface) *authenticator {
+	return &authenticator{
+		tokenReviews: tokenReviews,
+		tokenCache:   gocache.New(tokenCacheTTL, tokenCacheTTL),
+	}
+}
+
+func (a *authenticator) authenticate(r *http.Request) (Caller, apperrors.AppError) {
+	if token := strings.TrimSpace(r.Header.Get(httpconsts.HeaderCallerToken)); token != "" {
+		return a.authenticateToken(strings.TrimSpace(strings.TrimPrefix(token, "Bearer ")))
+	}
+
+	if xfcc := r.Header.Get(httpconsts.HeaderXForwardedClientCert); xfcc != "" {
+		return authenticateClientCertificate(xfcc)
+	}
+
+	return Caller{}, apperrors.Unauthorized("caller identity is missing, provide the service account token in the %s header or call the gateway with Istio mutual TLS", httpconsts.HeaderCallerToken)
+}
+
+func (a *authenticator) authenticateToken(token string) (Caller, apperrors.AppError) {
+	if a.tokenReviews == nil {
+		return Caller{}, apperrors.Unauthorized("authentication with the service account token is not supported")
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(hash[:])
+	if cached, found := a.tokenCache.Get(key); found {
+		return cached.(Caller), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenReviewTimeout)
+	defer cancel()
+
+	review, err := a.tokenReviews.Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		log.Errorf("Failed to review caller token: %s", err)
+		return Caller{}, apperrors.Internal("failed to review caller token, %s", err)
+	}
+
+	if !review.Status.Authenticated {
+		return Caller{}, apperrors.Unauthorized("caller token is invalid, %s", review.Status.Error)
+	}
+
+	caller, err := parseServiceAccountUsername(review.Status.User.Username)
+	if err != nil {
+		return Caller{}, apperrors.Unauthorized("caller token is invalid, %s", err)
+	}
+
+	a.tokenCache.Set(key, caller, gocache.DefaultExpiration)
+	return caller, nil
+}
+
+// parseServiceAccountUsername parses the username in the system:serviceaccount:<namespace>:<name> format
+func parseServiceAccountUsername(username string) (Caller, error) {
+	parts := strings.Split(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if !strings.HasPrefix(username, serviceAccountUsernamePrefix) || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Caller{}, fmt.Errorf("user '%s' is not a service account", username)
+	}
+	return Caller{Namespace: parts[0], ServiceAccount: parts[1]}, nil
+}
+
+// authenticateClientCertificate reads the SPIFFE identity of the client certificate set by Istio sidecar in the X-Forwarded-Client-Cert header.
+// The last element of the header describes the client of the closest proxy
+func authenticateClientCertificate(xfcc string) (Caller, apperrors.AppError) {
+	elements := splitQuoted(xfcc, ',')
+	for _, pair := range splitQuoted(elements[len(elements)-1], ';') {
+		key, value, _ := strings.Cut(pair, "=")
+		if !strings.EqualFold(strings.TrimSpace(key), "URI") {
+			continue
+		}
+		if caller, err := parseSPIFFEID(strings.Trim(strings.TrimSpace(value), `"`)); err == nil {
+			return caller, nil
+		}
+	}
+
+	return Caller{}, apperrors.Unauthorized("client certificate doesn't contain the service account identity")
+}
+
+// parseSPIFFEID parses the identity in the spiffe://<trust domain>/ns/<namespace>/sa/<name> format
+func parseSPIFFEID(uri string) (Caller, error) {
+	if !strings.HasPrefix(uri, "spiffe://") {
+		return Caller{}, fmt.Errorf("'%s' is not a SPIFFE ID", uri)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(uri, "spiffe://"), "/")
+	if len(parts) != 5 || parts[1] != "ns" || parts[3] != "sa" || parts[2] == "" || parts[4] == "" {
+		return Caller{}, fmt.Errorf("'%s' is not a service account SPIFFE ID", uri)
+	}
+	return Caller{Namespace: parts[2], ServiceAccount: parts[4]}, nil
+}
+
+// splitQuoted splits the value by the separator which isn't quoted
+func splitQuoted(value string, separator rune) []string {
+	var parts []string
+	var current strings.Builder
+	quoted := false
+	escaped := false
+
+	for _, char := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			quoted = !quoted
+		case char == separator && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(char)
+	}
+
+	return append(parts, current.String())
+}
@@ -0,0 +1,171 @@
+package accesscontrol
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+// reloadInterval is the minimum time between checks if the rules file changed
+const reloadInterval = 10 * time.Second
+
+const wildcard = "*"
+
+// Rules is the allow-list of callers of the Application services
+type Rules struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule allows the callers to call the services of the Application
+type Rule struct {
+	// Application is the name of the Application or * for all Applications
+	Application string `json:"application"`
+	// Services are the names of the services. All services of the Application are allowed if empty
+	Services []string `json:"services,omitempty"`
+	// Callers are the allowed callers
+	Callers []CallerRule `json:"callers"`
+}
+
+// CallerRule matches the caller by its namespace and service account
+type CallerRule struct {
+	Namespace string `json:"namespace"`
+	// ServiceAccount is the name of the service account. All service accounts in the namespace are matched if empty or *
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+func (r Rules) allows(caller Caller, application, service string) bool {
+	for _, rule := range r.Rules {
+		if rule.matchesService(application, service) && rule.matchesCaller(caller) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesService(application, service string) bool {
+	if r.Application != wildcard && r.Application != application {
+		return false
+	}
+	if len(r.Services) == 0 {
+		return true
+	}
+	for _, name := range r.Services {
+		if name == wildcard || name == service {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesCaller(caller Caller) bool {
+	for _, callerRule := range r.Callers {
+		if callerRule.Namespace != wildcard && callerRule.Namespace != caller.Namespace {
+			continue
+		}
+		if callerRule.ServiceAccount == "" || callerRule.ServiceAccount == wildcard || callerRule.ServiceAccount == caller.ServiceAccount {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rules) validate() error {
+	for i, rule := range r.Rules {
+		if rule.Application == "" {
+			return fmt.Errorf("rule %d has no application", i)
+		}
+		for _, callerRule := range rule.Callers {
+			if callerRule.Namespace == "" {
+				return fmt.Errorf("caller of rule %d for application %s has no namespace", i, rule.Application)
+			}
+		}
+	}
+	return nil
+}
+
+// rulesFile reloads the rules when the file, for example mounted from a ConfigMap, changes
+type rulesFile struct {
+	path      string
+	mutex     sync.Mutex
+	rules     Rules
+	modTime   time.Time
+	checkedAt time.Time
+	now       func() time.Time
+}
+
+func newRulesFile(path string) (*rulesFile, error) {
+	file := &rulesFile{path: path, now: time.Now}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read access control rules, %s", err)
+	}
+
+	file.rules, err = loadRules(path)
+	if err != nil {
+		return nil, err
+	}
+	file.modTime = info.ModTime()
+	file.checkedAt = file.now()
+
+	return file, nil
+}
+
+func (f *rulesFile) get() Rules {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.now().Sub(f.checkedAt) < reloadInterval {
+		return f.rules
+	}
+	f.checkedAt = f.now()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		log.Errorf("Failed to check access control rules, using previous rules: %s", err)
+		return f.rules
+	}
+	if info.ModTime().Equal(f.modTime) {
+		return f.rules
+	}
+
+	rules, err := loadRules(f.path)
+	if err != nil {
+		log.Errorf("Failed to reload access control rules, using previous rules: %s", err)
+		return f.rules
+	}
+
+	log.Infof("Reloaded access control rules from %s", f.path)
+	f.rules = rules
+	f.modTime = info.ModTime()
+	return f.rules
+}
+
+func loadRules(path string) (Rules, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Rules{}, fmt.Errorf("failed to read access control rules, %s", err)
+	}
+
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return Rules{}, fmt.Errorf("failed to parse access control rules, %s", err)
+	}
+
+	var rules Rules
+	if err := json.Unmarshal(jsonData, &rules); err != nil {
+		return Rules{}, fmt.Errorf("failed to parse access control rules, %s", err)
+	}
+
+	if err := rules.validate(); err != nil {
+		return Rules{}, fmt.Errorf("invalid access control rules, %s", err)
+	}
+
+	return rules, nil
+}
@@ -23,6 +23,12 @@ func errorCodeToHttpStatus(code int) int {
 		return http.StatusBadRequest
 	case apperrors.CodeUpstreamServerCallFailed:
 		return http.StatusBadGateway
+	case apperrors.CodeUnauthorized:
+		return http.StatusUnauthorized
+	case apperrors.CodeForbidden:
+		return http.StatusForbidden
+	case apperrors.CodeRequestTooLarge:
+		return http.StatusRequestEntityTooLarge
 	default:
 		return http.StatusInternalServerError
 	}
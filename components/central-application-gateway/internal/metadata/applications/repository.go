@@ -44,6 +44,8 @@ type ServiceAPI struct {
 	Credentials                 *Credentials
 	RequestParametersSecretName string
 	SkipVerify                  bool
+	SpecificationURL            string
+	APIType                     string
 }
 
 type predicateFunc func(service v1alpha1.Service, entry v1alpha1.Entry) bool
@@ -147,6 +149,8 @@ func convert(service v1alpha1.Service, entry v1alpha1.Entry, skipVerify bool) Se
 		Credentials:                 convertCredentialsFromK8sType(entry.Credentials),
 		RequestParametersSecretName: entry.RequestParametersSecretName,
 		SkipVerify:                  skipVerify,
+		SpecificationURL:            entry.SpecificationUrl,
+		APIType:                     entry.ApiType,
 	}
 
 	return Service{
@@ -25,8 +25,10 @@ func TestGetServices(t *testing.T) {
 	}
 
 	expectedServiceAPI := applications.ServiceAPI{
-		TargetURL:  "https://192.168.1.2",
-		SkipVerify: false,
+		TargetURL:        "https://192.168.1.2",
+		SkipVerify:       false,
+		SpecificationURL: "https://192.168.1.2/openapi.json",
+		APIType:          "OPEN_API",
 		Credentials: &applications.Credentials{
 			Type:       "OAuth",
 			SecretName: "SecretName",
@@ -35,8 +37,10 @@ func TestGetServices(t *testing.T) {
 	}
 
 	expectedServiceAPISkipVerify := applications.ServiceAPI{
-		TargetURL:  "https://192.168.1.2",
-		SkipVerify: true,
+		TargetURL:        "https://192.168.1.2",
+		SkipVerify:       true,
+		SpecificationURL: "https://192.168.1.2/openapi.json",
+		APIType:          "OPEN_API",
 		Credentials: &applications.Credentials{
 			Type:       "OAuth",
 			SecretName: "SecretName",
@@ -184,9 +188,11 @@ func TestGetServices(t *testing.T) {
 func createApplication(name string, skipVerify bool) *v1alpha1.Application {
 
 	service1Entry := v1alpha1.Entry{
-		Type:      "API",
-		Name:      "Service entry 1",
-		TargetUrl: "https://192.168.1.2",
+		Type:             "API",
+		Name:             "Service entry 1",
+		TargetUrl:        "https://192.168.1.2",
+		SpecificationUrl: "https://192.168.1.2/openapi.json",
+		ApiType:          "OPEN_API",
 		Credentials: v1alpha1.Credentials{
 			Type:              "OAuth",
 			SecretName:        "SecretName",
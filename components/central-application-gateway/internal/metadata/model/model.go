@@ -28,12 +28,43 @@ type API struct {
 	Credentials *authorization.Credentials
 	// Spec contains specification of an API.
 	Spec []byte
+	// SpecificationURL points to specification of an API.
+	SpecificationURL string
+	// APIType is a type of specification of an API, for example OPEN_API.
+	APIType string
 	// RequestParameters will be used with request send by the Application Gateway
 	RequestParameters *authorization.RequestParameters
+	// RewriteRules modify requests sent by the Application Gateway and responses of the API
+	RewriteRules *RewriteRules
 	// skipVerify is flag set on Application CRD
 	SkipVerify bool
 }
 
+// RewriteRules modify requests sent to the API and its responses.
+type RewriteRules struct {
+	// PathPrefixes replace the prefix of the request path relative to the target URL. The first matching rule is applied
+	PathPrefixes []PrefixRewrite `json:"pathPrefixes,omitempty"`
+	// RemoveRequestHeaders are names of headers removed from the request. The name ending with * matches all headers with the prefix
+	RemoveRequestHeaders []string `json:"removeRequestHeaders,omitempty"`
+	// ResponseHeaders replace the prefix of the response header values
+	ResponseHeaders []HeaderRewrite `json:"responseHeaders,omitempty"`
+	// RemoveResponseHeaders are names of headers removed from the response. The name ending with * matches all headers with the prefix
+	RemoveResponseHeaders []string `json:"removeResponseHeaders,omitempty"`
+}
+
+// PrefixRewrite replaces the From prefix with To.
+type PrefixRewrite struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// HeaderRewrite replaces the From prefix of the header value with To.
+type HeaderRewrite struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
 // Events contains specification for events.
 type Events struct {
 	// Spec contains data of events specification.
@@ -2,8 +2,10 @@ package serviceapi
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
 
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/applications"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
@@ -20,11 +22,19 @@ const (
 	TypeOAuthWithCert  = "OAuthWithCert"
 	TypeBasic          = "Basic"
 	TypeCertificateGen = "CertificateGen"
+	TypeTokenExchange  = "TokenExchange"
 	PrivateKeyKey      = "key"
 	CertificateKey     = "crt"
 
+	GrantTypeKey        = "grantType"
+	AssertionKey        = "assertion"
+	AssertionKeyKey     = "assertionKey"
+	AssertionSubjectKey = "assertionSubject"
+	AudienceKey         = "audience"
+
 	HeadersKey         = "headers"
 	QueryParametersKey = "queryParameters"
+	RewriteRulesKey    = "rewriteRules"
 )
 
 // Service manages API definition of a service
@@ -46,8 +56,10 @@ func NewService(secretsRepository secrets.Repository) Service {
 
 func (sas defaultService) Read(applicationAPI *applications.ServiceAPI) (*model.API, apperrors.AppError) {
 	api := &model.API{
-		TargetUrl:  applicationAPI.TargetURL,
-		SkipVerify: applicationAPI.SkipVerify,
+		TargetUrl:        applicationAPI.TargetURL,
+		SkipVerify:       applicationAPI.SkipVerify,
+		SpecificationURL: applicationAPI.SpecificationURL,
+		APIType:          applicationAPI.APIType,
 	}
 
 	if applicationAPI.Credentials != nil {
@@ -76,6 +88,13 @@ func (sas defaultService) Read(applicationAPI *applications.ServiceAPI) (*model.
 		}
 
 		api.RequestParameters = requestParameters
+
+		rewriteRules, err := getRewriteRules(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		api.RewriteRules = rewriteRules
 	}
 
 	return api, nil
@@ -102,6 +121,14 @@ func (sas defaultService) readCredentials(secret map[string][]byte, applicationA
 		credentials = &authorization.Credentials{
 			OAuthWithCert: oAuthWithCredentials,
 		}
+	} else if credentialsType == TypeTokenExchange {
+		tokenExchangeCredentials, err := getTokenExchangeCredentials(secret, applicationAPI.Credentials.URL)
+		if err != nil {
+			return nil, err
+		}
+		credentials = &authorization.Credentials{
+			TokenExchange: tokenExchangeCredentials,
+		}
 	} else if credentialsType == TypeBasic {
 		credentials = &authorization.Credentials{
 			BasicAuth: getBasicAuthCredentials(secret),
@@ -153,17 +180,70 @@ func getRequestParameters(secret map[string][]byte) (*authorization.RequestParam
 	return requestParameters, nil
 }
 
+func getRewriteRules(secret map[string][]byte) (*model.RewriteRules, apperrors.AppError) {
+	rewriteRulesData := secret[RewriteRulesKey]
+	if rewriteRulesData == nil {
+		return nil, nil
+	}
+
+	rewriteRules := &model.RewriteRules{}
+	err := json.Unmarshal(rewriteRulesData, rewriteRules)
+	if err != nil {
+		return nil, apperrors.Internal("Failed to unmarshal rewrite rules, %s", err.Error())
+	}
+
+	for _, prefix := range rewriteRules.PathPrefixes {
+		if !strings.HasPrefix(prefix.From, "/") {
+			return nil, apperrors.WrongInput("Path prefix '%s' of rewrite rule must start with /", prefix.From)
+		}
+	}
+
+	for _, header := range rewriteRules.ResponseHeaders {
+		if header.Name == "" || header.From == "" {
+			return nil, apperrors.WrongInput("Response header rewrite rule must have name and prefix")
+		}
+	}
+
+	return rewriteRules, nil
+}
+
 func getOAuthCredentials(secret map[string][]byte, url string) (*authorization.OAuth, apperrors.AppError) {
 	requestParameters, err := getRequestParameters(secret)
 	if err != nil {
 		return nil, err
 	}
 
+	grant, err := getOAuthGrant(secret)
+	if err != nil {
+		return nil, err
+	}
+
 	return &authorization.OAuth{
 		ClientID:          string(secret[ClientIDKey]),
 		ClientSecret:      string(secret[ClientSecretKey]),
 		URL:               url,
 		RequestParameters: requestParameters,
+		Grant:             grant,
+	}, nil
+}
+
+func getOAuthGrant(secret map[string][]byte) (*oauth.Grant, apperrors.AppError) {
+	grantType := oauth.GrantType(secret[GrantTypeKey])
+	if !grantType.IsSupported() {
+		return nil, apperrors.WrongInput("Unsupported OAuth grant type '%s'", grantType)
+	}
+
+	if grantType.IsClientCredentials() {
+		return nil, nil
+	}
+
+	return &oauth.Grant{
+		Type:             grantType,
+		Username:         string(secret[UsernameKey]),
+		Password:         string(secret[PasswordKey]),
+		Assertion:        string(secret[AssertionKey]),
+		AssertionKey:     secret[AssertionKeyKey],
+		AssertionSubject: string(secret[AssertionSubjectKey]),
 	}, nil
 }
 
@@ -183,6 +263,27 @@ func getOAuthWithCertCredentials(secret map[string][]byte, url string) (*authori
 	}, nil
 }
 
+func getTokenExchangeCredentials(secret map[string][]byte, url string) (*authorization.TokenExchange, apperrors.AppError) {
+	requestParameters, err := getRequestParameters(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	grantType := oauth.GrantType(secret[GrantTypeKey])
+	if grantType != "" && grantType != oauth.GrantTypeTokenExchange && grantType != oauth.GrantTypeJWTBearer {
+		return nil, apperrors.WrongInput("Unsupported token exchange grant type '%s'", grantType)
+	}
+
+	return &authorization.TokenExchange{
+		ClientID:          string(secret[ClientIDKey]),
+		ClientSecret:      string(secret[ClientSecretKey]),
+		URL:               url,
+		GrantType:         grantType,
+		Audience:          string(secret[AudienceKey]),
+		RequestParameters: requestParameters,
+	}, nil
+}
+
 func getBasicAuthCredentials(secret map[string][]byte) *authorization.BasicAuth {
 	return &authorization.BasicAuth{
 		Username: string(secret[UsernameKey]),
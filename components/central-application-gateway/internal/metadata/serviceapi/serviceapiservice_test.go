@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
 
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
 
@@ -38,6 +39,66 @@ func TestDefaultService_Read(t *testing.T) {
 		requestParamsSecret     map[string][]byte
 		resultingAPI            *model.API
 	}{
+		{
+			description: "api with oauth password grant credentials",
+			applicationAPI: &applications.ServiceAPI{
+				TargetURL: targetUrl,
+				Credentials: &applications.Credentials{
+					Type:       TypeOAuth,
+					SecretName: secretName,
+					URL:        oauthUrl,
+				},
+			},
+			credentialsSecret: map[string][]byte{
+				ClientIDKey:     []byte(clientId),
+				ClientSecretKey: []byte(clientSecret),
+				GrantTypeKey:    []byte(oauth.GrantTypePassword),
+				UsernameKey:     []byte(username),
+				PasswordKey:     []byte(password),
+			},
+			resultingAPI: &model.API{
+				TargetUrl: targetUrl,
+				Credentials: &authorization.Credentials{
+					OAuth: &authorization.OAuth{
+						ClientID:     clientId,
+						ClientSecret: clientSecret,
+						URL:          oauthUrl,
+						Grant: &oauth.Grant{
+							Type:     oauth.GrantTypePassword,
+							Username: username,
+							Password: password,
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "api with token exchange credentials",
+			applicationAPI: &applications.ServiceAPI{
+				TargetURL: targetUrl,
+				Credentials: &applications.Credentials{
+					Type:       TypeTokenExchange,
+					SecretName: secretName,
+					URL:        oauthUrl,
+				},
+			},
+			credentialsSecret: map[string][]byte{
+				ClientIDKey:     []byte(clientId),
+				ClientSecretKey: []byte(clientSecret),
+				AudienceKey:     []byte("audience"),
+			},
+			resultingAPI: &model.API{
+				TargetUrl: targetUrl,
+				Credentials: &authorization.Credentials{
+					TokenExchange: &authorization.TokenExchange{
+						ClientID:     clientId,
+						ClientSecret: clientSecret,
+						URL:          oauthUrl,
+						Audience:     "audience",
+					},
+				},
+			},
+		},
 		{
 			description: "api with oauth credentials",
 			applicationAPI: &applications.ServiceAPI{
@@ -148,6 +209,20 @@ func TestDefaultService_Read(t *testing.T) {
 				TargetUrl: targetUrl,
 			},
 		},
+		{
+			description: "api with specification",
+			applicationAPI: &applications.ServiceAPI{
+				TargetURL:        targetUrl,
+				SpecificationURL: targetUrl + "/openapi.json",
+				APIType:          "OPEN_API",
+			},
+			credentialsSecret: map[string][]byte{},
+			resultingAPI: &model.API{
+				TargetUrl:        targetUrl,
+				SpecificationURL: targetUrl + "/openapi.json",
+				APIType:          "OPEN_API",
+			},
+		},
 		{
 			description: "api with headers and query parameters",
 			applicationAPI: &applications.ServiceAPI{
@@ -212,6 +287,26 @@ func TestDefaultService_Read(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "api with rewrite rules",
+			applicationAPI: &applications.ServiceAPI{
+				TargetURL:                   targetUrl,
+				RequestParametersSecretName: "params-secret",
+			},
+			credentialsSecret:       map[string][]byte{},
+			requestParamsSecretName: "params-secret",
+			requestParamsSecret: map[string][]byte{
+				RewriteRulesKey: []byte(`{"pathPrefixes":[{"from":"/v1","to":"/api"}],"removeRequestHeaders":["Cookie"],"responseHeaders":[{"name":"Location","from":"http://internal","to":"http://public"}]}`),
+			},
+			resultingAPI: &model.API{
+				TargetUrl: targetUrl,
+				RewriteRules: &model.RewriteRules{
+					PathPrefixes:         []model.PrefixRewrite{{From: "/v1", To: "/api"}},
+					RemoveRequestHeaders: []string{"Cookie"},
+					ResponseHeaders:      []model.HeaderRewrite{{Name: "Location", From: "http://internal", To: "http://public"}},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -262,6 +357,32 @@ func TestDefaultService_Read(t *testing.T) {
 		secretsRepository.AssertExpectations(t)
 	})
 
+	t.Run("should return error when OAuth grant type is not supported", func(t *testing.T) {
+		// given
+		applicationServiceAPI := &applications.ServiceAPI{
+			TargetURL: targetUrl,
+			Credentials: &applications.Credentials{
+				Type:       TypeOAuth,
+				SecretName: secretName,
+				URL:        oauthUrl,
+			},
+		}
+
+		secretsRepository := new(secretsmocks.Repository)
+		secretsRepository.On("Get", secretName).
+			Return(map[string][]byte{GrantTypeKey: []byte("implicit")}, nil)
+
+		service := NewService(secretsRepository)
+
+		// when
+		api, err := service.Read(applicationServiceAPI)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, api)
+		assert.Equal(t, apperrors.CodeWrongInput, err.Code())
+	})
+
 	t.Run("should return error when reading request parameters fails", func(t *testing.T) {
 		// given
 		applicationServiceAPI := &applications.ServiceAPI{
@@ -285,4 +406,26 @@ func TestDefaultService_Read(t *testing.T) {
 
 		secretsRepository.AssertExpectations(t)
 	})
+
+	t.Run("should return error when rewrite rules are invalid", func(t *testing.T) {
+		// given
+		applicationServiceAPI := &applications.ServiceAPI{
+			TargetURL:                   targetUrl,
+			RequestParametersSecretName: secretName,
+		}
+
+		secretsRepository := new(secretsmocks.Repository)
+		secretsRepository.On("Get", secretName).
+			Return(map[string][]byte{RewriteRulesKey: []byte(`{"pathPrefixes":[{"from":"v1","to":"/api"}]}`)}, nil)
+
+		service := NewService(secretsRepository)
+
+		// when
+		api, err := service.Read(applicationServiceAPI)
+
+		// then
+		assert.Error(t, err)
+		assert.Nil(t, api)
+		assert.Equal(t, apperrors.CodeWrongInput, err.Code())
+	})
 }
@@ -0,0 +1,190 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespace = "central_application_gateway"
+
+	applicationLabel = "application"
+	serviceLabel     = "service"
+	entryLabel       = "entry"
+	codeLabel        = "code"
+
+	unknownAPI = "unknown"
+)
+
+var apiLabels = []string{applicationLabel, serviceLabel, entryLabel}
+
+type apiLabelsKey struct{}
+
+var (
+	// RequestDuration observes the duration of requests proxied to the target API, including authorization
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Duration of requests proxied to the target API",
+		Buckets:   prometheus.DefBuckets,
+	}, apiLabels)
+
+	// Requests counts requests proxied to the target API by the returned status code
+	Requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Number of requests proxied to the target API by the returned status code",
+	}, append(apiLabels, codeLabel))
+
+	// TokenFetchDuration observes the duration of requests to the OAuth token endpoint not served from the token cache
+	TokenFetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "oauth_token_fetch_duration_seconds",
+		Help:      "Duration of fetching OAuth tokens for the target API",
+		Buckets:   prometheus.DefBuckets,
+	}, apiLabels)
+
+	// CSRFTokenRefreshes counts invalidations of the CSRF token of the target API after it rejected the request
+	CSRFTokenRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "csrf_token_refreshes_total",
+		Help:      "Number of CSRF token refreshes for the target API",
+	}, apiLabels)
+
+	// ProxyCacheHits counts requests served with the proxy configuration found in the cache
+	ProxyCacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "proxy_cache_hits_total",
+		Help:      "Number of requests for which the proxy configuration of the target API was found in the cache",
+	}, apiLabels)
+
+	// ProxyCacheMisses counts requests which required creating the proxy configuration
+	ProxyCacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "proxy_cache_misses_total",
+		Help:      "Number of requests for which the proxy configuration of the target API was not found in the cache",
+	}, apiLabels)
+
+	// ResponseCacheHits counts GET and HEAD requests served from the response cache
+	ResponseCacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "response_cache_hits_total",
+		Help:      "Number of requests to the target API served from the response cache",
+	}, apiLabels)
+
+	// ResponseCacheMisses counts cacheable requests for which no fresh response was found in the response cache
+	ResponseCacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "response_cache_misses_total",
+		Help:      "Number of cacheable requests to the target API for which no fresh response was found in the response cache",
+	}, apiLabels)
+
+	// ResponseCacheRevalidations counts stale cached responses which the target API confirmed with 304 Not Modified
+	ResponseCacheRevalidations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "response_cache_revalidations_total",
+		Help:      "Number of stale cached responses confirmed by the target API as not modified",
+	}, apiLabels)
+
+	// ResponseCacheSize reports the total size of response bodies in the response cache
+	ResponseCacheSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "response_cache_size_bytes",
+		Help:      "Total size of response bodies stored in the response cache",
+	})
+
+	// CircuitBreakerState reports the circuit breaker state of the target API: 0 - closed, 1 - open, 2 - half-open
+	CircuitBreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_state",
+		Help:      "State of the circuit breaker of the target API: 0 - closed, 1 - open, 2 - half-open",
+	}, apiLabels)
+
+	// CircuitBreakerRejectedRequests counts requests rejected without calling the target API because its circuit breaker is open
+	CircuitBreakerRejectedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_rejected_requests_total",
+		Help:      "Number of requests rejected because the circuit breaker of the target API is open",
+	}, apiLabels)
+
+	// Retries counts requests to the target API repeated after a transport error or a 5xx response
+	Retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "retries_total",
+		Help:      "Number of requests to the target API repeated after a transport error or a 5xx response",
+	}, apiLabels)
+
+	// AccessDenied counts requests rejected because the caller isn't authenticated or isn't allowed to call the target API
+	AccessDenied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "access_denied_total",
+		Help:      "Number of requests rejected because the caller isn't allowed to call the target API",
+	}, apiLabels)
+)
+
+// Register registers all gateway metrics in the registerer
+func Register(registerer prometheus.Registerer) {
+	registerer.MustRegister(
+		RequestDuration,
+		Requests,
+		TokenFetchDuration,
+		CSRFTokenRefreshes,
+		ProxyCacheHits,
+		ProxyCacheMisses,
+		ResponseCacheHits,
+		ResponseCacheMisses,
+		ResponseCacheRevalidations,
+		ResponseCacheSize,
+		CircuitBreakerState,
+		CircuitBreakerRejectedRequests,
+		Retries,
+		AccessDenied,
+	)
+}
+
+// ObserveTokenFetch observes the duration of fetching the OAuth token for the proxied request
+func ObserveTokenFetch(ctx context.Context, duration time.Duration) {
+	TokenFetchDuration.With(APILabelsFromContext(ctx)).Observe(duration.Seconds())
+}
+
+// APILabels returns the label values identifying the target API
+func APILabels(application, service, entry string) prometheus.Labels {
+	return prometheus.Labels{
+		applicationLabel: application,
+		serviceLabel:     service,
+		entryLabel:       entry,
+	}
+}
+
+// UnknownAPILabels returns the label values of requests for which the target API wasn't found.
+// The names in the request path aren't used as label values until the API is found, so that the callers can't add arbitrary series
+func UnknownAPILabels() prometheus.Labels {
+	return APILabels(unknownAPI, unknownAPI, unknownAPI)
+}
+
+// RequestLabels returns the label values identifying the target API and the status code returned by the proxy
+func RequestLabels(apiLabels prometheus.Labels, code int) prometheus.Labels {
+	labels := prometheus.Labels{codeLabel: strconv.Itoa(code)}
+	for name, value := range apiLabels {
+		labels[name] = value
+	}
+	return labels
+}
+
+// ContextWithAPILabels returns the context of the request proxied to the target API,
+// so that metrics observed while handling the request are labelled with the target API
+func ContextWithAPILabels(ctx context.Context, apiLabels prometheus.Labels) context.Context {
+	return context.WithValue(ctx, apiLabelsKey{}, apiLabels)
+}
+
+// APILabelsFromContext returns the label values of the target API of the proxied request
+// or empty values if the request isn't proxied
+func APILabelsFromContext(ctx context.Context) prometheus.Labels {
+	if labels, ok := ctx.Value(apiLabelsKey{}).(prometheus.Labels); ok {
+		return labels
+	}
+	return APILabels("", "", "")
+}
@@ -0,0 +1,302 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDepth limits resolving of recursive schemas
+const maxDepth = 64
+
+// schemaValidator validates values against the subset of JSON Schema used by OpenAPI.
+// Formats, discriminators and read-only or write-only properties aren't validated
+type schemaValidator struct {
+	root map[string]interface{}
+}
+
+// resolve follows local references, like #/components/schemas/Order, to the referenced object
+func (v schemaValidator) resolve(value interface{}) interface{} {
+	for i := 0; i < maxDepth; i++ {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		ref, found := object["$ref"].(string)
+		if !found || !strings.HasPrefix(ref, "#/") {
+			return value
+		}
+
+		var current interface{} = v.root
+		for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			parent, ok := current.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			current = parent[token]
+		}
+		value = current
+	}
+	return nil
+}
+
+// location describes the validated value in violations, for example request body property 'items[0].sku'
+type location struct {
+	subject string
+	path    string
+}
+
+func (l location) property(name string) location {
+	if l.path == "" {
+		return location{subject: l.subject, path: name}
+	}
+	return location{subject: l.subject, path: l.path + "." + name}
+}
+
+func (l location) index(i int) location {
+	return location{subject: l.subject, path: fmt.Sprintf("%s[%d]", l.path, i)}
+}
+
+func (l location) String() string {
+	if l.path == "" {
+		return l.subject
+	}
+	return fmt.Sprintf("%s property '%s'", l.subject, l.path)
+}
+
+func (v schemaValidator) resolveSchema(schema map[string]interface{}) map[string]interface{} {
+	resolved, _ := v.resolve(schema).(map[string]interface{})
+	return resolved
+}
+
+func (v schemaValidator) validate(schema map[string]interface{}, value interface{}, location location, depth int) error {
+	if depth > maxDepth {
+		return nil
+	}
+	schema = v.resolveSchema(schema)
+	if schema == nil {
+		return nil
+	}
+
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || allowsType(schema, "null") {
+			return nil
+		}
+	}
+
+	if err := v.validateComposition(schema, value, location, depth); err != nil {
+		return err
+	}
+
+	if enum, found := schema["enum"].([]interface{}); found && !containsValue(enum, value) {
+		return fmt.Errorf("%s must be one of %v", location, enum)
+	}
+
+	if !matchesType(schema, value) {
+		return fmt.Errorf("%s must be of type %v", location, schema["type"])
+	}
+
+	switch typed := value.(type) {
+	case string:
+		return validateString(schema, typed, location)
+	case float64:
+		return validateNumber(schema, typed, location)
+	case []interface{}:
+		return v.validateArray(schema, typed, location, depth)
+	case map[string]interface{}:
+		return v.validateObject(schema, typed, location, depth)
+	}
+	return nil
+}
+
+func (v schemaValidator) validateComposition(schema map[string]interface{}, value interface{}, location location, depth int) error {
+	if allOf, found := schema["allOf"].([]interface{}); found {
+		for _, subschema := range allOf {
+			object, _ := subschema.(map[string]interface{})
+			if err := v.validate(object, value, location, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	if anyOf, found := schema["anyOf"].([]interface{}); found && v.countMatching(anyOf, value, location, depth) == 0 {
+		return fmt.Errorf("%s doesn't match any of the allowed schemas", location)
+	}
+
+	if oneOf, found := schema["oneOf"].([]interface{}); found && v.countMatching(oneOf, value, location, depth) != 1 {
+		return fmt.Errorf("%s must match exactly one of the allowed schemas", location)
+	}
+
+	return nil
+}
+
+func (v schemaValidator) countMatching(schemas []interface{}, value interface{}, location location, depth int) int {
+	matching := 0
+	for _, subschema := range schemas {
+		object, _ := subschema.(map[string]interface{})
+		if v.validate(object, value, location, depth+1) == nil {
+			matching++
+		}
+	}
+	return matching
+}
+
+func (v schemaValidator) validateArray(schema map[string]interface{}, value []interface{}, location location, depth int) error {
+	if minItems, found := schema["minItems"].(float64); found && float64(len(value)) < minItems {
+		return fmt.Errorf("%s must have at least %v items", location, minItems)
+	}
+	if maxItems, found := schema["maxItems"].(float64); found && float64(len(value)) > maxItems {
+		return fmt.Errorf("%s must have at most %v items", location, maxItems)
+	}
+
+	items, found := schema["items"].(map[string]interface{})
+	if !found {
+		return nil
+	}
+	for i, item := range value {
+		if err := v.validate(items, item, location.index(i), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v schemaValidator) validateObject(schema map[string]interface{}, value map[string]interface{}, location location, depth int) error {
+	required, _ := schema["required"].([]interface{})
+	for _, name := range required {
+		if name, ok := name.(string); ok {
+			if _, found := value[name]; !found {
+				return fmt.Errorf("%s is missing required property '%s'", location, name)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	for name, property := range value {
+		propertyLocation := location.property(name)
+		if propertySchema, found := properties[name].(map[string]interface{}); found {
+			if err := v.validate(propertySchema, property, propertyLocation, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s isn't allowed", propertyLocation)
+			}
+		case map[string]interface{}:
+			if err := v.validate(additional, property, propertyLocation, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateString(schema map[string]interface{}, value string, location location) error {
+	length := float64(utf8.RuneCountInString(value))
+	if minLength, found := schema["minLength"].(float64); found && length < minLength {
+		return fmt.Errorf("%s must be at least %v characters long", location, minLength)
+	}
+	if maxLength, found := schema["maxLength"].(float64); found && length > maxLength {
+		return fmt.Errorf("%s must be at most %v characters long", location, maxLength)
+	}
+	if pattern, found := schema["pattern"].(string); found {
+		// invalid patterns in the specification are ignored
+		if expression, err := regexp.Compile(pattern); err == nil && !expression.MatchString(value) {
+			return fmt.Errorf("%s must match pattern '%s'", location, pattern)
+		}
+	}
+	return nil
+}
+
+func validateNumber(schema map[string]interface{}, value float64, location location) error {
+	// OpenAPI 3.0 defines exclusive limits as booleans, OpenAPI 3.1 as numbers
+	exclusiveMinimum, _ := schema["exclusiveMinimum"].(bool)
+	exclusiveMaximum, _ := schema["exclusiveMaximum"].(bool)
+
+	if minimum, found := schema["minimum"].(float64); found && (value < minimum || exclusiveMinimum && value == minimum) {
+		return fmt.Errorf("%s must be %s %v", location, comparison("greater than", exclusiveMinimum), minimum)
+	}
+	if maximum, found := schema["maximum"].(float64); found && (value > maximum || exclusiveMaximum && value == maximum) {
+		return fmt.Errorf("%s must be %s %v", location, comparison("less than", exclusiveMaximum), maximum)
+	}
+	if minimum, found := schema["exclusiveMinimum"].(float64); found && value <= minimum {
+		return fmt.Errorf("%s must be greater than %v", location, minimum)
+	}
+	if maximum, found := schema["exclusiveMaximum"].(float64); found && value >= maximum {
+		return fmt.Errorf("%s must be less than %v", location, maximum)
+	}
+	return nil
+}
+
+func comparison(operator string, exclusive bool) string {
+	if exclusive {
+		return operator
+	}
+	return operator + " or equal to"
+}
+
+// matchesType checks the type of the value. OpenAPI 3.1 allows the list of types
+func matchesType(schema map[string]interface{}, value interface{}) bool {
+	switch types := schema["type"].(type) {
+	case string:
+		return isOfType(types, value)
+	case []interface{}:
+		for _, schemaType := range types {
+			if name, ok := schemaType.(string); ok && isOfType(name, value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func allowsType(schema map[string]interface{}, name string) bool {
+	types, _ := schema["type"].([]interface{})
+	return containsValue(types, name)
+}
+
+func isOfType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,446 @@
+// Package openapi contains validation of requests against OpenAPI 3 and Swagger 2 specifications
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	"sigs.k8s.io/yaml"
+)
+
+var methods = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+	http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+}
+
+// Spec is a parsed specification of the target API used to validate requests
+type Spec struct {
+	basePath  string
+	paths     []*pathItem
+	validator schemaValidator
+}
+
+type pathItem struct {
+	template   string
+	pattern    *regexp.Regexp
+	names      []string
+	operations map[string]*operation
+}
+
+type operation struct {
+	parameters []parameter
+	body       *requestBody
+}
+
+type parameter struct {
+	name     string
+	in       string
+	required bool
+	schema   map[string]interface{}
+}
+
+type requestBody struct {
+	required bool
+	// content maps media types to the schemas of the body
+	content map[string]map[string]interface{}
+}
+
+// Parse parses the OpenAPI 3 or Swagger 2 specification in the JSON or YAML format
+func Parse(data []byte) (*Spec, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse specification, %s", err)
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal(jsonData, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse specification, %s", err)
+	}
+
+	spec := &Spec{validator: schemaValidator{root: document}}
+
+	version, isOpenAPI3 := document["openapi"].(string)
+	if isOpenAPI3 && strings.HasPrefix(version, "3.") {
+		spec.basePath = openAPI3BasePath(document)
+	} else if version, _ := document["swagger"].(string); version == "2.0" {
+		spec.basePath, _ = document["basePath"].(string)
+	} else {
+		return nil, fmt.Errorf("specification is neither OpenAPI 3 nor Swagger 2")
+	}
+	spec.basePath = strings.TrimRight(spec.basePath, "/")
+
+	paths, _ := document["paths"].(map[string]interface{})
+	for template, value := range paths {
+		item, ok := spec.validator.resolve(value).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path, err := spec.newPathItem(template, item, isOpenAPI3)
+		if err != nil {
+			return nil, err
+		}
+		spec.paths = append(spec.paths, path)
+	}
+
+	// concrete paths take precedence over templated paths matching the same request
+	sort.SliceStable(spec.paths, func(i, j int) bool {
+		if len(spec.paths[i].names) != len(spec.paths[j].names) {
+			return len(spec.paths[i].names) < len(spec.paths[j].names)
+		}
+		return spec.paths[i].template < spec.paths[j].template
+	})
+
+	return spec, nil
+}
+
+func openAPI3BasePath(document map[string]interface{}) string {
+	servers, _ := document["servers"].([]interface{})
+	if len(servers) == 0 {
+		return ""
+	}
+	server, _ := servers[0].(map[string]interface{})
+	serverURL, _ := server["url"].(string)
+	parsed, err := url.Parse(serverURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Path
+}
+
+func (s *Spec) newPathItem(template string, item map[string]interface{}, isOpenAPI3 bool) (*pathItem, error) {
+	pattern, names, err := compilePathTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	path := &pathItem{
+		template:   template,
+		pattern:    pattern,
+		names:      names,
+		operations: map[string]*operation{},
+	}
+
+	commonParameters, _ := item["parameters"].([]interface{})
+	for _, method := range methods {
+		value, found := item[strings.ToLower(method)]
+		if !found {
+			continue
+		}
+		operationObject, _ := s.validator.resolve(value).(map[string]interface{})
+		operationParameters, _ := operationObject["parameters"].([]interface{})
+
+		op := &operation{}
+		op.parameters, op.body = s.parameters(append(append([]interface{}{}, commonParameters...), operationParameters...), isOpenAPI3)
+		if isOpenAPI3 {
+			op.body = s.openAPI3RequestBody(operationObject["requestBody"])
+		}
+		path.operations[method] = op
+	}
+
+	return path, nil
+}
+
+// parameters returns the parameters of the operation. Operation parameters override the path item parameters with the same name and location.
+// Swagger 2 defines the request body as the parameter
+func (s *Spec) parameters(values []interface{}, isOpenAPI3 bool) ([]parameter, *requestBody) {
+	var body *requestBody
+	byKey := map[string]int{}
+	var parameters []parameter
+
+	for _, value := range values {
+		object, ok := s.validator.resolve(value).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := object["name"].(string)
+		in, _ := object["in"].(string)
+		required, _ := object["required"].(bool)
+
+		if in == "body" && !isOpenAPI3 {
+			schema, _ := object["schema"].(map[string]interface{})
+			// the media types are defined by consumes, so the schema is applied to any JSON body
+			body = &requestBody{required: required, content: map[string]map[string]interface{}{"*/*": schema}}
+			continue
+		}
+
+		schema, _ := object["schema"].(map[string]interface{})
+		if !isOpenAPI3 {
+			// Swagger 2 defines the type of non-body parameters directly in the parameter
+			schema = object
+		}
+
+		p := parameter{name: name, in: in, required: required || in == "path", schema: schema}
+		key := in + "/" + name
+		if index, found := byKey[key]; found {
+			parameters[index] = p
+			continue
+		}
+		byKey[key] = len(parameters)
+		parameters = append(parameters, p)
+	}
+
+	return parameters, body
+}
+
+func (s *Spec) openAPI3RequestBody(value interface{}) *requestBody {
+	object, ok := s.validator.resolve(value).(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	required, _ := object["required"].(bool)
+	body := &requestBody{required: required, content: map[string]map[string]interface{}{}}
+
+	content, _ := object["content"].(map[string]interface{})
+	for mediaType, value := range content {
+		mediaTypeObject, _ := value.(map[string]interface{})
+		schema, _ := mediaTypeObject["schema"].(map[string]interface{})
+		body.content[strings.ToLower(mediaType)] = schema
+	}
+
+	return body
+}
+
+func compilePathTemplate(template string) (*regexp.Regexp, []string, error) {
+	var names []string
+	var expression strings.Builder
+	expression.WriteString("^")
+
+	for _, segment := range strings.Split(strings.Trim(template, "/"), "/") {
+		expression.WriteString("/")
+		for segment != "" {
+			start := strings.Index(segment, "{")
+			end := strings.Index(segment, "}")
+			if start < 0 || end < start {
+				expression.WriteString(regexp.QuoteMeta(segment))
+				break
+			}
+			expression.WriteString(regexp.QuoteMeta(segment[:start]))
+			expression.WriteString("([^/]+)")
+			names = append(names, segment[start+1:end])
+			segment = segment[end+1:]
+		}
+	}
+	expression.WriteString("$")
+
+	pattern, err := regexp.Compile(expression.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid path '%s' in specification, %s", template, err)
+	}
+	return pattern, names, nil
+}
+
+// errBodyTooLarge is returned when the JSON body can't be validated without reading more than the maximum size into memory
+var errBodyTooLarge = errors.New("request body is too large to be validated")
+
+// Validate checks if the path, the method, the parameters and the JSON body of the request match the specification.
+// The path is relative to the target URL of the API. JSON bodies larger than maxBodySize bytes are rejected, because they have to be read into memory
+func (s *Spec) Validate(r *http.Request, path string, maxBodySize int64) apperrors.AppError {
+	path = "/" + strings.Trim(path, "/")
+
+	item, pathParameters := s.match(path)
+	if item == nil {
+		return apperrors.WrongInput("path '%s' is not defined in the API specification", path)
+	}
+
+	op, found := item.operations[r.Method]
+	if !found {
+		return apperrors.WrongInput("method %s is not allowed for path '%s' in the API specification", r.Method, item.template)
+	}
+
+	if err := s.validateParameters(r, op, pathParameters); err != nil {
+		return apperrors.WrongInput("request doesn't match the API specification, %s", err)
+	}
+
+	if op.body != nil {
+		err := s.validateBody(r, op.body, maxBodySize)
+		if errors.Is(err, errBodyTooLarge) {
+			return apperrors.RequestTooLarge("%s, the maximum size is %d bytes", err, maxBodySize)
+		}
+		if err != nil {
+			return apperrors.WrongInput("request doesn't match the API specification, %s", err)
+		}
+	}
+
+	return nil
+}
+
+// match finds the path item for the path relative to the target URL. The target URL may include the base path of the API or not
+func (s *Spec) match(path string) (*pathItem, map[string]string) {
+	candidates := []string{path}
+	if s.basePath != "" && strings.HasPrefix(path, s.basePath+"/") {
+		candidates = append(candidates, strings.TrimPrefix(path, s.basePath))
+	}
+
+	for _, candidate := range candidates {
+		for _, item := range s.paths {
+			matches := item.pattern.FindStringSubmatch(candidate)
+			if matches == nil {
+				continue
+			}
+			parameters := map[string]string{}
+			for i, name := range item.names {
+				value, err := url.PathUnescape(matches[i+1])
+				if err != nil {
+					value = matches[i+1]
+				}
+				parameters[name] = value
+			}
+			return item, parameters
+		}
+	}
+
+	return nil, nil
+}
+
+// validateParameters validates path and query parameters. Header parameters aren't validated, because the gateway adds some of them, like authorization, later
+func (s *Spec) validateParameters(r *http.Request, op *operation, pathParameters map[string]string) error {
+	query := r.URL.Query()
+
+	for _, p := range op.parameters {
+		var values []string
+		switch p.in {
+		case "path":
+			if value, found := pathParameters[p.name]; found {
+				values = []string{value}
+			}
+		case "query":
+			values = query[p.name]
+		default:
+			continue
+		}
+
+		location := location{subject: fmt.Sprintf("%s parameter '%s'", p.in, p.name)}
+		if len(values) == 0 {
+			if p.required {
+				return fmt.Errorf("%s is required", location)
+			}
+			continue
+		}
+
+		if p.schema == nil {
+			continue
+		}
+		if err := s.validator.validate(p.schema, parameterValue(s.validator.resolveSchema(p.schema), values), location, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parameterValue converts the parameter value to the type defined in its schema, so that the schema can be validated
+func parameterValue(schema map[string]interface{}, values []string) interface{} {
+	schemaType, _ := schema["type"].(string)
+	if schemaType == "array" {
+		items, _ := schema["items"].(map[string]interface{})
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		result := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			result = append(result, parameterValue(items, []string{value}))
+		}
+		return result
+	}
+
+	value := values[0]
+	switch schemaType {
+	case "integer", "number":
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+	return value
+}
+
+func (s *Spec) validateBody(r *http.Request, body *requestBody, maxBodySize int64) error {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		if body.required {
+			return fmt.Errorf("request body is required")
+		}
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(httpconsts.HeaderContentType))
+	if err != nil {
+		mediaType = ""
+	}
+
+	schema, supported := body.schema(mediaType)
+	if !supported {
+		return fmt.Errorf("content type '%s' is not supported by the operation", mediaType)
+	}
+	if schema == nil || !isJSON(mediaType) {
+		return nil
+	}
+
+	if r.ContentLength > maxBodySize {
+		return errBodyTooLarge
+	}
+
+	// the body of unknown length, e.g. chunked, is read only up to the maximum size
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read request body, %s", err)
+	}
+	if int64(len(data)) > maxBodySize {
+		return errBodyTooLarge
+	}
+	_ = r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	r.ContentLength = int64(len(data))
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("request body is not valid JSON, %s", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("request body is not valid JSON, unexpected data after the JSON value")
+	}
+
+	return s.validator.validate(schema, value, location{subject: "request body"}, 0)
+}
+
+// schema returns the schema of the body with the media type. Media types not defined in the specification are not supported
+func (b *requestBody) schema(mediaType string) (map[string]interface{}, bool) {
+	if len(b.content) == 0 {
+		return nil, true
+	}
+
+	if schema, found := b.content[mediaType]; found {
+		return schema, true
+	}
+
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	if schema, found := b.content[mainType+"/*"]; found {
+		return schema, true
+	}
+	if schema, found := b.content["*/*"]; found {
+		return schema, true
+	}
+
+	return nil, false
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
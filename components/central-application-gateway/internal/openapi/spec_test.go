@@ -0,0 +1,314 @@
+package openapi
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const maxBodySize = 1024
+
+const openAPI3Spec = `
+openapi: 3.0.1
+servers:
+  - url: https://orders.example.com/api/v1
+paths:
+  /orders:
+    get:
+      parameters:
+        - name: top
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Order'
+  /orders/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+    delete: {}
+  /orders/latest:
+    get: {}
+components:
+  schemas:
+    Order:
+      type: object
+      required: [product, quantity]
+      additionalProperties: false
+      properties:
+        product:
+          type: string
+          minLength: 1
+        quantity:
+          type: integer
+          minimum: 1
+        status:
+          type: string
+          enum: [open, closed]
+        note:
+          type: string
+          nullable: true
+        items:
+          type: array
+          maxItems: 2
+          items:
+            $ref: '#/components/schemas/Item'
+    Item:
+      type: object
+      properties:
+        sku:
+          type: string
+          pattern: '^[A-Z]{3}$'
+`
+
+const swagger2Spec = `{
+  "swagger": "2.0",
+  "basePath": "/api",
+  "paths": {
+    "/customers/{id}": {
+      "put": {
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "type": "string"},
+          {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Customer"}}
+        ]
+      }
+    }
+  },
+  "definitions": {
+    "Customer": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {"name": {"type": "string"}}
+    }
+  }
+}`
+
+func TestSpec_Validate(t *testing.T) {
+	openAPI3, err := Parse([]byte(openAPI3Spec))
+	require.NoError(t, err)
+
+	swagger2, err := Parse([]byte(swagger2Spec))
+	require.NoError(t, err)
+
+	newRequest := func(method, path, body string) *http.Request {
+		r := httptest.NewRequest(method, "/"+strings.TrimPrefix(path, "/"), strings.NewReader(body))
+		if body != "" {
+			r.Header.Set("Content-Type", "application/json")
+		}
+		return r
+	}
+
+	newXMLRequest := func(method, path, body string) *http.Request {
+		r := newRequest(method, path, body)
+		r.Header.Set("Content-Type", "application/xml")
+		return r
+	}
+
+	for _, testCase := range []struct {
+		description   string
+		spec          *Spec
+		request       *http.Request
+		expectedError string
+	}{
+		{
+			description: "should accept request matching the specification",
+			spec:        openAPI3,
+			request:     newRequest(http.MethodPost, "orders", `{"product":"tea","quantity":2,"note":null,"items":[{"sku":"TEA"}]}`),
+		},
+		{
+			description: "should accept request with path including base path",
+			spec:        openAPI3,
+			request:     newRequest(http.MethodGet, "api/v1/orders?top=5", ""),
+		},
+		{
+			description: "should accept request to concrete path before templated path",
+			spec:        openAPI3,
+			request:     newRequest(http.MethodGet, "orders/latest", ""),
+		},
+		{
+			description: "should accept request with path parameter",
+			spec:        openAPI3,
+			request:     newRequest(http.MethodDelete, "orders/12", ""),
+		},
+		{
+			description:   "should reject request with undefined path",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodGet, "customers", ""),
+			expectedError: "path '/customers' is not defined",
+		},
+		{
+			description:   "should reject request with undefined method",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodPut, "orders", ""),
+			expectedError: "method PUT is not allowed for path '/orders'",
+		},
+		{
+			description:   "should reject request with invalid path parameter",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodDelete, "orders/first", ""),
+			expectedError: "path parameter 'id' must be of type integer",
+		},
+		{
+			description:   "should reject request with invalid query parameter",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodGet, "orders?top=0", ""),
+			expectedError: "query parameter 'top' must be greater than or equal to 1",
+		},
+		{
+			description:   "should reject request without required body",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodPost, "orders", ""),
+			expectedError: "request body is required",
+		},
+		{
+			description:   "should reject request with malformed JSON body",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodPost, "orders", `{"product":`),
+			expectedError: "request body is not valid JSON",
+		},
+		{
+			description:   "should reject request without required property",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodPost, "orders", `{"product":"tea"}`),
+			expectedError: "request body is missing required property 'quantity'",
+		},
+		{
+			description:   "should reject request with property of wrong type",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodPost, "orders", `{"product":"tea","quantity":1.5}`),
+			expectedError: "request body property 'quantity' must be of type integer",
+		},
+		{
+			description:   "should reject request with value not in enum",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodPost, "orders", `{"product":"tea","quantity":1,"status":"lost"}`),
+			expectedError: "request body property 'status' must be one of [open closed]",
+		},
+		{
+			description:   "should reject request with additional property",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodPost, "orders", `{"product":"tea","quantity":1,"price":2}`),
+			expectedError: "request body property 'price' isn't allowed",
+		},
+		{
+			description:   "should reject request with invalid nested property",
+			spec:          openAPI3,
+			request:       newRequest(http.MethodPost, "orders", `{"product":"tea","quantity":1,"items":[{"sku":"tea"}]}`),
+			expectedError: "request body property 'items[0].sku' must match pattern",
+		},
+		{
+			description:   "should reject request with unsupported content type",
+			spec:          openAPI3,
+			request:       newXMLRequest(http.MethodPost, "orders", "<order/>"),
+			expectedError: "content type 'application/xml' is not supported",
+		},
+		{
+			description: "should accept Swagger 2 request matching the specification",
+			spec:        swagger2,
+			request:     newRequest(http.MethodPut, "customers/c1", `{"name":"John"}`),
+		},
+		{
+			description:   "should reject Swagger 2 request with invalid body",
+			spec:          swagger2,
+			request:       newRequest(http.MethodPut, "api/customers/c1", `{"name":1}`),
+			expectedError: "request body property 'name' must be of type string",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			// when
+			err := testCase.spec.Validate(testCase.request, testCase.request.URL.Path, maxBodySize)
+
+			// then
+			if testCase.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, apperrors.CodeWrongInput, err.Code())
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+
+	t.Run("should keep request body after validation", func(t *testing.T) {
+		// given
+		body := `{"product":"tea","quantity":2}`
+		request := newRequest(http.MethodPost, "orders", body)
+
+		// when
+		err := openAPI3.Validate(request, request.URL.Path, maxBodySize)
+
+		// then
+		require.NoError(t, err)
+		forwarded, readErr := ioutil.ReadAll(request.Body)
+		require.NoError(t, readErr)
+		assert.Equal(t, body, string(forwarded))
+		assert.Equal(t, int64(len(body)), request.ContentLength)
+	})
+
+	t.Run("should reject request body larger than maximum size", func(t *testing.T) {
+		// given
+		body := `{"product":"` + strings.Repeat("a", maxBodySize) + `"}`
+		request := newRequest(http.MethodPost, "orders", body)
+
+		// when
+		err := openAPI3.Validate(request, request.URL.Path, maxBodySize)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeRequestTooLarge, err.Code())
+	})
+
+	t.Run("should read request body of unknown length only up to maximum size", func(t *testing.T) {
+		// given
+		body := &countingReader{Reader: strings.NewReader(`{"product":"` + strings.Repeat("a", 10*maxBodySize) + `"}`)}
+		request := newRequest(http.MethodPost, "orders", "")
+		request.Header.Set("Content-Type", "application/json")
+		request.Body = ioutil.NopCloser(body)
+		request.ContentLength = -1
+
+		// when
+		err := openAPI3.Validate(request, request.URL.Path, maxBodySize)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeRequestTooLarge, err.Code())
+		assert.LessOrEqual(t, body.read, maxBodySize+1)
+	})
+}
+
+type countingReader struct {
+	io.Reader
+	read int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += n
+	return n, err
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should fail for document which isn't OpenAPI specification", func(t *testing.T) {
+		// when
+		_, err := Parse([]byte(`<edmx:Edmx Version="4.0"/>`))
+
+		// then
+		require.Error(t, err)
+	})
+}
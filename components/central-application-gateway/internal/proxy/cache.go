@@ -3,6 +3,7 @@ package proxy
 import (
 	"net/http"
 	"net/http/httputil"
+	"strings"
 	"time"
 
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf"
@@ -31,8 +32,8 @@ func (ce *authorizationStrategyWrapper) AddAuthorization(r *http.Request, skipTL
 	return ce.actualStrategy.AddAuthorization(r, ce.clientCertificate.SetCertificate, skipTLSVerify)
 }
 
-func (ce *authorizationStrategyWrapper) Invalidate() {
-	ce.actualStrategy.Invalidate()
+func (ce *authorizationStrategyWrapper) Invalidate(subjectToken string) {
+	ce.actualStrategy.Invalidate(subjectToken)
 }
 
 // Cache is an interface for caching Proxies
@@ -41,6 +42,8 @@ type Cache interface {
 	Get(appName, serviceName, apiName string) (*CacheEntry, bool)
 	// Put adds entry to the cache
 	Put(appName, serviceName, apiName string, reverseProxy *httputil.ReverseProxy, authorizationStrategy authorization.Strategy, csrfTokenStrategy csrf.TokenStrategy, clientCertificate clientcert.ClientCertificate) *CacheEntry
+	// RemoveApplication removes all entries of the Application from the cache
+	RemoveApplication(appName string)
 }
 
 type cache struct {
@@ -55,7 +58,7 @@ func NewCache(proxyCacheTTL int) Cache {
 }
 
 func (p *cache) Get(appName, serviceName, apiName string) (*CacheEntry, bool) {
-	key := appName + serviceName + apiName
+	key := cacheKey(appName, serviceName, apiName)
 	proxy, found := p.proxyCache.Get(key)
 	if !found {
 		return nil, false
@@ -65,9 +68,23 @@ func (p *cache) Get(appName, serviceName, apiName string) (*CacheEntry, bool) {
 }
 
 func (p *cache) Put(appName, serviceName, apiName string, reverseProxy *httputil.ReverseProxy, authorizationStrategy authorization.Strategy, csrfTokenStrategy csrf.TokenStrategy, clientCertificate clientcert.ClientCertificate) *CacheEntry {
-	key := appName + serviceName + apiName
+	key := cacheKey(appName, serviceName, apiName)
 	proxy := &CacheEntry{Proxy: reverseProxy, AuthorizationStrategy: &authorizationStrategyWrapper{authorizationStrategy, reverseProxy, clientCertificate}, CSRFTokenStrategy: csrfTokenStrategy}
 	p.proxyCache.Set(key, proxy, gocache.DefaultExpiration)
 
 	return proxy
 }
+
+func (p *cache) RemoveApplication(appName string) {
+	prefix := appName + "/"
+	for key := range p.proxyCache.Items() {
+		if strings.HasPrefix(key, prefix) {
+			p.proxyCache.Delete(key)
+		}
+	}
+}
+
+// cacheKey separates the names, so that the entries of the Application can be found by the key prefix
+func cacheKey(appName, serviceName, apiName string) string {
+	return appName + "/" + serviceName + "/" + apiName
+}
@@ -55,4 +55,28 @@ func TestCache(t *testing.T) {
 		assert.Equal(t, authorizationStrategyMock, cacheEntry.AuthorizationStrategy.actualStrategy)
 		assert.Equal(t, csrfTokenStrategy, cacheEntry.CSRFTokenStrategy)
 	})
+
+	t.Run("should remove entries of application", func(t *testing.T) {
+		// given
+		cache := NewCache(60)
+		invalidator := NewCacheInvalidator()
+		invalidator.register(cache)
+
+		proxy := httputil.NewSingleHostReverseProxy(net.FormatURL("http", "www.example.com", 8080, ""))
+		clientCertificate := clientcert.NewClientCertificate(nil)
+		cache.Put("app", "service1", "api1", proxy, &mocks.Strategy{}, &csrfmocks.TokenStrategy{}, clientCertificate)
+		cache.Put("app", "service2", "", proxy, &mocks.Strategy{}, &csrfmocks.TokenStrategy{}, clientCertificate)
+		cache.Put("app2", "service1", "api1", proxy, &mocks.Strategy{}, &csrfmocks.TokenStrategy{}, clientCertificate)
+
+		// when
+		invalidator.InvalidateApplication("app")
+
+		// then
+		_, found := cache.Get("app", "service1", "api1")
+		assert.False(t, found)
+		_, found = cache.Get("app", "service2", "")
+		assert.False(t, found)
+		_, found = cache.Get("app2", "service1", "api1")
+		assert.True(t, found)
+	})
 }
@@ -0,0 +1,41 @@
+package proxy
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CacheInvalidator removes the cached proxies of the Applications whose configuration changed,
+// so that the next request uses the new target URL and credentials
+type CacheInvalidator struct {
+	mutex  sync.RWMutex
+	caches []Cache
+}
+
+// NewCacheInvalidator creates the invalidator of the proxies created with it in Config
+func NewCacheInvalidator() *CacheInvalidator {
+	return &CacheInvalidator{}
+}
+
+// InvalidateApplication removes the cached proxies of all services of the Application
+func (i *CacheInvalidator) InvalidateApplication(appName string) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	log.Infof("Configuration of application %s changed, removing cached proxies", appName)
+	for _, cache := range i.caches {
+		cache.RemoveApplication(appName)
+	}
+}
+
+func (i *CacheInvalidator) register(cache Cache) {
+	if i == nil {
+		return
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.caches = append(i.caches, cache)
+}
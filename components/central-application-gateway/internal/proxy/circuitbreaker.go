@@ -0,0 +1,211 @@
+package proxy
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metrics"
+	log "github.com/sirupsen/logrus"
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreakerConfig stores configuration of circuit breakers created for target APIs
+type CircuitBreakerConfig struct {
+	// FailureRatio is the ratio of failed requests in the Window which opens the circuit, 0 disables circuit breakers
+	FailureRatio float64
+	// MinRequests is the minimum number of requests in the Window before the FailureRatio is evaluated
+	MinRequests int
+	// Window is the period in which requests are counted
+	Window time.Duration
+	// OpenDuration is the period in which requests are rejected before probing the target API again
+	OpenDuration time.Duration
+	// HalfOpenProbes is the number of successful probe requests which close the circuit
+	HalfOpenProbes int
+}
+
+func (c CircuitBreakerConfig) enabled() bool {
+	return c.FailureRatio > 0
+}
+
+func (c CircuitBreakerConfig) halfOpenProbes() int {
+	if c.HalfOpenProbes < 1 {
+		return 1
+	}
+	return c.HalfOpenProbes
+}
+
+type circuitBreaker struct {
+	mu     sync.Mutex
+	config CircuitBreakerConfig
+	labels model.APIIdentifier
+	now    func() time.Time
+
+	state          circuitState
+	windowStart    time.Time
+	requests       int
+	failures       int
+	openedAt       time.Time
+	probesInFlight int
+	probesPassed   int
+}
+
+func newCircuitBreaker(config CircuitBreakerConfig, apiIdentifier model.APIIdentifier) *circuitBreaker {
+	cb := &circuitBreaker{
+		config: config,
+		labels: apiIdentifier,
+		now:    time.Now,
+	}
+	cb.windowStart = cb.now()
+	metrics.CircuitBreakerState.With(apiLabels(apiIdentifier)).Set(float64(circuitClosed))
+	return cb
+}
+
+// allow returns false if the request can't be sent to the target API
+func (cb *circuitBreaker) allow() bool {
+	if cb == nil {
+		return true
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.config.OpenDuration {
+			return false
+		}
+		cb.setState(circuitHalfOpen)
+		fallthrough
+	case circuitHalfOpen:
+		if cb.probesInFlight >= cb.config.halfOpenProbes()-cb.probesPassed {
+			return false
+		}
+		cb.probesInFlight++
+		return true
+	default:
+		return true
+	}
+}
+
+// report records the result of the request allowed by the circuit breaker
+func (cb *circuitBreaker) report(success bool) {
+	if cb == nil {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitHalfOpen:
+		if cb.probesInFlight > 0 {
+			cb.probesInFlight--
+		}
+		if !success {
+			cb.setState(circuitOpen)
+			return
+		}
+		cb.probesPassed++
+		if cb.probesPassed >= cb.config.halfOpenProbes() {
+			cb.setState(circuitClosed)
+		}
+	case circuitClosed:
+		if cb.now().Sub(cb.windowStart) > cb.config.Window {
+			cb.resetWindow()
+		}
+		cb.requests++
+		if !success {
+			cb.failures++
+		}
+		if cb.requests >= cb.config.MinRequests && float64(cb.failures)/float64(cb.requests) >= cb.config.FailureRatio {
+			cb.setState(circuitOpen)
+		}
+	}
+}
+
+// release forgets the request allowed by the circuit breaker without recording its result
+func (cb *circuitBreaker) release() {
+	if cb == nil {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == circuitHalfOpen && cb.probesInFlight > 0 {
+		cb.probesInFlight--
+	}
+}
+
+func (cb *circuitBreaker) setState(state circuitState) {
+	log.Infof("Circuit breaker for application '%s', service '%s', entry '%s' changed state from %s to %s",
+		cb.labels.Application, cb.labels.Service, cb.labels.Entry, cb.state, state)
+
+	cb.state = state
+	cb.probesInFlight = 0
+	cb.probesPassed = 0
+	cb.resetWindow()
+	if state == circuitOpen {
+		cb.openedAt = cb.now()
+	}
+	metrics.CircuitBreakerState.With(apiLabels(cb.labels)).Set(float64(state))
+}
+
+func (cb *circuitBreaker) resetWindow() {
+	cb.windowStart = cb.now()
+	cb.requests = 0
+	cb.failures = 0
+}
+
+// circuitBreakers keeps circuit breakers of target APIs, so that their state survives proxy cache expiration
+type circuitBreakers struct {
+	mu       sync.Mutex
+	config   CircuitBreakerConfig
+	breakers map[model.APIIdentifier]*circuitBreaker
+}
+
+func newCircuitBreakers(config CircuitBreakerConfig) *circuitBreakers {
+	return &circuitBreakers{
+		config:   config,
+		breakers: map[model.APIIdentifier]*circuitBreaker{},
+	}
+}
+
+// get returns the circuit breaker of the target API or nil if circuit breakers are disabled
+func (c *circuitBreakers) get(apiIdentifier model.APIIdentifier) *circuitBreaker {
+	if c == nil || !c.config.enabled() {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cb, found := c.breakers[apiIdentifier]
+	if !found {
+		cb = newCircuitBreaker(c.config, apiIdentifier)
+		c.breakers[apiIdentifier] = cb
+	}
+	return cb
+}
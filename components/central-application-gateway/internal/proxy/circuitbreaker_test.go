@@ -0,0 +1,110 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	config := CircuitBreakerConfig{
+		FailureRatio:   0.5,
+		MinRequests:    4,
+		Window:         time.Minute,
+		OpenDuration:   30 * time.Second,
+		HalfOpenProbes: 2,
+	}
+
+	newTestCircuitBreaker := func() (*circuitBreaker, *time.Time) {
+		now := time.Now()
+		cb := newCircuitBreaker(config, model.APIIdentifier{Application: "app", Service: "service"})
+		cb.now = func() time.Time { return now }
+		return cb, &now
+	}
+
+	t.Run("should stay closed below minimum number of requests", func(t *testing.T) {
+		cb, _ := newTestCircuitBreaker()
+
+		for i := 0; i < 3; i++ {
+			require.True(t, cb.allow())
+			cb.report(false)
+		}
+
+		assert.Equal(t, circuitClosed, cb.state)
+	})
+
+	t.Run("should open when failure ratio is reached", func(t *testing.T) {
+		cb, _ := newTestCircuitBreaker()
+
+		for _, success := range []bool{true, false, true, false} {
+			require.True(t, cb.allow())
+			cb.report(success)
+		}
+
+		assert.Equal(t, circuitOpen, cb.state)
+		assert.False(t, cb.allow())
+	})
+
+	t.Run("should forget failures from previous window", func(t *testing.T) {
+		cb, now := newTestCircuitBreaker()
+
+		for i := 0; i < 3; i++ {
+			cb.report(false)
+		}
+		*now = now.Add(2 * time.Minute)
+		cb.report(false)
+
+		assert.Equal(t, circuitClosed, cb.state)
+	})
+
+	t.Run("should close after successful probes", func(t *testing.T) {
+		cb, now := newTestCircuitBreaker()
+		cb.setState(circuitOpen)
+
+		*now = now.Add(config.OpenDuration)
+
+		require.True(t, cb.allow())
+		require.True(t, cb.allow())
+		require.False(t, cb.allow(), "only configured number of probes should be allowed")
+		assert.Equal(t, circuitHalfOpen, cb.state)
+
+		cb.report(true)
+		cb.report(true)
+
+		assert.Equal(t, circuitClosed, cb.state)
+		assert.True(t, cb.allow())
+	})
+
+	t.Run("should open again after failed probe", func(t *testing.T) {
+		cb, now := newTestCircuitBreaker()
+		cb.setState(circuitOpen)
+
+		*now = now.Add(config.OpenDuration)
+
+		require.True(t, cb.allow())
+		cb.report(false)
+
+		assert.Equal(t, circuitOpen, cb.state)
+		assert.False(t, cb.allow())
+	})
+
+	t.Run("should not create circuit breakers when disabled", func(t *testing.T) {
+		breakers := newCircuitBreakers(CircuitBreakerConfig{})
+
+		cb := breakers.get(model.APIIdentifier{Application: "app", Service: "service"})
+
+		assert.Nil(t, cb)
+		assert.True(t, cb.allow())
+	})
+
+	t.Run("should share circuit breaker of the same API", func(t *testing.T) {
+		breakers := newCircuitBreakers(config)
+		apiIdentifier := model.APIIdentifier{Application: "app", Service: "service", Entry: "entry"}
+
+		assert.Same(t, breakers.get(apiIdentifier), breakers.get(apiIdentifier))
+		assert.NotSame(t, breakers.get(apiIdentifier), breakers.get(model.APIIdentifier{Application: "app", Service: "other"}))
+	})
+}
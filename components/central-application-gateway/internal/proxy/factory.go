@@ -48,13 +48,21 @@ func New(
 		serviceDefService: serviceDefService,
 	}
 
+	cache := NewCache(config.ProxyCacheTTL)
+	config.CacheInvalidator.register(cache)
+
 	return &proxy{
-		cache:                        NewCache(config.ProxyCacheTTL),
+		cache:                        cache,
 		proxyTimeout:                 config.ProxyTimeout,
 		authorizationStrategyFactory: authorizationStrategyFactory,
 		csrfTokenStrategyFactory:     csrfTokenStrategyFactory,
 		extractPathFunc:              pathExtractor,
 		apiExtractor:                 apiExtractor,
+		circuitBreakers:              newCircuitBreakers(config.CircuitBreaker),
+		retryConfig:                  config.Retry,
+		responseCache:                newResponseCache(config.ResponseCache),
+		requestValidator:             newRequestValidator(config.ValidateRequests, config.ValidationMaxBodySize, config.ProxyCacheTTL, config.ProxyTimeout),
+		accessController:             config.AccessController,
 	}
 }
 
@@ -91,13 +99,21 @@ func NewForCompass(
 		serviceDefService: serviceDefService,
 	}
 
+	cache := NewCache(config.ProxyCacheTTL)
+	config.CacheInvalidator.register(cache)
+
 	return &proxy{
-		cache:                        NewCache(config.ProxyCacheTTL),
+		cache:                        cache,
 		proxyTimeout:                 config.ProxyTimeout,
 		authorizationStrategyFactory: authorizationStrategyFactory,
 		csrfTokenStrategyFactory:     csrfTokenStrategyFactory,
 		extractPathFunc:              extractFunc,
 		apiExtractor:                 apiExtractor,
+		circuitBreakers:              newCircuitBreakers(config.CircuitBreaker),
+		retryConfig:                  config.Retry,
+		responseCache:                newResponseCache(config.ResponseCache),
+		requestValidator:             newRequestValidator(config.ValidateRequests, config.ValidationMaxBodySize, config.ProxyCacheTTL, config.ProxyTimeout),
+		accessController:             config.AccessController,
 	}
 }
 
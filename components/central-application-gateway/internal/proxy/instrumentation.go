@@ -0,0 +1,57 @@
+package proxy
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// statusRecorder remembers the status code returned by the proxy
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func newStatusRecorder(w http.ResponseWriter) *statusRecorder {
+	return &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.status = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer %T doesn't support hijacking", r.ResponseWriter)
+	}
+	r.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// instrumentedCSRFTokenStrategy counts refreshes of the CSRF token of the target API
+type instrumentedCSRFTokenStrategy struct {
+	csrf.TokenStrategy
+	labels prometheus.Labels
+}
+
+func (s *instrumentedCSRFTokenStrategy) Invalidate() {
+	metrics.CSRFTokenRefreshes.With(s.labels).Inc()
+	s.TokenStrategy.Invalidate()
+}
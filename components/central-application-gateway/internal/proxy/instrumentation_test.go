@@ -0,0 +1,110 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	csrfMock "github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf/mocks"
+	metadatamodel "github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metrics"
+	proxyMocks "github.com/kyma-project/kyma/components/central-application-gateway/internal/proxy/mocks"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	authMock "github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/mocks"
+)
+
+func TestProxyMetrics(t *testing.T) {
+	apiIdentifier := metadatamodel.APIIdentifier{
+		Application: "metrics-app",
+		Service:     "metrics-service",
+		Entry:       "metrics-entry",
+	}
+	labels := apiLabels(apiIdentifier)
+
+	ts := NewTestServer(func(req *http.Request) {})
+	defer ts.Close()
+
+	authStrategyMock := &authMock.Strategy{}
+	authStrategyMock.
+		On("AddAuthorization", mock.MatchedBy(func(r *http.Request) bool {
+			return metrics.APILabelsFromContext(r.Context())["application"] == apiIdentifier.Application
+		}), mock.AnythingOfType("SetClientCertificateFunc"), false).
+		Return(nil).
+		Twice()
+
+	authStrategyFactoryMock := &authMock.StrategyFactory{}
+	authStrategyFactoryMock.On("Create", mock.Anything).Return(authStrategyMock).Once()
+
+	csrfFactoryMock, csrfStrategyMock := mockCSRFStrategy(authStrategyMock, func(mockCall *mock.Call) { mockCall.Twice() }, false)
+
+	apiExtractorMock := &proxyMocks.APIExtractor{}
+	apiExtractorMock.On("Get", apiIdentifier).Return(&metadatamodel.API{TargetUrl: ts.URL}, nil).Twice()
+
+	handler := newProxyForTest(apiExtractorMock, authStrategyFactoryMock, csrfFactoryMock, func(u *url.URL) (metadatamodel.APIIdentifier, string, *url.URL, apperrors.AppError) {
+		return apiIdentifier, u.Path, u, nil
+	}, nil, createProxyConfig(10))
+
+	for i := 0; i < 2; i++ {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/orders", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.Requests.With(metrics.RequestLabels(labels, http.StatusOK))))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ProxyCacheMisses.With(labels)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ProxyCacheHits.With(labels)))
+	authStrategyMock.AssertExpectations(t)
+	csrfStrategyMock.AssertExpectations(t)
+}
+
+func TestProxyMetricsOfUnknownAPI(t *testing.T) {
+	apiIdentifier := metadatamodel.APIIdentifier{
+		Application: "not-existing-app",
+		Service:     "not-existing-service",
+		Entry:       "not-existing-entry",
+	}
+
+	apiExtractorMock := &proxyMocks.APIExtractor{}
+	apiExtractorMock.On("Get", apiIdentifier).Return(nil, apperrors.NotFound("application not found")).Once()
+
+	handler := newProxyForTest(apiExtractorMock, &authMock.StrategyFactory{}, &csrfMock.TokenStrategyFactory{}, func(u *url.URL) (metadatamodel.APIIdentifier, string, *url.URL, apperrors.AppError) {
+		return apiIdentifier, u.Path, u, nil
+	}, nil, createProxyConfig(10))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.Requests.With(metrics.RequestLabels(metrics.UnknownAPILabels(), http.StatusNotFound))))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.Requests.With(metrics.RequestLabels(apiLabels(apiIdentifier), http.StatusNotFound))))
+	apiExtractorMock.AssertExpectations(t)
+}
+
+func TestInstrumentedCSRFTokenStrategy(t *testing.T) {
+	labels := apiLabels(metadatamodel.APIIdentifier{Application: "csrf-app", Service: "csrf-service"})
+	csrfTokenStrategyMock := &csrfMock.TokenStrategy{}
+	csrfTokenStrategyMock.On("Invalidate").Return().Once()
+
+	strategy := &instrumentedCSRFTokenStrategy{TokenStrategy: csrfTokenStrategyMock, labels: labels}
+	strategy.Invalidate()
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.CSRFTokenRefreshes.With(labels)))
+	csrfTokenStrategyMock.AssertExpectations(t)
+}
+
+func TestStatusRecorder(t *testing.T) {
+	rr := httptest.NewRecorder()
+	recorder := newStatusRecorder(rr)
+
+	assert.Equal(t, http.StatusOK, recorder.status, "status should default to 200 OK")
+
+	recorder.WriteHeader(http.StatusBadGateway)
+
+	assert.Equal(t, http.StatusBadGateway, recorder.status)
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+}
@@ -8,16 +8,20 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/accesscontrol"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/httperrors"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metrics"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/clientcert"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/proxyconfig"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type proxy struct {
@@ -29,6 +33,11 @@ type proxy struct {
 	extractPathFunc              pathExtractorFunc
 	extractGatewayFunc           gatewayURLExtractorFunc
 	apiExtractor                 APIExtractor
+	circuitBreakers              *circuitBreakers
+	retryConfig                  RetryConfig
+	responseCache                *responseCache
+	requestValidator             *requestValidator
+	accessController             accesscontrol.Controller
 }
 
 //go:generate mockery --name=APIExtractor
@@ -38,9 +47,20 @@ type APIExtractor interface {
 
 // Config stores Proxy config
 type Config struct {
-	ProxyTimeout  int
-	Application   string
-	ProxyCacheTTL int
+	ProxyTimeout   int
+	Application    string
+	ProxyCacheTTL  int
+	CircuitBreaker CircuitBreakerConfig
+	Retry          RetryConfig
+	ResponseCache  ResponseCacheConfig
+	// ValidateRequests enables validation of requests against the OpenAPI specification of the target API
+	ValidateRequests bool
+	// ValidationMaxBodySize is the maximum size, in bytes, of the JSON request body read into memory to validate it
+	ValidationMaxBodySize int64
+	// AccessController authorizes callers of the services. All callers are allowed if it is nil
+	AccessController accesscontrol.Controller
+	// CacheInvalidator removes the cached proxies when the configuration of the Application changes. The proxies expire after ProxyCacheTTL if it is nil
+	CacheInvalidator *CacheInvalidator
 }
 
 func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -50,31 +70,58 @@ func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	labels := metrics.UnknownAPILabels()
+	recorder := newStatusRecorder(w)
+	start := time.Now()
+	defer func() {
+		metrics.RequestDuration.With(labels).Observe(time.Since(start).Seconds())
+		metrics.Requests.With(metrics.RequestLabels(labels, recorder.status)).Inc()
+	}()
+	w = recorder
+
 	r.URL.Path = path
 
+	if p.accessController != nil {
+		err = p.accessController.Authorize(r, apiIdentifier)
+		if err != nil {
+			handleErrors(w, err)
+			return
+		}
+	}
+
 	serviceAPI, err := p.apiExtractor.Get(apiIdentifier)
 	if err != nil {
 		handleErrors(w, err)
 		return
 	}
+	labels = apiLabels(apiIdentifier)
 
-	cacheEntry, err := p.getOrCreateCacheEntry(apiIdentifier, *serviceAPI)
+	err = p.requestValidator.validate(apiIdentifier, serviceAPI, r)
 	if err != nil {
 		handleErrors(w, err)
 		return
 	}
 
-	newRequest, cancel := p.setRequestTimeout(r)
-	defer cancel()
-
-	err = p.addAuthorization(newRequest, cacheEntry, serviceAPI.SkipVerify)
+	cacheEntry, err := p.getOrCreateCacheEntry(apiIdentifier, *serviceAPI)
 	if err != nil {
 		handleErrors(w, err)
 		return
 	}
 
-	cacheEntry.Proxy.ModifyResponse = responseModifier(gwURL, serviceAPI.TargetUrl, urlRewriter)
-	cacheEntry.Proxy.ServeHTTP(w, newRequest)
+	newRequest, cancel := p.setRequestTimeout(r)
+	defer cancel()
+	newRequest = newRequest.WithContext(authorization.ContextWithUserToken(metrics.ContextWithAPILabels(newRequest.Context(), labels), r))
+
+	p.responseCache.serve(w, newRequest, apiIdentifier, labels, func(w http.ResponseWriter, r *http.Request) {
+		err := p.addAuthorization(r, cacheEntry, serviceAPI.SkipVerify)
+		if err != nil {
+			handleErrors(w, err)
+			return
+		}
+
+		cacheEntry.Proxy.ModifyResponse = responseModifier(gwURL, serviceAPI.TargetUrl, serviceAPI.RewriteRules, urlRewriter)
+		cacheEntry.Proxy.ServeHTTP(w, r)
+	})
 }
 
 func (p *proxy) extractPath(u *url.URL) (model.APIIdentifier, string, *url.URL, apperrors.AppError) {
@@ -90,9 +137,11 @@ func (p *proxy) getOrCreateCacheEntry(apiIdentifier model.APIIdentifier, service
 	cacheObj, found := p.cache.Get(apiIdentifier.Application, apiIdentifier.Service, apiIdentifier.Entry)
 
 	if found {
+		metrics.ProxyCacheHits.With(apiLabels(apiIdentifier)).Inc()
 		return cacheObj, nil
 	}
 
+	metrics.ProxyCacheMisses.With(apiLabels(apiIdentifier)).Inc()
 	return p.createCacheEntry(apiIdentifier, serviceAPI)
 }
 
@@ -100,7 +149,15 @@ func (p *proxy) createCacheEntry(apiIdentifier model.APIIdentifier, serviceAPI m
 	clientCertificate := clientcert.NewClientCertificate(nil)
 	authorizationStrategy := p.newAuthorizationStrategy(serviceAPI.Credentials)
 	csrfTokenStrategy := p.newCSRFTokenStrategy(authorizationStrategy, serviceAPI.Credentials)
-	proxy, err := makeProxy(serviceAPI.TargetUrl, serviceAPI.RequestParameters, apiIdentifier.Service, serviceAPI.SkipVerify, authorizationStrategy, csrfTokenStrategy, clientCertificate, p.proxyTimeout)
+	if serviceAPI.Credentials != nil && serviceAPI.Credentials.CSRFTokenEndpointURL != "" {
+		csrfTokenStrategy = &instrumentedCSRFTokenStrategy{TokenStrategy: csrfTokenStrategy, labels: apiLabels(apiIdentifier)}
+	}
+	resilienceConfig := resilienceConfig{
+		apiIdentifier:  apiIdentifier,
+		circuitBreaker: p.circuitBreakers.get(apiIdentifier),
+		retry:          p.retryConfig,
+	}
+	proxy, err := makeProxy(serviceAPI.TargetUrl, serviceAPI.RequestParameters, serviceAPI.RewriteRules, apiIdentifier.Service, serviceAPI.SkipVerify, authorizationStrategy, csrfTokenStrategy, clientCertificate, p.proxyTimeout, resilienceConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +186,12 @@ func (p *proxy) newCSRFTokenStrategyFromCSRFConfig(authorizationStrategy authori
 }
 
 func (p *proxy) setRequestTimeout(r *http.Request) (*http.Request, context.CancelFunc) {
+	if isUpgradeRequest(r) {
+		// the upgraded connection, like WebSocket, stays open until the caller or the target API closes it
+		ctx, cancel := context.WithCancel(context.Background())
+		return r.WithContext(ctx), cancel
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(p.proxyTimeout)*time.Second)
 	newRequest := r.WithContext(ctx)
 
@@ -146,6 +209,31 @@ func (p *proxy) addAuthorization(r *http.Request, cacheEntry *CacheEntry, skipTL
 	return cacheEntry.CSRFTokenStrategy.AddCSRFToken(r, skipTLSVerify)
 }
 
+// isUpgradeRequest returns true if the caller requests switching the protocol, for example to WebSocket
+func isUpgradeRequest(r *http.Request) bool {
+	for _, value := range r.Header.Values(httpconsts.HeaderConnection) {
+		for _, token := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return r.Header.Get(httpconsts.HeaderUpgrade) != ""
+			}
+		}
+	}
+	return false
+}
+
+// canBufferBody returns true if the request body can be kept in memory to repeat the request.
+// maxSize equal to 0 means no limit
+func canBufferBody(r *http.Request, maxSize int64) bool {
+	if isUpgradeRequest(r) {
+		return false
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return true
+	}
+	// the length of chunked and other streamed bodies is unknown
+	return r.ContentLength > 0 && (maxSize <= 0 || r.ContentLength <= maxSize)
+}
+
 func copyRequestBody(r *http.Request) (io.ReadCloser, apperrors.AppError) {
 	if r.Body == nil {
 		return nil, nil
@@ -174,6 +262,10 @@ func drainBody(b io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
 	return ioutil.NopCloser(&buf), ioutil.NopCloser(bytes.NewReader(buf.Bytes())), nil
 }
 
+func apiLabels(apiIdentifier model.APIIdentifier) prometheus.Labels {
+	return metrics.APILabels(apiIdentifier.Application, apiIdentifier.Service, apiIdentifier.Entry)
+}
+
 func handleErrors(w http.ResponseWriter, apperr apperrors.AppError) {
 	code, body := httperrors.AppErrorToResponse(apperr)
 	respondWithBody(w, code, body)
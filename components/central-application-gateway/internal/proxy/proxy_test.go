@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	accessControlMock "github.com/kyma-project/kyma/components/central-application-gateway/internal/accesscontrol/mocks"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf"
 	csrfMock "github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf/mocks"
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
@@ -392,7 +393,7 @@ func TestProxy(t *testing.T) {
 		authStrategyMock.
 			On("AddAuthorization", mock.Anything, mock.AnythingOfType("SetClientCertificateFunc"), false).
 			Return(nil).Twice()
-		authStrategyMock.On("Invalidate").Return().Once()
+		authStrategyMock.On("Invalidate", "").Return().Once()
 
 		csrfTokenStrategyMock := &csrfMock.TokenStrategy{}
 		csrfTokenStrategyMock.On("AddCSRFToken", mock.AnythingOfType("*http.Request"), false).Return(nil).Twice()
@@ -482,6 +483,9 @@ func newProxyForTest(
 		extractPathFunc:              pathExtractorFunc,
 		extractGatewayFunc:           extractGatewayFunc,
 		apiExtractor:                 apiExtractor,
+		responseCache:                newResponseCache(proxyConfig.ResponseCache),
+		requestValidator:             newRequestValidator(proxyConfig.ValidateRequests, proxyConfig.ValidationMaxBodySize, proxyConfig.ProxyCacheTTL, proxyConfig.ProxyTimeout),
+		accessController:             proxyConfig.AccessController,
 	}
 }
 
@@ -503,9 +507,10 @@ func NewTestServerForRetryTest(status int, check func(req *http.Request)) *httpt
 
 func createProxyConfig(proxyTimeout int) Config {
 	return Config{
-		ProxyTimeout:  proxyTimeout,
-		Application:   "test",
-		ProxyCacheTTL: proxyTimeout,
+		ProxyTimeout:          proxyTimeout,
+		Application:           "test",
+		ProxyCacheTTL:         proxyTimeout,
+		ValidationMaxBodySize: 1024,
 	}
 }
 
@@ -572,3 +577,33 @@ type ensureCalledFunc func(mockCall *mock.Call)
 func calledOnce(mockCall *mock.Call) {
 	mockCall.Once()
 }
+
+func TestProxyAccessControl(t *testing.T) {
+	apiIdentifier := metadatamodel.APIIdentifier{Application: "app", Service: "service"}
+
+	t.Run("should reject caller before reading the API", func(t *testing.T) {
+		// given
+		accessControllerMock := &accessControlMock.Controller{}
+		accessControllerMock.On("Authorize", mock.AnythingOfType("*http.Request"), apiIdentifier).Return(apperrors.Forbidden("caller not allowed")).Once()
+
+		apiExtractorMock := &proxyMocks.APIExtractor{}
+
+		config := createProxyConfig(10)
+		config.AccessController = accessControllerMock
+		handler := newProxyForTest(apiExtractorMock, &authMock.StrategyFactory{}, &csrfMock.TokenStrategyFactory{}, func(u *url.URL) (metadatamodel.APIIdentifier, string, *url.URL, apperrors.AppError) {
+			return apiIdentifier, u.Path, u, nil
+		}, nil, config)
+
+		req, err := http.NewRequest(http.MethodGet, "/orders", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+
+		// when
+		handler.ServeHTTP(rr, req)
+
+		// then
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		accessControllerMock.AssertExpectations(t)
+		apiExtractorMock.AssertNotCalled(t, "Get", mock.Anything)
+	})
+}
@@ -0,0 +1,132 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/openapi"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	gocache "github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// maxSpecificationSize limits the size of the downloaded API specification
+	maxSpecificationSize = 10 * 1024 * 1024
+	// specificationFetchBackoff is the time for which the failed fetch of the specification is reported without fetching it again
+	specificationFetchBackoff = 30 * time.Second
+)
+
+// requestValidator rejects requests which don't match the OpenAPI specification of the target API.
+// The parsed specifications are cached for the same time as the proxy configuration
+type requestValidator struct {
+	specs          *gocache.Cache
+	client         *http.Client
+	insecureClient *http.Client
+	maxBodySize    int64
+}
+
+// cachedSpec stores the parsed specification. Spec is nil if the specification isn't in the OpenAPI format.
+// Err is the error of the failed fetch, which is cached for a short time so that the specification URL isn't called for every request
+type cachedSpec struct {
+	spec *openapi.Spec
+	err  apperrors.AppError
+}
+
+func newRequestValidator(enabled bool, maxBodySize int64, cacheTTL, timeout int) *requestValidator {
+	if !enabled {
+		return nil
+	}
+
+	newClient := func(skipVerify bool) *http.Client {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: skipVerify}
+		return &http.Client{Transport: transport, Timeout: time.Duration(timeout) * time.Second}
+	}
+
+	return &requestValidator{
+		specs:          gocache.New(time.Duration(cacheTTL)*time.Second, cleanupInterval*time.Second),
+		client:         newClient(false),
+		insecureClient: newClient(true),
+		maxBodySize:    maxBodySize,
+	}
+}
+
+// validate checks the request against the specification of the API. Requests to APIs without the OpenAPI specification aren't validated
+func (v *requestValidator) validate(apiIdentifier model.APIIdentifier, api *model.API, r *http.Request) apperrors.AppError {
+	if v == nil || !isOpenAPIType(api.APIType) || (len(api.Spec) == 0 && api.SpecificationURL == "") {
+		return nil
+	}
+
+	spec, err := v.getSpec(apiIdentifier, api)
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		return nil
+	}
+
+	return spec.Validate(r, r.URL.Path, v.maxBodySize)
+}
+
+func (v *requestValidator) getSpec(apiIdentifier model.APIIdentifier, api *model.API) (*openapi.Spec, apperrors.AppError) {
+	key := strings.Join([]string{apiIdentifier.Application, apiIdentifier.Service, apiIdentifier.Entry, api.SpecificationURL}, "/")
+	if cached, found := v.specs.Get(key); found {
+		return cached.(cachedSpec).spec, cached.(cachedSpec).err
+	}
+
+	data := api.Spec
+	if len(data) == 0 {
+		var err apperrors.AppError
+		data, err = v.fetchSpec(api.SpecificationURL, api.SkipVerify)
+		if err != nil {
+			v.specs.Set(key, cachedSpec{err: err}, specificationFetchBackoff)
+			return nil, err
+		}
+	}
+
+	spec, parseErr := openapi.Parse(data)
+	if parseErr != nil {
+		log.Warnf("Requests to %s/%s/%s aren't validated: %s", apiIdentifier.Application, apiIdentifier.Service, apiIdentifier.Entry, parseErr)
+	}
+	v.specs.Set(key, cachedSpec{spec: spec}, gocache.DefaultExpiration)
+
+	return spec, nil
+}
+
+func (v *requestValidator) fetchSpec(specificationURL string, skipVerify bool) ([]byte, apperrors.AppError) {
+	client := v.client
+	if skipVerify {
+		client = v.insecureClient
+	}
+
+	res, err := client.Get(specificationURL)
+	if err != nil {
+		return nil, apperrors.UpstreamServerCallFailed("failed to get API specification, %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, apperrors.UpstreamServerCallFailed("failed to get API specification, status code %d", res.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, maxSpecificationSize+1))
+	if err != nil {
+		return nil, apperrors.UpstreamServerCallFailed("failed to read API specification, %s", err)
+	}
+	if len(data) > maxSpecificationSize {
+		return nil, apperrors.UpstreamServerCallFailed("API specification is larger than %d bytes", maxSpecificationSize)
+	}
+
+	return data, nil
+}
+
+func isOpenAPIType(apiType string) bool {
+	normalized := strings.ToLower(strings.ReplaceAll(apiType, "_", ""))
+	return normalized == "" || normalized == "openapi"
+}
@@ -0,0 +1,169 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	metadatamodel "github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	proxyMocks "github.com/kyma-project/kyma/components/central-application-gateway/internal/proxy/mocks"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	authMock "github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/mocks"
+)
+
+const ordersSpec = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/orders": {
+      "post": {
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {"type": "object", "required": ["product"], "properties": {"product": {"type": "string"}}}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestProxyRequestValidation(t *testing.T) {
+	apiIdentifier := metadatamodel.APIIdentifier{Application: "app", Service: "service"}
+
+	specRequests := 0
+	specServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		specRequests++
+		_, _ = w.Write([]byte(ordersSpec))
+	}))
+	defer specServer.Close()
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer backend.Close()
+
+	newHandler := func(api *metadatamodel.API, validateRequests bool) (http.Handler, *authMock.Strategy) {
+		authStrategyMock := &authMock.Strategy{}
+		authStrategyMock.On("AddAuthorization", mock.AnythingOfType("*http.Request"), mock.AnythingOfType("SetClientCertificateFunc"), false).Return(nil)
+
+		authStrategyFactoryMock := &authMock.StrategyFactory{}
+		authStrategyFactoryMock.On("Create", mock.Anything).Return(authStrategyMock)
+
+		csrfFactoryMock, _ := mockCSRFStrategy(authStrategyMock, func(mockCall *mock.Call) {}, false)
+
+		apiExtractorMock := &proxyMocks.APIExtractor{}
+		apiExtractorMock.On("Get", apiIdentifier).Return(api, nil)
+
+		config := createProxyConfig(10)
+		config.ValidateRequests = validateRequests
+		handler := newProxyForTest(apiExtractorMock, authStrategyFactoryMock, csrfFactoryMock, func(u *url.URL) (metadatamodel.APIIdentifier, string, *url.URL, apperrors.AppError) {
+			return apiIdentifier, u.Path, u, nil
+		}, nil, config)
+
+		return handler, authStrategyMock
+	}
+
+	newRequest := func(path, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("should proxy request matching the specification and reject other requests before adding authorization", func(t *testing.T) {
+		// given
+		api := &metadatamodel.API{TargetUrl: backend.URL, SpecificationURL: specServer.URL, APIType: "OPEN_API"}
+		handler, authStrategyMock := newHandler(api, true)
+
+		// when
+		valid := httptest.NewRecorder()
+		handler.ServeHTTP(valid, newRequest("/orders", `{"product":"tea"}`))
+
+		invalidBody := httptest.NewRecorder()
+		handler.ServeHTTP(invalidBody, newRequest("/orders", `{"product":1}`))
+
+		invalidPath := httptest.NewRecorder()
+		handler.ServeHTTP(invalidPath, newRequest("/customers", `{}`))
+
+		// then
+		assert.Equal(t, http.StatusCreated, valid.Code)
+		assert.Equal(t, http.StatusBadRequest, invalidBody.Code)
+		assert.Contains(t, invalidBody.Body.String(), "request body property 'product' must be of type string")
+		assert.Equal(t, http.StatusBadRequest, invalidPath.Code)
+		assert.Contains(t, invalidPath.Body.String(), "path '/customers' is not defined")
+		assert.Equal(t, 1, specRequests, "specification should be cached")
+		authStrategyMock.AssertNumberOfCalls(t, "AddAuthorization", 1)
+	})
+
+	t.Run("should not validate requests when validation is disabled", func(t *testing.T) {
+		// given
+		api := &metadatamodel.API{TargetUrl: backend.URL, SpecificationURL: specServer.URL}
+		handler, _ := newHandler(api, false)
+
+		// when
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest("/customers", `{}`))
+
+		// then
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should not validate requests to API with specification of other type", func(t *testing.T) {
+		// given
+		api := &metadatamodel.API{TargetUrl: backend.URL, SpecificationURL: specServer.URL, APIType: "ODATA"}
+		handler, _ := newHandler(api, true)
+
+		// when
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest("/customers", `{}`))
+
+		// then
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should return error when specification can't be fetched and not fetch it again for every request", func(t *testing.T) {
+		// given
+		notFoundRequests := 0
+		notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			notFoundRequests++
+			http.NotFound(w, r)
+		}))
+		defer notFound.Close()
+
+		api := &metadatamodel.API{TargetUrl: backend.URL, SpecificationURL: notFound.URL}
+		handler, _ := newHandler(api, true)
+
+		// when
+		first := httptest.NewRecorder()
+		handler.ServeHTTP(first, newRequest("/orders", `{"product":"tea"}`))
+		second := httptest.NewRecorder()
+		handler.ServeHTTP(second, newRequest("/orders", `{"product":"tea"}`))
+
+		// then
+		require.Equal(t, http.StatusBadGateway, first.Code)
+		assert.Contains(t, first.Body.String(), "failed to get API specification")
+		assert.Equal(t, http.StatusBadGateway, second.Code)
+		assert.Equal(t, 1, notFoundRequests)
+	})
+
+	t.Run("should reject request body larger than maximum size", func(t *testing.T) {
+		// given
+		api := &metadatamodel.API{TargetUrl: backend.URL, SpecificationURL: specServer.URL}
+		handler, authStrategyMock := newHandler(api, true)
+		body := `{"product":"` + strings.Repeat("a", 1024) + `"}`
+
+		// when
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest("/orders", body))
+
+		// then
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		authStrategyMock.AssertNotCalled(t, "AddAuthorization", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
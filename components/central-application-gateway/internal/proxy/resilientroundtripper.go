@@ -0,0 +1,132 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metrics"
+	log "github.com/sirupsen/logrus"
+)
+
+// RetryConfig stores configuration of retries of idempotent requests which failed with a transport error or a 5xx response
+type RetryConfig struct {
+	// MaxRetries is the maximum number of times the request is repeated, 0 disables retries
+	MaxRetries int
+	// InitialBackoff is the upper limit of the jittered delay before the first retry, doubled with every next retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the upper limit of the jittered delay
+	MaxBackoff time.Duration
+	// MaxBodySize is the maximum size, in bytes, of the request body kept in memory to repeat the request, 0 means no limit.
+	// Larger and streamed bodies are passed through, and such requests are not retried
+	MaxBodySize int64
+}
+
+// ResilientRoundTripper rejects requests to target APIs with the open circuit breaker and retries idempotent requests which failed
+type ResilientRoundTripper struct {
+	roundTripper   http.RoundTripper
+	circuitBreaker *circuitBreaker
+	retryConfig    RetryConfig
+	apiIdentifier  model.APIIdentifier
+}
+
+func NewResilientRoundTripper(roundTripper http.RoundTripper, circuitBreaker *circuitBreaker, retryConfig RetryConfig, apiIdentifier model.APIIdentifier) *ResilientRoundTripper {
+	return &ResilientRoundTripper{
+		roundTripper:   roundTripper,
+		circuitBreaker: circuitBreaker,
+		retryConfig:    retryConfig,
+		apiIdentifier:  apiIdentifier,
+	}
+}
+
+func (p *ResilientRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxRetries := 0
+	if isIdempotent(req.Method) && canBufferBody(req, p.retryConfig.MaxBodySize) {
+		maxRetries = p.retryConfig.MaxRetries
+	}
+
+	var body []byte
+	if maxRetries > 0 && req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if !p.circuitBreaker.allow() {
+			metrics.CircuitBreakerRejectedRequests.With(apiLabels(p.apiIdentifier)).Inc()
+			return nil, errCircuitOpen
+		}
+
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := p.roundTripper.RoundTrip(req)
+		failed := isFailure(resp, err)
+		if errors.Is(req.Context().Err(), context.Canceled) {
+			// the request was cancelled by the caller, the target API is not to blame
+			p.circuitBreaker.release()
+		} else {
+			p.circuitBreaker.report(!failed)
+		}
+
+		if !failed || attempt >= maxRetries || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		metrics.Retries.With(apiLabels(p.apiIdentifier)).Inc()
+		log.Infof("Retrying request to service '%s' after failed attempt %d", p.apiIdentifier.Service, attempt+1)
+
+		if err := p.wait(req.Context(), attempt); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wait sleeps for a random period up to the exponential backoff of the attempt
+func (p *ResilientRoundTripper) wait(ctx context.Context, attempt int) error {
+	backoff := p.retryConfig.InitialBackoff << attempt
+	if backoff <= 0 || backoff > p.retryConfig.MaxBackoff {
+		backoff = p.retryConfig.MaxBackoff
+	}
+	if backoff <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(backoff))))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
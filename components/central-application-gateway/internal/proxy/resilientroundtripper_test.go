@@ -0,0 +1,113 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResilientRoundTripper(t *testing.T) {
+	apiIdentifier := model.APIIdentifier{Application: "app", Service: "service"}
+	retryConfig := RetryConfig{
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+
+	newTestServer := func(statusCodes ...int) (*httptest.Server, *[]string) {
+		var bodies []string
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+
+			statusCode := http.StatusOK
+			if len(bodies) <= len(statusCodes) {
+				statusCode = statusCodes[len(bodies)-1]
+			}
+			w.WriteHeader(statusCode)
+		})), &bodies
+	}
+
+	t.Run("should retry idempotent request with the same body", func(t *testing.T) {
+		ts, bodies := newTestServer(http.StatusBadGateway, http.StatusServiceUnavailable)
+		defer ts.Close()
+
+		transport := NewResilientRoundTripper(http.DefaultTransport, nil, retryConfig, apiIdentifier)
+		req, err := http.NewRequest(http.MethodPut, ts.URL, strings.NewReader("payload"))
+		require.NoError(t, err)
+
+		res, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, []string{"payload", "payload", "payload"}, *bodies)
+	})
+
+	t.Run("should return last response when retries are exhausted", func(t *testing.T) {
+		ts, bodies := newTestServer(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+		defer ts.Close()
+
+		transport := NewResilientRoundTripper(http.DefaultTransport, nil, retryConfig, apiIdentifier)
+		req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+
+		res, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		assert.Len(t, *bodies, 3)
+	})
+
+	t.Run("should not retry non-idempotent request", func(t *testing.T) {
+		ts, bodies := newTestServer(http.StatusBadGateway)
+		defer ts.Close()
+
+		transport := NewResilientRoundTripper(http.DefaultTransport, nil, retryConfig, apiIdentifier)
+		req, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader("payload"))
+		require.NoError(t, err)
+
+		res, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+
+		assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("should reject requests when circuit breaker is open", func(t *testing.T) {
+		ts, bodies := newTestServer(http.StatusInternalServerError, http.StatusInternalServerError)
+		defer ts.Close()
+
+		cb := newCircuitBreaker(CircuitBreakerConfig{
+			FailureRatio: 1,
+			MinRequests:  2,
+			Window:       time.Minute,
+			OpenDuration: time.Minute,
+		}, apiIdentifier)
+		transport := NewResilientRoundTripper(http.DefaultTransport, cb, RetryConfig{}, apiIdentifier)
+
+		for i := 0; i < 2; i++ {
+			req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+			require.NoError(t, err)
+			res, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			_ = res.Body.Close()
+		}
+
+		req, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		_, err = transport.RoundTrip(req)
+
+		assert.ErrorIs(t, err, errCircuitOpen)
+		assert.Len(t, *bodies, 2)
+	})
+}
@@ -0,0 +1,392 @@
+package proxy
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metrics"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ResponseCacheConfig configures caching of responses to GET and HEAD requests
+type ResponseCacheConfig struct {
+	// MaxSize is the maximum total size, in bytes, of cached response bodies. 0 disables the cache
+	MaxSize int64
+	// MaxEntrySize is the maximum size, in bytes, of a single cached response body
+	MaxEntrySize int64
+	// Headers are the request headers whose values are part of the cache key
+	Headers []string
+}
+
+type cachedResponse struct {
+	key      string
+	status   int
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+	expires  time.Time
+}
+
+func (cr *cachedResponse) hasValidators() bool {
+	return cr.header.Get(httpconsts.HeaderETag) != "" || cr.header.Get(httpconsts.HeaderLastModified) != ""
+}
+
+// responseCache stores responses of the target APIs which the backend allowed to cache.
+// Least recently used responses are evicted when the cache exceeds its size
+type responseCache struct {
+	config  ResponseCacheConfig
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	size    int64
+	now     func() time.Time
+}
+
+func newResponseCache(config ResponseCacheConfig) *responseCache {
+	if config.MaxSize <= 0 {
+		return nil
+	}
+
+	if config.MaxEntrySize <= 0 || config.MaxEntrySize > config.MaxSize {
+		config.MaxEntrySize = config.MaxSize
+	}
+
+	return &responseCache{
+		config:  config,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// serve writes the cached response if it is fresh, otherwise calls next and caches its response
+func (c *responseCache) serve(w http.ResponseWriter, r *http.Request, apiIdentifier model.APIIdentifier, labels prometheus.Labels, next http.HandlerFunc) {
+	if c == nil || (r.Method != http.MethodGet && r.Method != http.MethodHead) || isUpgradeRequest(r) {
+		next(w, r)
+		return
+	}
+
+	requestDirectives := parseCacheControl(r.Header.Get(httpconsts.HeaderCacheControl))
+	if _, noStore := requestDirectives["no-store"]; noStore {
+		next(w, r)
+		return
+	}
+
+	key := c.key(apiIdentifier, r)
+	cached := c.get(key)
+	if _, noCache := requestDirectives["no-cache"]; cached != nil && !noCache && c.now().Before(cached.expires) {
+		metrics.ResponseCacheHits.With(labels).Inc()
+		c.write(w, r, cached)
+		return
+	}
+	metrics.ResponseCacheMisses.With(labels).Inc()
+
+	revalidate := cached != nil && cached.hasValidators() && !isConditional(r)
+	if revalidate {
+		if etag := cached.header.Get(httpconsts.HeaderETag); etag != "" {
+			r.Header.Set(httpconsts.HeaderIfNoneMatch, etag)
+		}
+		if lastModified := cached.header.Get(httpconsts.HeaderLastModified); lastModified != "" {
+			r.Header.Set(httpconsts.HeaderIfModifiedSince, lastModified)
+		}
+	}
+
+	recorder := newCacheRecorder(w, c.config.MaxEntrySize, revalidate)
+	next(recorder, r)
+
+	if recorder.notModified {
+		metrics.ResponseCacheRevalidations.With(labels).Inc()
+		refreshed := c.refresh(cached, recorder.header)
+		c.write(w, r, refreshed)
+		return
+	}
+
+	if response := c.newCachedResponse(key, recorder); response != nil {
+		c.put(response)
+	}
+}
+
+func (c *responseCache) key(apiIdentifier model.APIIdentifier, r *http.Request) string {
+	hash := sha256.New()
+	parts := []string{r.Method, apiIdentifier.Application, apiIdentifier.Service, apiIdentifier.Entry, r.URL.Path, r.URL.RawQuery,
+		// responses for different callers are never shared, because the target API may authorize them differently
+		r.Header.Get(httpconsts.HeaderAuthorization), r.Header.Get(httpconsts.HeaderAccessToken)}
+	for _, header := range c.config.Headers {
+		parts = append(parts, header, strings.Join(r.Header.Values(header), ","))
+	}
+	for _, part := range parts {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *responseCache) get(key string) *cachedResponse {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, found := c.entries[key]
+	if !found {
+		return nil
+	}
+	c.lru.MoveToFront(element)
+	return element.Value.(*cachedResponse)
+}
+
+func (c *responseCache) put(response *cachedResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.entries[response.key]; found {
+		c.remove(element)
+	}
+
+	c.entries[response.key] = c.lru.PushFront(response)
+	c.size += int64(len(response.body))
+
+	for c.size > c.config.MaxSize {
+		c.remove(c.lru.Back())
+	}
+	metrics.ResponseCacheSize.Set(float64(c.size))
+}
+
+func (c *responseCache) remove(element *list.Element) {
+	response := c.lru.Remove(element).(*cachedResponse)
+	delete(c.entries, response.key)
+	c.size -= int64(len(response.body))
+}
+
+// refresh updates the cached response with the headers of the 304 Not Modified response
+func (c *responseCache) refresh(cached *cachedResponse, notModifiedHeader http.Header) *cachedResponse {
+	header := cached.header.Clone()
+	for _, name := range []string{httpconsts.HeaderCacheControl, httpconsts.HeaderExpires, httpconsts.HeaderETag, httpconsts.HeaderLastModified, httpconsts.HeaderDate} {
+		if value := notModifiedHeader.Get(name); value != "" {
+			header.Set(name, value)
+		}
+	}
+
+	refreshed := &cachedResponse{
+		key:      cached.key,
+		status:   cached.status,
+		header:   header,
+		body:     cached.body,
+		storedAt: c.now(),
+	}
+	ttl, cacheable := freshnessLifetime(header, c.now())
+	if !cacheable {
+		c.mutex.Lock()
+		if element, found := c.entries[cached.key]; found {
+			c.remove(element)
+		}
+		c.mutex.Unlock()
+		return refreshed
+	}
+
+	refreshed.expires = refreshed.storedAt.Add(ttl)
+	c.put(refreshed)
+	return refreshed
+}
+
+func (c *responseCache) newCachedResponse(key string, recorder *cacheRecorder) *cachedResponse {
+	if !recorder.wroteHeader || recorder.status != http.StatusOK || recorder.overflow {
+		return nil
+	}
+
+	header := recorder.header.Clone()
+	if !c.keyCoversVary(header) || header.Get(httpconsts.HeaderSetCookie) != "" {
+		return nil
+	}
+
+	ttl, cacheable := freshnessLifetime(header, c.now())
+	if !cacheable {
+		return nil
+	}
+
+	now := c.now()
+	return &cachedResponse{
+		key:      key,
+		status:   recorder.status,
+		header:   header,
+		body:     recorder.body.Bytes(),
+		storedAt: now,
+		expires:  now.Add(ttl),
+	}
+}
+
+// keyCoversVary returns true if all request headers which the response varies on are part of the cache key,
+// otherwise the response, for example compressed for the caller accepting gzip, could be served to callers which sent different values
+func (c *responseCache) keyCoversVary(header http.Header) bool {
+	for _, value := range header.Values(httpconsts.HeaderVary) {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if name == "*" || !c.isKeyHeader(name) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *responseCache) isKeyHeader(name string) bool {
+	if strings.EqualFold(name, httpconsts.HeaderAuthorization) || strings.EqualFold(name, httpconsts.HeaderAccessToken) {
+		return true
+	}
+	for _, header := range c.config.Headers {
+		if strings.EqualFold(name, header) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *responseCache) write(w http.ResponseWriter, r *http.Request, cached *cachedResponse) {
+	header := w.Header()
+	for name := range header {
+		header.Del(name)
+	}
+	for name, values := range cached.header {
+		header[name] = append([]string(nil), values...)
+	}
+	header.Set(httpconsts.HeaderAge, strconv.Itoa(int(c.now().Sub(cached.storedAt).Seconds())))
+
+	w.WriteHeader(cached.status)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(cached.body)
+	}
+}
+
+// freshnessLifetime returns for how long the response may be served from the cache.
+// Responses without freshness information are cached only if they can be revalidated
+func freshnessLifetime(header http.Header, now time.Time) (time.Duration, bool) {
+	directives := parseCacheControl(header.Get(httpconsts.HeaderCacheControl))
+	if _, noStore := directives["no-store"]; noStore {
+		return 0, false
+	}
+	if _, private := directives["private"]; private {
+		return 0, false
+	}
+
+	revalidatable := header.Get(httpconsts.HeaderETag) != "" || header.Get(httpconsts.HeaderLastModified) != ""
+	if _, noCache := directives["no-cache"]; noCache {
+		return 0, revalidatable
+	}
+
+	for _, directive := range []string{"s-maxage", "max-age"} {
+		if value, found := directives[directive]; found {
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				return 0, revalidatable
+			}
+			return time.Duration(seconds) * time.Second, seconds > 0 || revalidatable
+		}
+	}
+
+	if expires := header.Get(httpconsts.HeaderExpires); expires != "" {
+		expiresAt, err := http.ParseTime(expires)
+		if err != nil || !expiresAt.After(now) {
+			return 0, revalidatable
+		}
+		return expiresAt.Sub(now), true
+	}
+
+	return 0, revalidatable
+}
+
+func parseCacheControl(value string) map[string]string {
+	directives := map[string]string{}
+	for _, directive := range strings.Split(value, ",") {
+		directive = strings.TrimSpace(directive)
+		if directive == "" {
+			continue
+		}
+		name, argument, _ := strings.Cut(directive, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(argument), `"`)
+	}
+	return directives
+}
+
+func isConditional(r *http.Request) bool {
+	return r.Header.Get(httpconsts.HeaderIfNoneMatch) != "" || r.Header.Get(httpconsts.HeaderIfModifiedSince) != ""
+}
+
+// cacheRecorder passes the response to the caller and keeps its copy for the cache.
+// When the cached response is revalidated, 304 Not Modified isn't passed, because the caller didn't send the conditional request
+type cacheRecorder struct {
+	http.ResponseWriter
+	header               http.Header
+	status               int
+	body                 bytes.Buffer
+	maxSize              int64
+	overflow             bool
+	wroteHeader          bool
+	interceptNotModified bool
+	notModified          bool
+}
+
+func newCacheRecorder(w http.ResponseWriter, maxSize int64, interceptNotModified bool) *cacheRecorder {
+	return &cacheRecorder{
+		ResponseWriter:       w,
+		status:               http.StatusOK,
+		maxSize:              maxSize,
+		interceptNotModified: interceptNotModified,
+	}
+}
+
+func (r *cacheRecorder) WriteHeader(statusCode int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	r.status = statusCode
+	r.header = r.ResponseWriter.Header().Clone()
+
+	if r.interceptNotModified && statusCode == http.StatusNotModified {
+		r.notModified = true
+		return
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *cacheRecorder) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	if r.notModified {
+		return len(b), nil
+	}
+
+	if !r.overflow {
+		if int64(r.body.Len()+len(b)) > r.maxSize {
+			r.overflow = true
+			r.body = bytes.Buffer{}
+		} else {
+			r.body.Write(b)
+		}
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *cacheRecorder) Flush() {
+	if r.notModified {
+		return
+	}
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *cacheRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
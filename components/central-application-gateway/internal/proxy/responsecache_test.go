@@ -0,0 +1,223 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	apiIdentifier := model.APIIdentifier{Application: "app", Service: "service"}
+	labels := apiLabels(apiIdentifier)
+	config := ResponseCacheConfig{
+		MaxSize:      10,
+		MaxEntrySize: 6,
+		Headers:      []string{httpconsts.HeaderAccept},
+	}
+
+	newTestResponseCache := func() (*responseCache, *time.Time) {
+		now := time.Now()
+		cache := newResponseCache(config)
+		cache.now = func() time.Time { return now }
+		return cache, &now
+	}
+
+	backend := func(calls *[]*http.Request, header http.Header, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			*calls = append(*calls, r.Clone(r.Context()))
+			for name, values := range header {
+				for _, value := range values {
+					w.Header().Add(name, value)
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(body))
+		}
+	}
+
+	serve := func(cache *responseCache, r *http.Request, next http.HandlerFunc) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		cache.serve(rr, r, apiIdentifier, labels, next)
+		return rr
+	}
+
+	t.Run("should serve fresh response from cache", func(t *testing.T) {
+		// given
+		cache, now := newTestResponseCache()
+		var calls []*http.Request
+		next := backend(&calls, http.Header{httpconsts.HeaderCacheControl: {"max-age=60"}}, "body")
+
+		// when
+		first := serve(cache, httptest.NewRequest(http.MethodGet, "/orders?top=1", nil), next)
+		*now = now.Add(10 * time.Second)
+		second := serve(cache, httptest.NewRequest(http.MethodGet, "/orders?top=1", nil), next)
+
+		// then
+		assert.Len(t, calls, 1)
+		assert.Equal(t, "body", first.Body.String())
+		assert.Equal(t, http.StatusOK, second.Code)
+		assert.Equal(t, "body", second.Body.String())
+		assert.Equal(t, "10", second.Header().Get(httpconsts.HeaderAge))
+	})
+
+	t.Run("should not cache response without freshness information and validators", func(t *testing.T) {
+		// given
+		cache, _ := newTestResponseCache()
+		var calls []*http.Request
+		next := backend(&calls, http.Header{}, "body")
+
+		// when
+		serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil), next)
+		serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil), next)
+
+		// then
+		assert.Len(t, calls, 2)
+	})
+
+	t.Run("should not cache private response", func(t *testing.T) {
+		// given
+		cache, _ := newTestResponseCache()
+		var calls []*http.Request
+		next := backend(&calls, http.Header{httpconsts.HeaderCacheControl: {"private, max-age=60"}}, "body")
+
+		// when
+		serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil), next)
+		serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil), next)
+
+		// then
+		assert.Len(t, calls, 2)
+	})
+
+	t.Run("should revalidate stale response with ETag", func(t *testing.T) {
+		// given
+		cache, now := newTestResponseCache()
+		var calls []*http.Request
+		serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil),
+			backend(&calls, http.Header{httpconsts.HeaderCacheControl: {"max-age=1"}, httpconsts.HeaderETag: {`"v1"`}}, "body"))
+		*now = now.Add(2 * time.Second)
+
+		notModified := func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.Clone(r.Context()))
+			w.Header().Set(httpconsts.HeaderCacheControl, "max-age=60")
+			w.WriteHeader(http.StatusNotModified)
+		}
+
+		// when
+		revalidated := serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil), notModified)
+		cached := serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil), notModified)
+
+		// then
+		require.Len(t, calls, 2)
+		assert.Equal(t, `"v1"`, calls[1].Header.Get(httpconsts.HeaderIfNoneMatch))
+		assert.Equal(t, http.StatusOK, revalidated.Code)
+		assert.Equal(t, "body", revalidated.Body.String())
+		assert.Equal(t, http.StatusOK, cached.Code)
+		assert.Equal(t, "body", cached.Body.String())
+	})
+
+	t.Run("should not share responses between callers and varying headers", func(t *testing.T) {
+		// given
+		cache, _ := newTestResponseCache()
+		var calls []*http.Request
+		next := backend(&calls, http.Header{httpconsts.HeaderCacheControl: {"max-age=60"}}, "b")
+
+		newRequest := func(authorization, accept string) *http.Request {
+			r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			r.Header.Set(httpconsts.HeaderAuthorization, authorization)
+			r.Header.Set(httpconsts.HeaderAccept, accept)
+			return r
+		}
+
+		// when
+		serve(cache, newRequest("Bearer first", "application/json"), next)
+		serve(cache, newRequest("Bearer second", "application/json"), next)
+		serve(cache, newRequest("Bearer first", "application/xml"), next)
+		serve(cache, newRequest("Bearer first", "application/json"), next)
+
+		// then
+		assert.Len(t, calls, 3)
+	})
+
+	t.Run("should cache only responses varying on headers of cache key", func(t *testing.T) {
+		// given
+		cache, _ := newTestResponseCache()
+		var calls []*http.Request
+		next := backend(&calls, http.Header{
+			httpconsts.HeaderCacheControl: {"max-age=60"},
+			httpconsts.HeaderVary:         {"accept, Authorization"},
+		}, "b")
+		compressed := backend(&calls, http.Header{
+			httpconsts.HeaderCacheControl: {"max-age=60"},
+			httpconsts.HeaderVary:         {"Accept", "Accept-Encoding"},
+		}, "b")
+
+		// when
+		serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil), next)
+		serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil), next)
+		serve(cache, httptest.NewRequest(http.MethodGet, "/invoices", nil), compressed)
+		serve(cache, httptest.NewRequest(http.MethodGet, "/invoices", nil), compressed)
+
+		// then
+		assert.Len(t, calls, 3)
+	})
+
+	t.Run("should bypass cache when caller requests it", func(t *testing.T) {
+		// given
+		cache, _ := newTestResponseCache()
+		var calls []*http.Request
+		next := backend(&calls, http.Header{httpconsts.HeaderCacheControl: {"max-age=60"}}, "body")
+		serve(cache, httptest.NewRequest(http.MethodGet, "/orders", nil), next)
+
+		request := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		request.Header.Set(httpconsts.HeaderCacheControl, "no-cache")
+
+		// when
+		serve(cache, request, next)
+
+		// then
+		assert.Len(t, calls, 2)
+	})
+
+	t.Run("should not cache responses of other methods", func(t *testing.T) {
+		// given
+		cache, _ := newTestResponseCache()
+		var calls []*http.Request
+		next := backend(&calls, http.Header{httpconsts.HeaderCacheControl: {"max-age=60"}}, "body")
+
+		// when
+		serve(cache, httptest.NewRequest(http.MethodPost, "/orders", nil), next)
+		serve(cache, httptest.NewRequest(http.MethodPost, "/orders", nil), next)
+
+		// then
+		assert.Len(t, calls, 2)
+	})
+
+	t.Run("should respect size limits", func(t *testing.T) {
+		// given
+		cache, _ := newTestResponseCache()
+		var calls []*http.Request
+		cacheable := http.Header{httpconsts.HeaderCacheControl: {"max-age=60"}}
+
+		// when
+		serve(cache, httptest.NewRequest(http.MethodGet, "/large", nil), backend(&calls, cacheable, "1234567"))
+		serve(cache, httptest.NewRequest(http.MethodGet, "/first", nil), backend(&calls, cacheable, "12345"))
+		serve(cache, httptest.NewRequest(http.MethodGet, "/second", nil), backend(&calls, cacheable, "12345"))
+		serve(cache, httptest.NewRequest(http.MethodGet, "/third", nil), backend(&calls, cacheable, "12345"))
+
+		// then
+		assert.Equal(t, int64(10), cache.size)
+		assert.Len(t, cache.entries, 2)
+		assert.Nil(t, cache.get(cache.key(apiIdentifier, httptest.NewRequest(http.MethodGet, "/large", nil))), "response larger than entry limit should not be cached")
+		assert.Nil(t, cache.get(cache.key(apiIdentifier, httptest.NewRequest(http.MethodGet, "/first", nil))), "least recently used response should be evicted")
+	})
+
+	t.Run("should be disabled without size", func(t *testing.T) {
+		assert.Nil(t, newResponseCache(ResponseCacheConfig{}))
+	})
+}
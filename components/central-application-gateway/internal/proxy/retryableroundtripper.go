@@ -9,6 +9,7 @@ import (
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/clientcert"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httptools"
 )
 
 type RetryableRoundTripper struct {
@@ -18,9 +19,10 @@ type RetryableRoundTripper struct {
 	clientCertificate     clientcert.ClientCertificate
 	timeout               int
 	skipTLSVerify         bool
+	maxBodySize           int64
 }
 
-func NewRetryableRoundTripper(roundTripper http.RoundTripper, authorizationStrategy authorization.Strategy, csrfTokenStrategy csrf.TokenStrategy, clientCertificate clientcert.ClientCertificate, timeout int, skipTLSVerify bool) *RetryableRoundTripper {
+func NewRetryableRoundTripper(roundTripper http.RoundTripper, authorizationStrategy authorization.Strategy, csrfTokenStrategy csrf.TokenStrategy, clientCertificate clientcert.ClientCertificate, timeout int, skipTLSVerify bool, maxBodySize int64) *RetryableRoundTripper {
 	return &RetryableRoundTripper{
 		roundTripper:          roundTripper,
 		authorizationStrategy: authorizationStrategy,
@@ -28,10 +30,16 @@ func NewRetryableRoundTripper(roundTripper http.RoundTripper, authorizationStrat
 		clientCertificate:     clientCertificate,
 		timeout:               timeout,
 		skipTLSVerify:         skipTLSVerify,
+		maxBodySize:           maxBodySize,
 	}
 }
 
 func (p *RetryableRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !canBufferBody(req, p.maxBodySize) {
+		// upgraded connections and large or streamed bodies are passed through, so the request can't be repeated
+		return p.roundTripper.RoundTrip(req)
+	}
+
 	// Handle the case when credentials has been changed or OAuth token has expired
 	secondRequestBody, copyErr := copyRequestBody(req)
 	if copyErr != nil {
@@ -67,13 +75,13 @@ func (p *RetryableRoundTripper) retry(req *http.Request, retryBody io.ReadCloser
 
 func (p *RetryableRoundTripper) prepareRequest(req *http.Request) (*http.Request, context.CancelFunc) {
 	req.RequestURI = ""
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(p.timeout)*time.Second)
+	ctx, cancel := context.WithTimeout(httptools.DetachedContext(req.Context()), time.Duration(p.timeout)*time.Second)
 	return req.WithContext(ctx), cancel
 }
 
 func (p *RetryableRoundTripper) addAuthorization(r *http.Request) error {
 	authorizationStrategy := p.authorizationStrategy
-	authorizationStrategy.Invalidate()
+	authorizationStrategy.Invalidate(authorization.UserTokenFromContext(r.Context()))
 	err := authorizationStrategy.AddAuthorization(r, p.clientCertificate.SetCertificate, p.skipTLSVerify)
 	if err != nil {
 		return err
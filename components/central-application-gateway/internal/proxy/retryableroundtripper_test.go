@@ -26,7 +26,7 @@ func TestRetryableRoundTripper(t *testing.T) {
 			On("AddAuthorization", mock.AnythingOfType("*http.Request"), mock.AnythingOfType("SetClientCertificateFunc"), skipTLSVerify).
 			Return(nil).
 			Once()
-		result.On("Invalidate").Return().Once()
+		result.On("Invalidate", "").Return().Once()
 		return result
 	}
 
@@ -167,7 +167,7 @@ func TestRetryableRoundTripper(t *testing.T) {
 			csrfTokenStrategyMock := tc.csrfTokenStrategyFunc(tc.skipTLSVerify)
 			clientCertificate := clientcert.NewClientCertificate(nil)
 
-			transport := NewRetryableRoundTripper(http.DefaultTransport, authStrategyMock, csrfTokenStrategyMock, clientCertificate, 10, tc.skipTLSVerify)
+			transport := NewRetryableRoundTripper(http.DefaultTransport, authStrategyMock, csrfTokenStrategyMock, clientCertificate, 10, tc.skipTLSVerify, 0)
 			httpClient := &http.Client{
 				Transport: transport,
 			}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf"
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/clientcert"
@@ -17,13 +18,21 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func makeProxy(targetURL string, requestParameters *authorization.RequestParameters, serviceName string, skipTLSVerify bool, authorizationStrategy authorization.Strategy, csrfTokenStrategy csrf.TokenStrategy, clientCertificate clientcert.ClientCertificate, timeout int) (*httputil.ReverseProxy, apperrors.AppError) {
+// resilienceConfig stores the circuit breaker and the retry configuration of the target API
+type resilienceConfig struct {
+	apiIdentifier  model.APIIdentifier
+	circuitBreaker *circuitBreaker
+	retry          RetryConfig
+}
+
+func makeProxy(targetURL string, requestParameters *authorization.RequestParameters, rewriteRules *model.RewriteRules, serviceName string, skipTLSVerify bool, authorizationStrategy authorization.Strategy, csrfTokenStrategy csrf.TokenStrategy, clientCertificate clientcert.ClientCertificate, timeout int, resilience resilienceConfig) (*httputil.ReverseProxy, apperrors.AppError) {
 	roundTripper := httptools.NewRoundTripper(httptools.WithTLSSkipVerify(skipTLSVerify), httptools.WithGetClientCertificate(clientCertificate.GetClientCertificate))
-	retryableRoundTripper := NewRetryableRoundTripper(roundTripper, authorizationStrategy, csrfTokenStrategy, clientCertificate, timeout, skipTLSVerify)
-	return newProxy(targetURL, requestParameters, serviceName, retryableRoundTripper)
+	resilientRoundTripper := NewResilientRoundTripper(roundTripper, resilience.circuitBreaker, resilience.retry, resilience.apiIdentifier)
+	retryableRoundTripper := NewRetryableRoundTripper(resilientRoundTripper, authorizationStrategy, csrfTokenStrategy, clientCertificate, timeout, skipTLSVerify, resilience.retry.MaxBodySize)
+	return newProxy(targetURL, requestParameters, rewriteRules, serviceName, retryableRoundTripper)
 }
 
-func newProxy(targetURL string, requestParameters *authorization.RequestParameters, serviceName string, transport http.RoundTripper) (*httputil.ReverseProxy, apperrors.AppError) {
+func newProxy(targetURL string, requestParameters *authorization.RequestParameters, rewriteRules *model.RewriteRules, serviceName string, transport http.RoundTripper) (*httputil.ReverseProxy, apperrors.AppError) {
 	target, err := url.Parse(targetURL)
 	if err != nil {
 		log.Errorf("failed to parse target url '%s': '%s'", targetURL, err.Error())
@@ -38,7 +47,7 @@ func newProxy(targetURL string, requestParameters *authorization.RequestParamete
 		req.URL.Host = target.Host
 		req.Host = target.Host
 
-		combinedPath := joinPaths(target.Path, req.URL.Path)
+		combinedPath := joinPaths(target.Path, rewritePath(rewriteRules, req.URL.Path))
 		req.URL.RawPath = combinedPath
 		req.URL.Path = combinedPath
 
@@ -53,6 +62,8 @@ func newProxy(targetURL string, requestParameters *authorization.RequestParamete
 			setCustomHeaders(req.Header, requestParameters.Headers)
 		}
 
+		rewriteRequestHeaders(rewriteRules, req.Header)
+
 		log.Infof("Modified request url : '%s', schema : '%s', path : '%s'", req.URL.String(), req.URL.Scheme, req.URL.Path)
 	}
 	errorHandler := func(rw http.ResponseWriter, req *http.Request, err error) {
@@ -92,9 +103,12 @@ func setCustomHeaders(reqHeaders http.Header, customHeaders *map[string][]string
 func responseModifier(
 	gatewayURL *url.URL,
 	targetURL string,
+	rewriteRules *model.RewriteRules,
 	urlRewriter func(gatewayURL, target, loc *url.URL) *url.URL,
 ) func(*http.Response) error {
 	return func(resp *http.Response) error {
+		rewriteResponseHeaders(rewriteRules, resp)
+
 		if (resp.StatusCode < 300 || resp.StatusCode >= 400) &&
 			resp.StatusCode != http.StatusCreated {
 			return nil
@@ -149,6 +163,12 @@ func urlRewriter(gatewayURL, target, loc *url.URL) *url.URL {
 
 func codeRewriter(rw http.ResponseWriter, err error) {
 
+	if errors.Is(err, errCircuitOpen) {
+		log.Infof("%s: HTTP status code was rewritten to 503", err)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
 	if errors.Is(err, context.DeadlineExceeded) {
 		log.Infof("%s: HTTP status code was rewritten to 504", err)
 		rw.WriteHeader(http.StatusGatewayTimeout)
@@ -202,7 +202,7 @@ func TestResponseModifier(t *testing.T) {
 			gw, err := url.Parse(gateway) // this could be out of the loop, but here it felt more readable
 			require.Nil(t, err)
 
-			rm := responseModifier(gw, target, rewriter)
+			rm := responseModifier(gw, target, nil, rewriter)
 
 			// when
 			err = rm(res)
@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+)
+
+// rewritePath replaces the prefix of the path with the first matching rule.
+// The prefix matches only whole path segments unless it ends with /
+func rewritePath(rules *model.RewriteRules, path string) string {
+	if rules == nil {
+		return path
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	for _, prefix := range rules.PathPrefixes {
+		if !strings.HasPrefix(path, prefix.From) {
+			continue
+		}
+
+		rest := strings.TrimPrefix(path, prefix.From)
+		if rest != "" && !strings.HasSuffix(prefix.From, "/") && !strings.HasPrefix(rest, "/") {
+			continue
+		}
+
+		return prefix.To + rest
+	}
+
+	return path
+}
+
+// rewriteRequestHeaders removes the request headers which must not be sent to the target API
+func rewriteRequestHeaders(rules *model.RewriteRules, header http.Header) {
+	if rules == nil {
+		return
+	}
+
+	removeHeaders(header, rules.RemoveRequestHeaders)
+
+	if matchesHeader(rules.RemoveRequestHeaders, httpconsts.HeaderXForwardedFor) {
+		// ReverseProxy doesn't add X-Forwarded-For if the header is set to nil
+		header[httpconsts.HeaderXForwardedFor] = nil
+	}
+}
+
+// rewriteResponseHeaders replaces the prefixes of the response header values and removes the response headers
+func rewriteResponseHeaders(rules *model.RewriteRules, resp *http.Response) {
+	if rules == nil {
+		return
+	}
+
+	for _, rule := range rules.ResponseHeaders {
+		values := resp.Header.Values(rule.Name)
+		for i, value := range values {
+			if strings.HasPrefix(value, rule.From) {
+				values[i] = rule.To + strings.TrimPrefix(value, rule.From)
+			}
+		}
+	}
+
+	removeHeaders(resp.Header, rules.RemoveResponseHeaders)
+}
+
+// removeHeaders removes the headers matching the names. The name ending with * matches all headers with the prefix
+func removeHeaders(header http.Header, names []string) {
+	for key := range header {
+		if matchesHeader(names, key) {
+			delete(header, key)
+		}
+	}
+}
+
+func matchesHeader(names []string, header string) bool {
+	for _, name := range names {
+		if strings.HasSuffix(name, "*") {
+			if strings.HasPrefix(strings.ToLower(header), strings.ToLower(strings.TrimSuffix(name, "*"))) {
+				return true
+			}
+		} else if strings.EqualFold(name, header) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+)
+
+func TestRewritePath(t *testing.T) {
+	rules := &model.RewriteRules{
+		PathPrefixes: []model.PrefixRewrite{
+			{From: "/v1/orders", To: "/api/orders"},
+			{From: "/static/", To: "/assets/"},
+			{From: "/v1", To: "/api/v1"},
+		},
+	}
+
+	for _, testCase := range []struct {
+		path     string
+		expected string
+	}{
+		{path: "/v1/orders", expected: "/api/orders"},
+		{path: "/v1/orders/123", expected: "/api/orders/123"},
+		{path: "v1/orders/123", expected: "/api/orders/123"},
+		{path: "/v1/ordersarchive", expected: "/api/v1/ordersarchive"},
+		{path: "/static/logo.png", expected: "/assets/logo.png"},
+		{path: "/v10/orders", expected: "/v10/orders"},
+		{path: "/customers", expected: "/customers"},
+	} {
+		t.Run("should rewrite "+testCase.path, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, rewritePath(rules, testCase.path))
+		})
+	}
+
+	t.Run("should not change path without rules", func(t *testing.T) {
+		assert.Equal(t, "v1/orders", rewritePath(nil, "v1/orders"))
+	})
+}
+
+func TestProxyRewriteRules(t *testing.T) {
+	// given
+	var received *http.Request
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		w.Header().Set("Location", "http://internal.local/api/orders/123")
+		w.Header().Set("X-Backend-Node", "node-1")
+		w.Header().Set("X-Backend-Version", "1.2")
+		w.Header().Set("Server", "backend")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer backend.Close()
+
+	rules := &model.RewriteRules{
+		PathPrefixes:          []model.PrefixRewrite{{From: "/v1", To: "/api"}},
+		RemoveRequestHeaders:  []string{"X-Forwarded-*", "Cookie"},
+		ResponseHeaders:       []model.HeaderRewrite{{Name: "Location", From: "http://internal.local/api", To: "http://gateway.local/app/service/v1"}},
+		RemoveResponseHeaders: []string{"x-backend-*"},
+	}
+
+	proxy, err := newProxy(backend.URL+"/base", nil, rules, "service", http.DefaultTransport)
+	require.Nil(t, err)
+	proxy.ModifyResponse = responseModifier(nil, backend.URL+"/base", rules, urlRewriter)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/orders", nil)
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("X-Forwarded-Host", "gateway.local")
+	req.Header.Set("X-Request-Id", "abc")
+
+	// when
+	rr := httptest.NewRecorder()
+	proxy.ServeHTTP(rr, req)
+
+	// then
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.NotNil(t, received)
+	assert.Equal(t, "/base/api/orders", received.URL.Path)
+	assert.Empty(t, received.Header.Get("Cookie"))
+	assert.Empty(t, received.Header.Get("X-Forwarded-Host"))
+	assert.Empty(t, received.Header.Get("X-Forwarded-For"))
+	assert.Equal(t, "abc", received.Header.Get("X-Request-Id"))
+
+	assert.Equal(t, "http://gateway.local/app/service/v1/orders/123", rr.Header().Get("Location"))
+	assert.Empty(t, rr.Header().Get("X-Backend-Node"))
+	assert.Empty(t, rr.Header().Get("X-Backend-Version"))
+	assert.Equal(t, "backend", rr.Header().Get("Server"))
+}
@@ -0,0 +1,180 @@
+package proxy
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	csrfMock "github.com/kyma-project/kyma/components/central-application-gateway/internal/csrf/mocks"
+	metadatamodel "github.com/kyma-project/kyma/components/central-application-gateway/internal/metadata/model"
+	proxyMocks "github.com/kyma-project/kyma/components/central-application-gateway/internal/proxy/mocks"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/clientcert"
+	authMock "github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/mocks"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+)
+
+func TestProxyUpgrade(t *testing.T) {
+	// given
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isUpgradeRequest(r) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, _ = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		_ = buf.Flush()
+
+		line, err := buf.ReadString('\n')
+		if err != nil {
+			return
+		}
+		_, _ = buf.WriteString("echo: " + line)
+		_ = buf.Flush()
+	}))
+	defer backend.Close()
+
+	apiIdentifier := metadatamodel.APIIdentifier{Application: "app", Service: "service"}
+
+	authStrategyMock := &authMock.Strategy{}
+	authStrategyMock.On("AddAuthorization", mock.AnythingOfType("*http.Request"), mock.AnythingOfType("SetClientCertificateFunc"), false).Return(nil).Once()
+
+	authStrategyFactoryMock := &authMock.StrategyFactory{}
+	authStrategyFactoryMock.On("Create", mock.Anything).Return(authStrategyMock).Once()
+
+	csrfFactoryMock, csrfStrategyMock := mockCSRFStrategy(authStrategyMock, func(mockCall *mock.Call) { mockCall.Once() }, false)
+
+	apiExtractorMock := &proxyMocks.APIExtractor{}
+	apiExtractorMock.On("Get", apiIdentifier).Return(&metadatamodel.API{TargetUrl: backend.URL}, nil).Once()
+
+	// a timeout shorter than the connection lifetime must not close the upgraded connection
+	config := createProxyConfig(1)
+	handler := newProxyForTest(apiExtractorMock, authStrategyFactoryMock, csrfFactoryMock, func(u *url.URL) (metadatamodel.APIIdentifier, string, *url.URL, apperrors.AppError) {
+		return apiIdentifier, u.Path, u, nil
+	}, nil, config)
+
+	gateway := httptest.NewServer(handler)
+	defer gateway.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(gateway.URL, "http://"))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// when
+	_, err = conn.Write([]byte("GET /socket HTTP/1.1\r\nHost: gateway\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n"))
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	res, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+
+	time.Sleep(1500 * time.Millisecond)
+	_, err = conn.Write([]byte("hello\n"))
+	require.NoError(t, err)
+	line, err := reader.ReadString('\n')
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+	assert.Equal(t, "echo: hello\n", line)
+	authStrategyMock.AssertExpectations(t)
+	csrfStrategyMock.AssertExpectations(t)
+}
+
+func TestStreamedRequestBody(t *testing.T) {
+	newTestServer := func(statusCode int) (*httptest.Server, *[]string) {
+		var bodies []string
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			w.WriteHeader(statusCode)
+		})), &bodies
+	}
+
+	newStreamedRequest := func(t *testing.T, method, url string) *http.Request {
+		reader, writer := io.Pipe()
+		go func() {
+			_, _ = writer.Write([]byte("streamed"))
+			_ = writer.Close()
+		}()
+		req, err := http.NewRequest(method, url, reader)
+		require.NoError(t, err)
+		req.ContentLength = -1
+		return req
+	}
+
+	t.Run("should not retry streamed request rejected with 401", func(t *testing.T) {
+		// given
+		ts, bodies := newTestServer(http.StatusUnauthorized)
+		defer ts.Close()
+
+		transport := NewRetryableRoundTripper(http.DefaultTransport, &authMock.Strategy{}, &csrfMock.TokenStrategy{}, clientcert.NewClientCertificate(nil), 10, false, 0)
+
+		// when
+		res, err := transport.RoundTrip(newStreamedRequest(t, http.MethodPost, ts.URL))
+
+		// then
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, []string{"streamed"}, *bodies)
+	})
+
+	t.Run("should not retry idempotent request with body larger than limit", func(t *testing.T) {
+		// given
+		ts, bodies := newTestServer(http.StatusBadGateway)
+		defer ts.Close()
+
+		retryConfig := RetryConfig{MaxRetries: 2, MaxBodySize: 4}
+		transport := NewResilientRoundTripper(http.DefaultTransport, nil, retryConfig, metadatamodel.APIIdentifier{Service: "service"})
+		req, err := http.NewRequest(http.MethodPut, ts.URL, strings.NewReader("payload"))
+		require.NoError(t, err)
+
+		// when
+		res, err := transport.RoundTrip(req)
+
+		// then
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+}
+
+func TestCanBufferBody(t *testing.T) {
+	newRequest := func(body io.Reader, header http.Header) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", body)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		return req
+	}
+
+	streamed := newRequest(strings.NewReader("body"), nil)
+	streamed.ContentLength = -1
+
+	assert.True(t, canBufferBody(newRequest(nil, nil), 1), "request without body")
+	assert.True(t, canBufferBody(newRequest(strings.NewReader("body"), nil), 4), "body within limit")
+	assert.True(t, canBufferBody(newRequest(strings.NewReader("body"), nil), 0), "no limit")
+	assert.False(t, canBufferBody(newRequest(strings.NewReader("body"), nil), 3), "body over limit")
+	assert.False(t, canBufferBody(streamed, 0), "streamed body")
+	assert.False(t, canBufferBody(newRequest(nil, http.Header{
+		httpconsts.HeaderConnection: {"keep-alive, Upgrade"},
+		httpconsts.HeaderUpgrade:    {"websocket"},
+	}), 0), "upgrade request")
+}
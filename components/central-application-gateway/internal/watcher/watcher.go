@@ -0,0 +1,111 @@
+// Package watcher contains the watcher of the changes of the Applications and the Secrets they reference
+package watcher
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
+	"github.com/kyma-project/kyma/components/application-operator/pkg/client/clientset/versioned"
+	applicationinformers "github.com/kyma-project/kyma/components/application-operator/pkg/client/informers/externalversions/applicationconnector/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Invalidator removes the data cached for the Application
+type Invalidator interface {
+	InvalidateApplication(appName string)
+}
+
+// Watcher notifies the invalidator when the Application or the Secret with its credentials or request parameters changes
+type Watcher struct {
+	applicationInformer cache.SharedIndexInformer
+	secretInformer      cache.SharedIndexInformer
+	invalidator         Invalidator
+}
+
+// New creates the watcher of the Applications and the Secrets in the namespace
+func New(applicationClientset versioned.Interface, coreClientset kubernetes.Interface, namespace string, resyncPeriod time.Duration, invalidator Invalidator) *Watcher {
+	w := &Watcher{
+		applicationInformer: applicationinformers.NewApplicationInformer(applicationClientset, resyncPeriod, cache.Indexers{}),
+		secretInformer:      coreinformers.NewSecretInformer(coreClientset, namespace, resyncPeriod, cache.Indexers{}),
+		invalidator:         invalidator,
+	}
+
+	w.applicationInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldApp, oldOk := oldObj.(*v1alpha1.Application)
+			newApp, newOk := newObj.(*v1alpha1.Application)
+			if oldOk && newOk && !reflect.DeepEqual(oldApp.Spec, newApp.Spec) {
+				w.invalidator.InvalidateApplication(newApp.Name)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if app, ok := unwrap(obj).(*v1alpha1.Application); ok {
+				w.invalidator.InvalidateApplication(app.Name)
+			}
+		},
+	})
+
+	w.secretInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSecret, oldOk := oldObj.(*v1.Secret)
+			newSecret, newOk := newObj.(*v1.Secret)
+			if oldOk && newOk && !reflect.DeepEqual(oldSecret.Data, newSecret.Data) {
+				w.secretChanged(newSecret.Name)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if secret, ok := unwrap(obj).(*v1.Secret); ok {
+				w.secretChanged(secret.Name)
+			}
+		},
+	})
+
+	return w
+}
+
+// Run watches the changes until the stop channel is closed
+func (w *Watcher) Run(stopCh <-chan struct{}) {
+	go w.applicationInformer.Run(stopCh)
+	go w.secretInformer.Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, w.applicationInformer.HasSynced, w.secretInformer.HasSynced) {
+		log.Warn("Watcher stopped before the Applications and Secrets were synchronized")
+		return
+	}
+	log.Info("Watching changes of Applications and Secrets")
+
+	<-stopCh
+}
+
+func (w *Watcher) secretChanged(secretName string) {
+	for _, obj := range w.applicationInformer.GetStore().List() {
+		app, ok := obj.(*v1alpha1.Application)
+		if ok && referencesSecret(app, secretName) {
+			w.invalidator.InvalidateApplication(app.Name)
+		}
+	}
+}
+
+func referencesSecret(app *v1alpha1.Application, secretName string) bool {
+	for _, service := range app.Spec.Services {
+		for _, entry := range service.Entries {
+			if entry.Credentials.SecretName == secretName || entry.RequestParametersSecretName == secretName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unwrap returns the deleted object whose final state is unknown because the watch missed the delete event
+func unwrap(obj interface{}) interface{} {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return tombstone.Obj
+	}
+	return obj
+}
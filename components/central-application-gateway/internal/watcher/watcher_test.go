@@ -0,0 +1,130 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
+	"github.com/kyma-project/kyma/components/application-operator/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corefake "k8s.io/client-go/kubernetes/fake"
+)
+
+const namespace = "kyma-integration"
+
+type invalidatorStub chan string
+
+func (i invalidatorStub) InvalidateApplication(appName string) {
+	i <- appName
+}
+
+func TestWatcher(t *testing.T) {
+	// given
+	app := &v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "commerce"},
+		Spec: v1alpha1.ApplicationSpec{
+			Services: []v1alpha1.Service{{
+				Name: "orders",
+				Entries: []v1alpha1.Entry{{
+					TargetUrl:                   "http://orders.local",
+					Credentials:                 v1alpha1.Credentials{Type: "OAuth", SecretName: "orders-credentials"},
+					RequestParametersSecretName: "orders-parameters",
+				}},
+			}},
+		},
+	}
+	otherApp := &v1alpha1.Application{ObjectMeta: metav1.ObjectMeta{Name: "marketing"}}
+	credentials := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "orders-credentials", Namespace: namespace}, Data: map[string][]byte{"clientId": []byte("old")}}
+	unrelated := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: namespace}, Data: map[string][]byte{"key": []byte("old")}}
+
+	applicationClientset := fake.NewSimpleClientset(app, otherApp)
+	coreClientset := corefake.NewSimpleClientset(credentials, unrelated)
+	invalidator := make(invalidatorStub, 10)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	watcher := New(applicationClientset, coreClientset, namespace, 0, invalidator)
+	go watcher.Run(stopCh)
+	require.Eventually(t, func() bool {
+		return watcher.applicationInformer.HasSynced() && watcher.secretInformer.HasSynced()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	applications := applicationClientset.ApplicationconnectorV1alpha1().Applications()
+	secrets := coreClientset.CoreV1().Secrets(namespace)
+	ctx := context.Background()
+
+	t.Run("should invalidate application when credentials secret changes", func(t *testing.T) {
+		// when
+		updated := credentials.DeepCopy()
+		updated.Data["clientId"] = []byte("new")
+		_, err := secrets.Update(ctx, updated, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "commerce", receive(t, invalidator))
+	})
+
+	t.Run("should not invalidate applications when unrelated secret changes", func(t *testing.T) {
+		// when
+		updated := unrelated.DeepCopy()
+		updated.Data["key"] = []byte("new")
+		_, err := secrets.Update(ctx, updated, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		// then
+		assertNothingReceived(t, invalidator)
+	})
+
+	t.Run("should not invalidate application when only status changes", func(t *testing.T) {
+		// when
+		updated := otherApp.DeepCopy()
+		updated.Status.InstallationStatus.Status = "deployed"
+		_, err := applications.Update(ctx, updated, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		// then
+		assertNothingReceived(t, invalidator)
+	})
+
+	t.Run("should invalidate application when its spec changes", func(t *testing.T) {
+		// when
+		updated := app.DeepCopy()
+		updated.Spec.Services[0].Entries[0].TargetUrl = "http://orders-v2.local"
+		_, err := applications.Update(ctx, updated, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "commerce", receive(t, invalidator))
+	})
+
+	t.Run("should invalidate deleted application", func(t *testing.T) {
+		// when
+		err := applications.Delete(ctx, otherApp.Name, metav1.DeleteOptions{})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "marketing", receive(t, invalidator))
+	})
+}
+
+func receive(t *testing.T, invalidator invalidatorStub) string {
+	select {
+	case appName := <-invalidator:
+		return appName
+	case <-time.After(5 * time.Second):
+		t.Fatal("application wasn't invalidated")
+		return ""
+	}
+}
+
+func assertNothingReceived(t *testing.T, invalidator invalidatorStub) {
+	select {
+	case appName := <-invalidator:
+		t.Errorf("application %s shouldn't be invalidated", appName)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -8,6 +8,9 @@ const (
 	CodeAlreadyExists            = 3
 	CodeWrongInput               = 4
 	CodeUpstreamServerCallFailed = 5
+	CodeUnauthorized             = 6
+	CodeForbidden                = 7
+	CodeRequestTooLarge          = 8
 )
 
 type AppError interface {
@@ -44,6 +47,18 @@ func UpstreamServerCallFailed(format string, a ...interface{}) AppError {
 	return errorf(CodeUpstreamServerCallFailed, format, a...)
 }
 
+func Unauthorized(format string, a ...interface{}) AppError {
+	return errorf(CodeUnauthorized, format, a...)
+}
+
+func Forbidden(format string, a ...interface{}) AppError {
+	return errorf(CodeForbidden, format, a...)
+}
+
+func RequestTooLarge(format string, a ...interface{}) AppError {
+	return errorf(CodeRequestTooLarge, format, a...)
+}
+
 func (ae appError) Code() int {
 	return ae.code
 }
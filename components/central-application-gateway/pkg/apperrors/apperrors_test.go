@@ -14,6 +14,9 @@ func TestAppError(t *testing.T) {
 		assert.Equal(t, CodeAlreadyExists, AlreadyExists("error").Code())
 		assert.Equal(t, CodeWrongInput, WrongInput("error").Code())
 		assert.Equal(t, CodeUpstreamServerCallFailed, UpstreamServerCallFailed("error").Code())
+		assert.Equal(t, CodeUnauthorized, Unauthorized("error").Code())
+		assert.Equal(t, CodeForbidden, Forbidden("error").Code())
+		assert.Equal(t, CodeRequestTooLarge, RequestTooLarge("error").Code())
 	})
 
 	t.Run("should create error with simple message", func(t *testing.T) {
@@ -22,6 +25,9 @@ func TestAppError(t *testing.T) {
 		assert.Equal(t, "error", AlreadyExists("error").Error())
 		assert.Equal(t, "error", WrongInput("error").Error())
 		assert.Equal(t, "error", UpstreamServerCallFailed("error").Error())
+		assert.Equal(t, "error", Unauthorized("error").Error())
+		assert.Equal(t, "error", Forbidden("error").Error())
+		assert.Equal(t, "error", RequestTooLarge("error").Error())
 	})
 
 	t.Run("should create error with formatted message", func(t *testing.T) {
@@ -30,5 +36,8 @@ func TestAppError(t *testing.T) {
 		assert.Equal(t, "code: 1, error: bug", AlreadyExists("code: %d, error: %s", 1, "bug").Error())
 		assert.Equal(t, "code: 1, error: bug", WrongInput("code: %d, error: %s", 1, "bug").Error())
 		assert.Equal(t, "code: 1, error: bug", UpstreamServerCallFailed("code: %d, error: %s", 1, "bug").Error())
+		assert.Equal(t, "code: 1, error: bug", Unauthorized("code: %d, error: %s", 1, "bug").Error())
+		assert.Equal(t, "code: 1, error: bug", Forbidden("code: %d, error: %s", 1, "bug").Error())
+		assert.Equal(t, "code: 1, error: bug", RequestTooLarge("code: %d, error: %s", 1, "bug").Error())
 	})
 }
@@ -25,5 +25,5 @@ func (b basicAuthStrategy) AddAuthorization(r *http.Request, _ clientcert.SetCli
 	return nil
 }
 
-func (b basicAuthStrategy) Invalidate() {
+func (b basicAuthStrategy) Invalidate(_ string) {
 }
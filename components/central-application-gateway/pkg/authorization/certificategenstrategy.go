@@ -29,7 +29,7 @@ func (b certificateGenStrategy) AddAuthorization(r *http.Request, setter clientc
 	return nil
 }
 
-func (b certificateGenStrategy) Invalidate() {
+func (b certificateGenStrategy) Invalidate(_ string) {
 }
 
 func (b certificateGenStrategy) prepareCertificate() (tls.Certificate, error) {
@@ -28,6 +28,6 @@ func (e externalTokenStrategy) AddAuthorization(r *http.Request, setter clientce
 	return e.strategy.AddAuthorization(r, setter, skipTLSVerification)
 }
 
-func (o externalTokenStrategy) Invalidate() {
-	o.strategy.Invalidate()
+func (o externalTokenStrategy) Invalidate(subjectToken string) {
+	o.strategy.Invalidate(subjectToken)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth/mocks"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -41,7 +42,7 @@ func TestExternalAuthStrategy(t *testing.T) {
 	t.Run("should use provided strategy when external token header is missing", func(t *testing.T) {
 		// given
 		oauthClientMock := &mocks.Client{}
-		oauthClientMock.On("GetToken", "clientId", "clientSecret", "www.example.com/token", (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("token", nil).Once()
+		oauthClientMock.On("GetToken", mock.Anything, "clientId", "clientSecret", "www.example.com/token", (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("token", nil).Once()
 
 		oauthStrategy := newOAuthStrategy(oauthClientMock, "clientId", "clientSecret", "www.example.com/token", nil)
 
@@ -71,7 +72,7 @@ func TestExternalAuthStrategy(t *testing.T) {
 		externalTokenStrategy := newExternalTokenStrategy(&oauthStrategy)
 
 		// when
-		externalTokenStrategy.Invalidate()
+		externalTokenStrategy.Invalidate("")
 
 		// then
 		oauthClientMock.AssertExpectations(t)
@@ -1,6 +1,7 @@
 package authorization
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
@@ -14,8 +15,8 @@ import (
 type Strategy interface {
 	// Adds Authorization header to the request
 	AddAuthorization(r *http.Request, setter clientcert.SetClientCertificateFunc, skipTLSVerification bool) apperrors.AppError
-	// Invalidates internal state
-	Invalidate()
+	// Invalidates internal state, subjectToken is the bearer token of the caller of the rejected request if any
+	Invalidate(subjectToken string)
 }
 
 //go:generate mockery --name=StrategyFactory
@@ -27,11 +28,14 @@ type StrategyFactory interface {
 //go:generate mockery --name=OAuthClient
 type OAuthClient interface {
 	// GetToken obtains OAuth token
-	GetToken(clientID string, clientSecret string, authURL string, headers, queryParameters *map[string][]string, skipTLSVerification bool) (string, apperrors.AppError)
-	GetTokenMTLS(clientID, authURL string, certificate, privateKey []byte, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError)
+	GetToken(ctx context.Context, clientID string, clientSecret string, authURL string, headers, queryParameters *map[string][]string, skipTLSVerification bool) (string, apperrors.AppError)
+	GetTokenMTLS(ctx context.Context, clientID, authURL string, certificate, privateKey []byte, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError)
 	// InvalidateTokenCache resets internal token cache
 	InvalidateTokenCache(clientID string, clientSecret string, authURL string)
 	InvalidateTokenCacheMTLS(clientID, authURL string, certificate, privateKey []byte)
+	// GetTokenWithGrant obtains OAuth token using grant other than client credentials
+	GetTokenWithGrant(ctx context.Context, clientID, clientSecret, authURL string, grant oauth.Grant, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError)
+	InvalidateTokenCacheWithGrant(clientID, clientSecret, authURL string, grant oauth.Grant)
 }
 
 type authorizationStrategyFactory struct {
@@ -44,11 +48,15 @@ func (asf authorizationStrategyFactory) Create(c *Credentials) Strategy {
 }
 
 func (asf authorizationStrategyFactory) create(c *Credentials) Strategy {
-	if c != nil && c.OAuth != nil {
+	if c != nil && c.OAuth != nil && c.OAuth.Grant != nil && !c.OAuth.Grant.Type.IsClientCredentials() {
+		return newOAuthGrantStrategy(asf.oauthClient, c.OAuth.ClientID, c.OAuth.ClientSecret, c.OAuth.URL, *c.OAuth.Grant, c.OAuth.RequestParameters)
+	} else if c != nil && c.OAuth != nil {
 		return newOAuthStrategy(asf.oauthClient, c.OAuth.ClientID, c.OAuth.ClientSecret, c.OAuth.URL, c.OAuth.RequestParameters)
 	} else if c != nil && c.OAuthWithCert != nil {
 		oAuthStrategy := newOAuthWithCertStrategy(asf.oauthClient, c.OAuthWithCert.ClientID, c.OAuthWithCert.ClientSecret, c.OAuthWithCert.Certificate, c.OAuthWithCert.PrivateKey, c.OAuthWithCert.URL, c.OAuthWithCert.RequestParameters)
 		return &oAuthStrategy
+	} else if c != nil && c.TokenExchange != nil {
+		return newTokenExchangeStrategy(asf.oauthClient, c.TokenExchange.ClientID, c.TokenExchange.ClientSecret, c.TokenExchange.URL, c.TokenExchange.GrantType, c.TokenExchange.Audience, c.TokenExchange.RequestParameters)
 	} else if c != nil && c.BasicAuth != nil {
 		return newBasicAuthStrategy(c.BasicAuth.Username, c.BasicAuth.Password)
 	} else if c != nil && c.CertificateGen != nil {
@@ -61,12 +69,14 @@ func (asf authorizationStrategyFactory) create(c *Credentials) Strategy {
 // FactoryConfiguration holds factory configuration options
 type FactoryConfiguration struct {
 	OAuthClientTimeout int
+	// ObserveTokenFetch is called with the duration of every request to the OAuth token endpoint
+	ObserveTokenFetch oauth.FetchObserver
 }
 
 // NewStrategyFactory creates factory for instantiating Strategy implementations
 func NewStrategyFactory(config FactoryConfiguration) StrategyFactory {
 	cache := tokencache.NewTokenCache()
-	oauthClient := oauth.NewOauthClient(config.OAuthClientTimeout, cache)
+	oauthClient := oauth.NewOauthClient(config.OAuthClientTimeout, cache, config.ObserveTokenFetch)
 
 	return authorizationStrategyFactory{oauthClient: oauthClient}
 }
@@ -8,9 +8,11 @@ import (
 
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/clientcert"
 
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
 	oauthMocks "github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth/mocks"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,10 +101,46 @@ func TestStrategyFactory(t *testing.T) {
 		assert.Equal(t, "Bearer external", authHeader)
 	})
 
+	t.Run("should create oauth grant strategy", func(t *testing.T) {
+		// given
+		grant := oauth.Grant{Type: oauth.GrantTypePassword, Username: "username", Password: "password"}
+		oauthClientMock := &oauthMocks.Client{}
+		oauthClientMock.On("GetTokenWithGrant", mock.Anything, "clientId", "clientSecret", "www.example.com/token", grant, (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("token", nil).Once()
+
+		factory := authorizationStrategyFactory{oauthClient: oauthClientMock}
+		credentials := &Credentials{
+			OAuth: &OAuth{
+				ClientID:     "clientId",
+				ClientSecret: "clientSecret",
+				URL:          "www.example.com/token",
+				Grant:        &grant,
+			},
+		}
+
+		// when
+		strategy := factory.Create(credentials)
+
+		// then
+		require.NotNil(t, strategy)
+
+		// given
+		request, err := http.NewRequest("GET", "www.example.com", nil)
+		require.NoError(t, err)
+
+		// when
+		err = strategy.AddAuthorization(request, nil, false)
+
+		// then
+		authHeader := request.Header.Get(httpconsts.HeaderAuthorization)
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer token", authHeader)
+		oauthClientMock.AssertExpectations(t)
+	})
+
 	t.Run("should create oauth strategy", func(t *testing.T) {
 		// given
 		oauthClientMock := &oauthMocks.Client{}
-		oauthClientMock.On("GetToken", "clientId", "clientSecret", "www.example.com/token", (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("token", nil)
+		oauthClientMock.On("GetToken", mock.Anything, "clientId", "clientSecret", "www.example.com/token", (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("token", nil)
 
 		factory := authorizationStrategyFactory{oauthClient: oauthClientMock}
 		credentials := &Credentials{
@@ -149,7 +187,7 @@ func TestStrategyFactory(t *testing.T) {
 	t.Run("should create oauth with cert strategy", func(t *testing.T) {
 		// given
 		oauthClientMock := &oauthMocks.Client{}
-		oauthClientMock.On("GetTokenMTLS", "clientId", "www.example.com/token", []byte(testconsts.Certificate), []byte(testconsts.PrivateKey), (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("token", nil)
+		oauthClientMock.On("GetTokenMTLS", mock.Anything, "clientId", "www.example.com/token", []byte(testconsts.Certificate), []byte(testconsts.PrivateKey), (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("token", nil)
 
 		factory := authorizationStrategyFactory{oauthClient: oauthClientMock}
 		credentials := &Credentials{
@@ -3,9 +3,13 @@
 package mocks
 
 import (
+	context "context"
+
 	apperrors "github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
 
 	mock "github.com/stretchr/testify/mock"
+
+	oauth "github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
 )
 
 // OAuthClient is an autogenerated mock type for the OAuthClient type
@@ -13,20 +17,43 @@ type OAuthClient struct {
 	mock.Mock
 }
 
-// GetToken provides a mock function with given fields: clientID, clientSecret, authURL, headers, queryParameters, skipTLSVerification
-func (_m *OAuthClient) GetToken(clientID string, clientSecret string, authURL string, headers *map[string][]string, queryParameters *map[string][]string, skipTLSVerification bool) (string, apperrors.AppError) {
-	ret := _m.Called(clientID, clientSecret, authURL, headers, queryParameters, skipTLSVerification)
+// GetToken provides a mock function with given fields: ctx, clientID, clientSecret, authURL, headers, queryParameters, skipTLSVerification
+func (_m *OAuthClient) GetToken(ctx context.Context, clientID string, clientSecret string, authURL string, headers *map[string][]string, queryParameters *map[string][]string, skipTLSVerification bool) (string, apperrors.AppError) {
+	ret := _m.Called(ctx, clientID, clientSecret, authURL, headers, queryParameters, skipTLSVerification)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *map[string][]string, *map[string][]string, bool) string); ok {
+		r0 = rf(ctx, clientID, clientSecret, authURL, headers, queryParameters, skipTLSVerification)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
+		r1 = rf(ctx, clientID, clientSecret, authURL, headers, queryParameters, skipTLSVerification)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetTokenMTLS provides a mock function with given fields: ctx, clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify
+func (_m *OAuthClient) GetTokenMTLS(ctx context.Context, clientID string, authURL string, certificate []byte, privateKey []byte, headers *map[string][]string, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
+	ret := _m.Called(ctx, clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string, string, *map[string][]string, *map[string][]string, bool) string); ok {
-		r0 = rf(clientID, clientSecret, authURL, headers, queryParameters, skipTLSVerification)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte, []byte, *map[string][]string, *map[string][]string, bool) string); ok {
+		r0 = rf(ctx, clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, string, string, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
-		r1 = rf(clientID, clientSecret, authURL, headers, queryParameters, skipTLSVerification)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte, []byte, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
+		r1 = rf(ctx, clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -36,20 +63,20 @@ func (_m *OAuthClient) GetToken(clientID string, clientSecret string, authURL st
 	return r0, r1
 }
 
-// GetTokenMTLS provides a mock function with given fields: clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify
-func (_m *OAuthClient) GetTokenMTLS(clientID string, authURL string, certificate []byte, privateKey []byte, headers *map[string][]string, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
-	ret := _m.Called(clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
+// GetTokenWithGrant provides a mock function with given fields: ctx, clientID, clientSecret, authURL, grant, headers, queryParameters, skipVerify
+func (_m *OAuthClient) GetTokenWithGrant(ctx context.Context, clientID string, clientSecret string, authURL string, grant oauth.Grant, headers *map[string][]string, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
+	ret := _m.Called(ctx, clientID, clientSecret, authURL, grant, headers, queryParameters, skipVerify)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string, []byte, []byte, *map[string][]string, *map[string][]string, bool) string); ok {
-		r0 = rf(clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, oauth.Grant, *map[string][]string, *map[string][]string, bool) string); ok {
+		r0 = rf(ctx, clientID, clientSecret, authURL, grant, headers, queryParameters, skipVerify)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, string, []byte, []byte, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
-		r1 = rf(clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, oauth.Grant, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
+		r1 = rf(ctx, clientID, clientSecret, authURL, grant, headers, queryParameters, skipVerify)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -69,6 +96,11 @@ func (_m *OAuthClient) InvalidateTokenCacheMTLS(clientID string, authURL string,
 	_m.Called(clientID, authURL, certificate, privateKey)
 }
 
+// InvalidateTokenCacheWithGrant provides a mock function with given fields: clientID, clientSecret, authURL, grant
+func (_m *OAuthClient) InvalidateTokenCacheWithGrant(clientID string, clientSecret string, authURL string, grant oauth.Grant) {
+	_m.Called(clientID, clientSecret, authURL, grant)
+}
+
 type mockConstructorTestingTNewOAuthClient interface {
 	mock.TestingT
 	Cleanup(func())
@@ -33,9 +33,9 @@ func (_m *Strategy) AddAuthorization(r *http.Request, setter clientcert.SetClien
 	return r0
 }
 
-// Invalidate provides a mock function with given fields:
-func (_m *Strategy) Invalidate() {
-	_m.Called()
+// Invalidate provides a mock function with given fields: subjectToken
+func (_m *Strategy) Invalidate(subjectToken string) {
+	_m.Called(subjectToken)
 }
 
 type mockConstructorTestingTNewStrategy interface {
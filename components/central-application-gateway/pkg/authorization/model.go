@@ -1,5 +1,7 @@
 package authorization
 
+import "github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
+
 // Credentials contains OAuth or BasicAuth configuration.
 type Credentials struct {
 	// OAuth is OAuth configuration.
@@ -10,6 +12,8 @@ type Credentials struct {
 	BasicAuth *BasicAuth
 	// CertificateGen is CertificateGen configuration.
 	CertificateGen *CertificateGen
+	// TokenExchange is TokenExchange configuration.
+	TokenExchange *TokenExchange
 	// CSRFTokenEndpointURL (optional) to fetch CSRF token
 	// Deprecated: This field is only used for old implementation of fetching credentials from Application and Secrets. It is not used by authorization package.
 	// It should be removed when it is no longer supported
@@ -34,6 +38,8 @@ type OAuth struct {
 	ClientSecret string
 	// RequestParameters will be used with request send by the Application Gateway.
 	RequestParameters *RequestParameters
+	// Grant (optional) to use instead of client credentials grant.
+	Grant *oauth.Grant
 }
 
 // CertificateGen details of CertificateGen configuration
@@ -53,6 +59,23 @@ type OAuthWithCert struct {
 	RequestParameters *RequestParameters
 }
 
+// TokenExchange contains details of exchanging the token of the caller for the token of the target API user
+type TokenExchange struct {
+	// URL to OAuth token provider.
+	URL string
+	// ClientID to use for authorization.
+	ClientID string
+	// ClientSecret to use for authorization.
+	ClientSecret string
+	// GrantType used to exchange the token, either token exchange (RFC 8693) or JWT bearer (user token flow).
+	// Token exchange is used if empty.
+	GrantType oauth.GrantType
+	// Audience (optional) of the exchanged token.
+	Audience string
+	// RequestParameters will be used with request send by the Application Gateway.
+	RequestParameters *RequestParameters
+}
+
 // RequestParameters contains Headers and QueryParameters
 type RequestParameters struct {
 	Headers         *map[string][]string `json:"headers,omitempty"`
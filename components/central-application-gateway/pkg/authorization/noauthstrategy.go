@@ -19,6 +19,6 @@ func (ns noAuthStrategy) AddAuthorization(_ *http.Request, _ clientcert.SetClien
 	return nil
 }
 
-func (ns noAuthStrategy) Invalidate() {
+func (ns noAuthStrategy) Invalidate(_ string) {
 
 }
@@ -0,0 +1,171 @@
+package oauth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+)
+
+// GrantType is the OAuth flow used to obtain the token
+type GrantType string
+
+const (
+	GrantTypeClientCredentials GrantType = "client_credentials"
+	GrantTypePassword          GrantType = "password"
+	GrantTypeJWTBearer         GrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	GrantTypeSAMLBearer        GrantType = "urn:ietf:params:oauth:grant-type:saml2-bearer"
+	GrantTypeTokenExchange     GrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	tokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+
+	jwtAssertionLifetime = 5 * time.Minute
+)
+
+// IsSupported returns true if the client implements the grant type
+func (gt GrantType) IsSupported() bool {
+	switch gt {
+	case "", GrantTypeClientCredentials, GrantTypePassword, GrantTypeJWTBearer, GrantTypeSAMLBearer, GrantTypeTokenExchange:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsClientCredentials returns true for the default client credentials grant
+func (gt GrantType) IsClientCredentials() bool {
+	return gt == "" || gt == GrantTypeClientCredentials
+}
+
+// Grant contains the parameters of the token request specific to the grant type
+type Grant struct {
+	// Type of the grant
+	Type GrantType
+	// Username of the resource owner for the password grant
+	Username string
+	// Password of the resource owner for the password grant
+	Password string
+	// Assertion is the JWT (RFC 7523) or the base64url encoded SAML 2.0 (RFC 7522) bearer assertion,
+	// or the subject token of the token exchange (RFC 8693)
+	Assertion string
+	// AssertionKey is the PEM encoded RSA private key which signs the JWT bearer assertion if Assertion is empty
+	AssertionKey []byte
+	// AssertionSubject is the subject of the signed JWT bearer assertion, client ID is used if empty
+	AssertionSubject string
+	// Audience (optional) of the token requested with the token exchange
+	Audience string
+	// UserBound is set for grants which obtain the token on behalf of the caller of the proxied request,
+	// for example with the caller's token as the assertion. Such tokens are not refreshed in the background
+	UserBound bool
+}
+
+func (g Grant) form(clientID, authURL string) (url.Values, apperrors.AppError) {
+	form := url.Values{}
+	form.Add("client_id", clientID)
+	form.Add("grant_type", string(g.Type))
+
+	switch g.Type {
+	case GrantTypePassword:
+		form.Add("username", g.Username)
+		form.Add("password", g.Password)
+	case GrantTypeJWTBearer:
+		assertion := g.Assertion
+		if assertion == "" {
+			var err apperrors.AppError
+			assertion, err = signJWTAssertion(g.AssertionKey, clientID, g.AssertionSubject, authURL, time.Now())
+			if err != nil {
+				return nil, err
+			}
+		}
+		form.Add("assertion", assertion)
+	case GrantTypeSAMLBearer:
+		form.Add("assertion", g.Assertion)
+	case GrantTypeTokenExchange:
+		form.Add("subject_token", g.Assertion)
+		form.Add("subject_token_type", tokenTypeAccessToken)
+		if g.Audience != "" {
+			form.Add("audience", g.Audience)
+		}
+	default:
+		return nil, apperrors.WrongInput("unsupported OAuth grant type '%s'", g.Type)
+	}
+
+	return form, nil
+}
+
+// cacheKey identifies the token obtained with the grant without storing credentials in plain text
+func (g Grant) cacheKey(clientID, clientSecret, authURL string) string {
+	hash := sha256.New()
+	for _, part := range []string{clientID, clientSecret, authURL, string(g.Type), g.Username, g.Password, g.Assertion, string(g.AssertionKey), g.AssertionSubject, g.Audience} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return "grant-" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// signJWTAssertion creates the RS256 signed JWT bearer assertion described in RFC 7523
+func signJWTAssertion(privateKey []byte, issuer, subject, audience string, now time.Time) (string, apperrors.AppError) {
+	key, err := parseRSAPrivateKey(privateKey)
+	if err != nil {
+		return "", apperrors.Internal("failed to parse JWT assertion key: %s", err.Error())
+	}
+
+	if subject == "" {
+		subject = issuer
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", apperrors.Internal("failed to generate JWT assertion ID: %s", err.Error())
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iss": issuer,
+		"sub": subject,
+		"aud": audience,
+		"iat": now.Unix(),
+		"exp": now.Add(jwtAssertionLifetime).Unix(),
+		"jti": hex.EncodeToString(jti),
+	})
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", apperrors.Internal("failed to sign JWT assertion: %s", err.Error())
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, x509.ErrUnsupportedAlgorithm
+	}
+	return key, nil
+}
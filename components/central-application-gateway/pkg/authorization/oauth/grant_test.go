@@ -0,0 +1,179 @@
+package oauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth/tokencache/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOauthClient_GetTokenWithGrant(t *testing.T) {
+	newTokenServer := func(t *testing.T, check func(r *http.Request)) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			check(r)
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(oauthResponse{AccessToken: "123456789", TokenType: "bearer", ExpiresIn: 3600})
+		}))
+	}
+
+	newTokenCache := func() *mocks.TokenCache {
+		tokenCache := &mocks.TokenCache{}
+		tokenCache.On("Get", mock.AnythingOfType("string")).Return("", false)
+		tokenCache.On("Add", mock.AnythingOfType("string"), "123456789", 3600).Return()
+		return tokenCache
+	}
+
+	t.Run("should fetch token with password grant", func(t *testing.T) {
+		// given
+		ts := newTokenServer(t, func(r *http.Request) {
+			assert.Equal(t, "password", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "testID", r.PostForm.Get("client_id"))
+			assert.Equal(t, "testSecret", r.PostForm.Get("client_secret"))
+			assert.Equal(t, "user", r.PostForm.Get("username"))
+			assert.Equal(t, "pass", r.PostForm.Get("password"))
+		})
+		defer ts.Close()
+
+		tokenCache := newTokenCache()
+		oauthClient := NewOauthClient(10, tokenCache, nil)
+		grant := Grant{Type: GrantTypePassword, Username: "user", Password: "pass"}
+
+		// when
+		token, err := oauthClient.GetTokenWithGrant(context.Background(), "testID", "testSecret", ts.URL, grant, nil, nil, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "123456789", token)
+		tokenCache.AssertExpectations(t)
+	})
+
+	t.Run("should fetch token with SAML bearer grant without client secret", func(t *testing.T) {
+		// given
+		ts := newTokenServer(t, func(r *http.Request) {
+			assert.Equal(t, string(GrantTypeSAMLBearer), r.PostForm.Get("grant_type"))
+			assert.Equal(t, "saml-assertion", r.PostForm.Get("assertion"))
+			assert.Empty(t, r.PostForm.Get("client_secret"))
+			assert.Empty(t, r.Header.Get("Authorization"))
+		})
+		defer ts.Close()
+
+		oauthClient := NewOauthClient(10, newTokenCache(), nil)
+		grant := Grant{Type: GrantTypeSAMLBearer, Assertion: "saml-assertion"}
+
+		// when
+		token, err := oauthClient.GetTokenWithGrant(context.Background(), "testID", "", ts.URL, grant, nil, nil, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "123456789", token)
+	})
+
+	t.Run("should exchange subject token", func(t *testing.T) {
+		// given
+		ts := newTokenServer(t, func(r *http.Request) {
+			assert.Equal(t, string(GrantTypeTokenExchange), r.PostForm.Get("grant_type"))
+			assert.Equal(t, "user-token", r.PostForm.Get("subject_token"))
+			assert.Equal(t, "urn:ietf:params:oauth:token-type:access_token", r.PostForm.Get("subject_token_type"))
+			assert.Equal(t, "target", r.PostForm.Get("audience"))
+		})
+		defer ts.Close()
+
+		oauthClient := NewOauthClient(10, newTokenCache(), nil)
+		refreshes := 0
+		oauthClient.(*client).refresher.afterFunc = func(d time.Duration, f func()) *time.Timer {
+			refreshes++
+			return time.NewTimer(time.Hour)
+		}
+		grant := Grant{Type: GrantTypeTokenExchange, Assertion: "user-token", Audience: "target", UserBound: true}
+
+		// when
+		token, err := oauthClient.GetTokenWithGrant(context.Background(), "testID", "testSecret", ts.URL, grant, nil, nil, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "123456789", token)
+		assert.Zero(t, refreshes, "token of the caller should not be refreshed in the background")
+	})
+
+	t.Run("should fetch token with signed JWT bearer assertion", func(t *testing.T) {
+		// given
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+		var tokenURL string
+		ts := newTokenServer(t, func(r *http.Request) {
+			assert.Equal(t, string(GrantTypeJWTBearer), r.PostForm.Get("grant_type"))
+
+			parts := strings.Split(r.PostForm.Get("assertion"), ".")
+			require.Len(t, parts, 3)
+
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			require.NoError(t, err)
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+			claimsData, err := base64.RawURLEncoding.DecodeString(parts[1])
+			require.NoError(t, err)
+			claims := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(claimsData, &claims))
+			assert.Equal(t, "testID", claims["iss"])
+			assert.Equal(t, "user@example.com", claims["sub"])
+			assert.Equal(t, tokenURL, claims["aud"])
+		})
+		defer ts.Close()
+		tokenURL = ts.URL
+
+		oauthClient := NewOauthClient(10, newTokenCache(), nil)
+		grant := Grant{Type: GrantTypeJWTBearer, AssertionKey: keyPEM, AssertionSubject: "user@example.com"}
+
+		// when
+		token, appErr := oauthClient.GetTokenWithGrant(context.Background(), "testID", "testSecret", ts.URL, grant, nil, nil, false)
+
+		// then
+		require.NoError(t, appErr)
+		assert.Equal(t, "123456789", token)
+	})
+
+	t.Run("should fail with invalid JWT assertion key", func(t *testing.T) {
+		// given
+		oauthClient := NewOauthClient(10, newTokenCache(), nil)
+		grant := Grant{Type: GrantTypeJWTBearer, AssertionKey: []byte("invalid")}
+
+		// when
+		_, err := oauthClient.GetTokenWithGrant(context.Background(), "testID", "testSecret", "http://localhost/token", grant, nil, nil, false)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeInternal, err.Code())
+		assert.Contains(t, err.Error(), "no PEM encoded private key found")
+	})
+
+	t.Run("should cache tokens of different users separately", func(t *testing.T) {
+		// given
+		first := Grant{Type: GrantTypePassword, Username: "first", Password: "pass"}
+		second := Grant{Type: GrantTypePassword, Username: "second", Password: "pass"}
+
+		// then
+		assert.NotEqual(t, first.cacheKey("testID", "testSecret", "url"), second.cacheKey("testID", "testSecret", "url"))
+		assert.Equal(t, first.cacheKey("testID", "testSecret", "url"), first.cacheKey("testID", "testSecret", "url"))
+	})
+}
@@ -3,8 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	apperrors "github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
 	mock "github.com/stretchr/testify/mock"
+
+	oauth "github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -12,20 +16,43 @@ type Client struct {
 	mock.Mock
 }
 
-// GetToken provides a mock function with given fields: clientID, clientSecret, authURL, headers, queryParameters, skipVerify
-func (_m *Client) GetToken(clientID string, clientSecret string, authURL string, headers *map[string][]string, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
-	ret := _m.Called(clientID, clientSecret, authURL, headers, queryParameters, skipVerify)
+// GetToken provides a mock function with given fields: ctx, clientID, clientSecret, authURL, headers, queryParameters, skipVerify
+func (_m *Client) GetToken(ctx context.Context, clientID string, clientSecret string, authURL string, headers *map[string][]string, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
+	ret := _m.Called(ctx, clientID, clientSecret, authURL, headers, queryParameters, skipVerify)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *map[string][]string, *map[string][]string, bool) string); ok {
+		r0 = rf(ctx, clientID, clientSecret, authURL, headers, queryParameters, skipVerify)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 apperrors.AppError
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
+		r1 = rf(ctx, clientID, clientSecret, authURL, headers, queryParameters, skipVerify)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(apperrors.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetTokenMTLS provides a mock function with given fields: ctx, clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify
+func (_m *Client) GetTokenMTLS(ctx context.Context, clientID string, authURL string, certificate []byte, privateKey []byte, headers *map[string][]string, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
+	ret := _m.Called(ctx, clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string, string, *map[string][]string, *map[string][]string, bool) string); ok {
-		r0 = rf(clientID, clientSecret, authURL, headers, queryParameters, skipVerify)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte, []byte, *map[string][]string, *map[string][]string, bool) string); ok {
+		r0 = rf(ctx, clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, string, string, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
-		r1 = rf(clientID, clientSecret, authURL, headers, queryParameters, skipVerify)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte, []byte, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
+		r1 = rf(ctx, clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -35,20 +62,20 @@ func (_m *Client) GetToken(clientID string, clientSecret string, authURL string,
 	return r0, r1
 }
 
-// GetTokenMTLS provides a mock function with given fields: clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify
-func (_m *Client) GetTokenMTLS(clientID string, authURL string, certificate []byte, privateKey []byte, headers *map[string][]string, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
-	ret := _m.Called(clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
+// GetTokenWithGrant provides a mock function with given fields: ctx, clientID, clientSecret, authURL, grant, headers, queryParameters, skipVerify
+func (_m *Client) GetTokenWithGrant(ctx context.Context, clientID string, clientSecret string, authURL string, grant oauth.Grant, headers *map[string][]string, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
+	ret := _m.Called(ctx, clientID, clientSecret, authURL, grant, headers, queryParameters, skipVerify)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string, []byte, []byte, *map[string][]string, *map[string][]string, bool) string); ok {
-		r0 = rf(clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, oauth.Grant, *map[string][]string, *map[string][]string, bool) string); ok {
+		r0 = rf(ctx, clientID, clientSecret, authURL, grant, headers, queryParameters, skipVerify)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 apperrors.AppError
-	if rf, ok := ret.Get(1).(func(string, string, []byte, []byte, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
-		r1 = rf(clientID, authURL, certificate, privateKey, headers, queryParameters, skipVerify)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, oauth.Grant, *map[string][]string, *map[string][]string, bool) apperrors.AppError); ok {
+		r1 = rf(ctx, clientID, clientSecret, authURL, grant, headers, queryParameters, skipVerify)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(apperrors.AppError)
@@ -68,6 +95,11 @@ func (_m *Client) InvalidateTokenCacheMTLS(clientID string, authURL string, cert
 	_m.Called(clientID, authURL, certificate, privateKey)
 }
 
+// InvalidateTokenCacheWithGrant provides a mock function with given fields: clientID, clientSecret, authURL, grant
+func (_m *Client) InvalidateTokenCacheWithGrant(clientID string, clientSecret string, authURL string, grant oauth.Grant) {
+	_m.Called(clientID, clientSecret, authURL, grant)
+}
+
 type mockConstructorTestingTNewClient interface {
 	mock.TestingT
 	Cleanup(func())
@@ -29,42 +29,59 @@ type oauthResponse struct {
 
 //go:generate mockery --name=Client
 type Client interface {
-	GetToken(clientID, clientSecret, authURL string, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError)
-	GetTokenMTLS(clientID, authURL string, certificate, privateKey []byte, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError)
+	GetToken(ctx context.Context, clientID, clientSecret, authURL string, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError)
+	GetTokenMTLS(ctx context.Context, clientID, authURL string, certificate, privateKey []byte, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError)
 	InvalidateTokenCache(clientID string, clientSecret string, authURL string)
 	InvalidateTokenCacheMTLS(clientID, authURL string, certificate, privateKey []byte)
+	GetTokenWithGrant(ctx context.Context, clientID, clientSecret, authURL string, grant Grant, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError)
+	InvalidateTokenCacheWithGrant(clientID, clientSecret, authURL string, grant Grant)
 }
 
+// FetchObserver is called with the duration of every request to the token endpoint,
+// the context is the one of the proxied request for which the token is fetched
+type FetchObserver func(ctx context.Context, duration time.Duration)
+
 type client struct {
 	timeoutDuration int
 	tokenCache      tokencache.TokenCache
+	refresher       *tokenRefresher
+	fetchObserver   FetchObserver
 }
 
-func NewOauthClient(timeoutDuration int, tokenCache tokencache.TokenCache) Client {
+// NewOauthClient creates the OAuth client, the fetch observer is optional
+func NewOauthClient(timeoutDuration int, tokenCache tokencache.TokenCache, fetchObserver FetchObserver) Client {
 	return &client{
 		timeoutDuration: timeoutDuration,
 		tokenCache:      tokenCache,
+		refresher:       newTokenRefresher(tokenCache),
+		fetchObserver:   fetchObserver,
 	}
 }
 
-func (c *client) GetToken(clientID, clientSecret, authURL string, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
-	token, found := c.tokenCache.Get(c.makeOAuthTokenCacheKey(clientID, clientSecret, authURL))
+func (c *client) GetToken(ctx context.Context, clientID, clientSecret, authURL string, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
+	key := c.makeOAuthTokenCacheKey(clientID, clientSecret, authURL)
+	token, found := c.refresher.get(key)
 	if found {
 		return token, nil
 	}
 
-	tokenResponse, err := c.requestToken(clientID, clientSecret, authURL, headers, queryParameters, skipVerify)
+	request := func(ctx context.Context) (*oauthResponse, apperrors.AppError) {
+		return c.requestToken(ctx, clientID, clientSecret, authURL, headers, queryParameters, skipVerify)
+	}
+
+	tokenResponse, err := request(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	c.tokenCache.Add(c.makeOAuthTokenCacheKey(clientID, clientSecret, authURL), tokenResponse.AccessToken, tokenResponse.ExpiresIn)
+	c.refresher.add(ctx, key, tokenResponse, request)
 
 	return tokenResponse.AccessToken, nil
 }
 
-func (c *client) GetTokenMTLS(clientID, authURL string, certificate, privateKey []byte, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
-	token, found := c.tokenCache.Get(c.makeMTLSOAuthTokenCacheKey(clientID, authURL, certificate, privateKey))
+func (c *client) GetTokenMTLS(ctx context.Context, clientID, authURL string, certificate, privateKey []byte, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
+	key := c.makeMTLSOAuthTokenCacheKey(clientID, authURL, certificate, privateKey)
+	token, found := c.refresher.get(key)
 	if found {
 		return token, nil
 	}
@@ -74,26 +91,64 @@ func (c *client) GetTokenMTLS(clientID, authURL string, certificate, privateKey
 		return "", apperrors.Internal("Failed to prepare certificate, %s", err.Error())
 	}
 
-	tokenResponse, requestError := c.requestTokenMTLS(clientID, authURL, cert, headers, queryParameters, skipVerify)
-	if err != nil {
+	request := func(ctx context.Context) (*oauthResponse, apperrors.AppError) {
+		tokenResponse, err := c.requestTokenMTLS(ctx, clientID, authURL, cert, headers, queryParameters, skipVerify)
+		if err != nil {
+			return nil, err
+		}
+
+		if tokenResponse == nil {
+			return nil, apperrors.Internal("Failed to fetch token, possible certificate problem")
+		}
+
+		return tokenResponse, nil
+	}
+
+	tokenResponse, requestError := request(ctx)
+	if requestError != nil {
 		return "", requestError
 	}
 
-	if tokenResponse == nil {
-		return "", apperrors.Internal("Failed to fetch token, possible certificate problem")
+	c.refresher.add(ctx, key, tokenResponse, request)
+
+	return tokenResponse.AccessToken, nil
+}
+
+func (c *client) GetTokenWithGrant(ctx context.Context, clientID, clientSecret, authURL string, grant Grant, headers, queryParameters *map[string][]string, skipVerify bool) (string, apperrors.AppError) {
+	key := grant.cacheKey(clientID, clientSecret, authURL)
+	token, found := c.refresher.get(key)
+	if found {
+		return token, nil
+	}
+
+	request := func(ctx context.Context) (*oauthResponse, apperrors.AppError) {
+		return c.requestTokenWithGrant(ctx, clientID, clientSecret, authURL, grant, headers, queryParameters, skipVerify)
+	}
+
+	tokenResponse, err := request(ctx)
+	if err != nil {
+		return "", err
 	}
 
-	c.tokenCache.Add(c.makeMTLSOAuthTokenCacheKey(clientID, authURL, certificate, privateKey), tokenResponse.AccessToken, tokenResponse.ExpiresIn)
+	if grant.UserBound {
+		// the token is used only as long as the caller sends requests
+		request = nil
+	}
+	c.refresher.add(ctx, key, tokenResponse, request)
 
 	return tokenResponse.AccessToken, nil
 }
 
 func (c *client) InvalidateTokenCache(clientID, clientSecret, authURL string) {
-	c.tokenCache.Remove(c.makeOAuthTokenCacheKey(clientID, clientSecret, authURL))
+	c.refresher.remove(c.makeOAuthTokenCacheKey(clientID, clientSecret, authURL))
 }
 
 func (c *client) InvalidateTokenCacheMTLS(clientID, authURL string, certificate, privateKey []byte) {
-	c.tokenCache.Remove(c.makeMTLSOAuthTokenCacheKey(clientID, authURL, certificate, privateKey))
+	c.refresher.remove(c.makeMTLSOAuthTokenCacheKey(clientID, authURL, certificate, privateKey))
+}
+
+func (c *client) InvalidateTokenCacheWithGrant(clientID, clientSecret, authURL string, grant Grant) {
+	c.refresher.remove(grant.cacheKey(clientID, clientSecret, authURL))
 }
 
 // to avoid case of single clientID and different endpoints for MTLS and standard oauth
@@ -110,86 +165,67 @@ func (c *client) makeMTLSOAuthTokenCacheKey(clientID, authURL string, certificat
 	return fmt.Sprintf("%v-%v-%v-%v", clientID, hashedCertificate, hashedKey, authURL)
 }
 
-func (c *client) requestToken(clientID, clientSecret, authURL string, headers, queryParameters *map[string][]string, skipVerify bool) (*oauthResponse, apperrors.AppError) {
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: skipVerify},
-	}
-	client := &http.Client{Transport: transport}
-
+func (c *client) requestToken(ctx context.Context, clientID, clientSecret, authURL string, headers, queryParameters *map[string][]string, skipVerify bool) (*oauthResponse, apperrors.AppError) {
 	form := url.Values{}
 	form.Add("client_id", clientID)
 	form.Add("client_secret", clientSecret)
 	form.Add("grant_type", "client_credentials")
 
-	req, err := http.NewRequest(http.MethodPost, authURL, strings.NewReader(form.Encode()))
-	if err != nil {
-		return nil, apperrors.Internal("failed to create token request: %s", err.Error())
-	}
-
-	util.AddBasicAuthHeader(req, clientID, clientSecret)
-	req.Header.Add(httpconsts.HeaderContentType, httpconsts.ContentTypeApplicationURLEncoded)
-
-	setCustomQueryParameters(req.URL, queryParameters)
-	setCustomHeaders(req.Header, headers)
-
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.timeoutDuration)*time.Second)
-	defer cancel()
-	requestWithContext := req.WithContext(ctx)
+	return c.postTokenRequest(ctx, authURL, &tls.Config{InsecureSkipVerify: skipVerify}, form, clientID, clientSecret, headers, queryParameters)
+}
 
-	response, err := client.Do(requestWithContext)
-	if err != nil {
-		return nil, apperrors.UpstreamServerCallFailed("failed to make a request to '%s': %s", authURL, err.Error())
+func (c *client) requestTokenMTLS(ctx context.Context, clientID, authURL string, cert tls.Certificate, headers, queryParameters *map[string][]string, skipVerify bool) (*oauthResponse, apperrors.AppError) {
+	tlsConfig := &tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		InsecureSkipVerify: skipVerify,
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return nil, apperrors.UpstreamServerCallFailed("incorrect response code '%d' while getting token from %s", response.StatusCode, authURL)
-	}
+	form := url.Values{}
+	form.Add("client_id", clientID)
+	form.Add("grant_type", "client_credentials")
 
-	body, err := ioutil.ReadAll(response.Body)
-	defer response.Body.Close()
-	if err != nil {
-		return nil, apperrors.UpstreamServerCallFailed("failed to read token response body from '%s': %s", authURL, err.Error())
-	}
+	return c.postTokenRequest(ctx, authURL, tlsConfig, form, "", "", headers, queryParameters)
+}
 
-	tokenResponse := &oauthResponse{}
+func (c *client) requestTokenWithGrant(ctx context.Context, clientID, clientSecret, authURL string, grant Grant, headers, queryParameters *map[string][]string, skipVerify bool) (*oauthResponse, apperrors.AppError) {
+	form, appErr := grant.form(clientID, authURL)
+	if appErr != nil {
+		return nil, appErr
+	}
 
-	err = json.Unmarshal(body, tokenResponse)
-	if err != nil {
-		return nil, apperrors.UpstreamServerCallFailed("failed to unmarshal token response body: %s", err.Error())
+	// public clients using password or assertion grants may not have a secret
+	if clientSecret != "" {
+		form.Add("client_secret", clientSecret)
 	}
 
-	return tokenResponse, nil
+	return c.postTokenRequest(ctx, authURL, &tls.Config{InsecureSkipVerify: skipVerify}, form, clientID, clientSecret, headers, queryParameters)
 }
 
-func (c *client) requestTokenMTLS(clientID, authURL string, cert tls.Certificate, headers, queryParameters *map[string][]string, skipVerify bool) (*oauthResponse, apperrors.AppError) {
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			Certificates:       []tls.Certificate{cert},
-			InsecureSkipVerify: skipVerify,
-		},
-	}
-	client := &http.Client{Transport: transport}
-
-	form := url.Values{}
-	form.Add("client_id", clientID)
-	form.Add("grant_type", "client_credentials")
+// postTokenRequest sends the form to the token endpoint and decodes the token response.
+// The client is authenticated with the basic auth header only if the client secret is set
+func (c *client) postTokenRequest(ctx context.Context, authURL string, tlsConfig *tls.Config, form url.Values, clientID, clientSecret string, headers, queryParameters *map[string][]string) (*oauthResponse, apperrors.AppError) {
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
 
 	req, err := http.NewRequest(http.MethodPost, authURL, strings.NewReader(form.Encode()))
-
 	if err != nil {
 		return nil, apperrors.Internal("failed to create token request: %s", err.Error())
 	}
 
+	if clientSecret != "" {
+		util.AddBasicAuthHeader(req, clientID, clientSecret)
+	}
 	req.Header.Add(httpconsts.HeaderContentType, httpconsts.ContentTypeApplicationURLEncoded)
 
 	setCustomQueryParameters(req.URL, queryParameters)
 	setCustomHeaders(req.Header, headers)
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.timeoutDuration)*time.Second)
+	requestCtx, cancel := context.WithTimeout(ctx, time.Duration(c.timeoutDuration)*time.Second)
 	defer cancel()
-	requestWithContext := req.WithContext(ctx)
+	requestWithContext := req.WithContext(requestCtx)
 
+	start := time.Now()
 	response, err := client.Do(requestWithContext)
+	c.observeFetch(ctx, start)
 	if err != nil {
 		return nil, apperrors.UpstreamServerCallFailed("failed to make a request to '%s': %s", authURL, err.Error())
 	}
@@ -226,3 +262,9 @@ func setCustomHeaders(reqHeaders http.Header, customHeaders *map[string][]string
 
 	httptools.SetHeaders(reqHeaders, customHeaders)
 }
+
+func (c *client) observeFetch(ctx context.Context, start time.Time) {
+	if c.fetchObserver != nil {
+		c.fetchObserver(ctx, time.Since(start))
+	}
+}
@@ -1,6 +1,7 @@
 package oauth
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
@@ -8,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
 
@@ -22,10 +24,10 @@ func TestOauthClient_GetToken(t *testing.T) {
 		tokenCache := mocks.TokenCache{}
 		tokenCache.On("Get", "testIDtestSecret").Return("123456789", true)
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		oauthClient := NewOauthClient(10, &tokenCache, nil)
 
 		// when
-		token, err := oauthClient.GetToken("testID", "testSecret", "", nil, nil, false)
+		token, err := oauthClient.GetToken(context.Background(), "testID", "testSecret", "", nil, nil, false)
 
 		// then
 		require.NoError(t, err)
@@ -52,15 +54,21 @@ func TestOauthClient_GetToken(t *testing.T) {
 		tokenCache.On("Get", tokenKey).Return("", false)
 		tokenCache.On("Add", tokenKey, "123456789", 3600).Return()
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		type ctxKey struct{}
+		var observedCtx context.Context
+		oauthClient := NewOauthClient(10, &tokenCache, func(ctx context.Context, _ time.Duration) {
+			observedCtx = ctx
+		})
 
 		// when
-		token, err := oauthClient.GetToken("testID", "testSecret", ts.URL, nil, nil, false)
+		token, err := oauthClient.GetToken(context.WithValue(context.Background(), ctxKey{}, "request"), "testID", "testSecret", ts.URL, nil, nil, false)
 
 		// then
 		require.NoError(t, err)
 		assert.Equal(t, "123456789", token)
 		tokenCache.AssertExpectations(t)
+		require.NotNil(t, observedCtx)
+		assert.Equal(t, "request", observedCtx.Value(ctxKey{}))
 	})
 
 	t.Run("should fetch token from insecure server when token if not present in cache", func(t *testing.T) {
@@ -84,10 +92,10 @@ func TestOauthClient_GetToken(t *testing.T) {
 		tokenCache.On("Get", tokenKey).Return("", false)
 		tokenCache.On("Add", tokenKey, "123456789", 3600).Return()
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		oauthClient := NewOauthClient(10, &tokenCache, nil)
 
 		// when
-		token, err := oauthClient.GetToken("testID", "testSecret", ts.URL, nil, nil, true)
+		token, err := oauthClient.GetToken(context.Background(), "testID", "testSecret", ts.URL, nil, nil, true)
 
 		// then
 		require.NoError(t, err)
@@ -122,10 +130,10 @@ func TestOauthClient_GetToken(t *testing.T) {
 		tokenCache.On("Get", tokenKey).Return("", false)
 		tokenCache.On("Add", tokenKey, "123456789", 3600).Return()
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		oauthClient := NewOauthClient(10, &tokenCache, nil)
 
 		// when
-		token, err := oauthClient.GetToken("testID", "testSecret", ts.URL, &headers, &queryParameters, false)
+		token, err := oauthClient.GetToken(context.Background(), "testID", "testSecret", ts.URL, &headers, &queryParameters, false)
 
 		// then
 		require.NoError(t, err)
@@ -146,10 +154,10 @@ func TestOauthClient_GetToken(t *testing.T) {
 		tokenCache := mocks.TokenCache{}
 		tokenCache.On("Get", tokenKey).Return("", false)
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		oauthClient := NewOauthClient(10, &tokenCache, nil)
 
 		// when
-		token, err := oauthClient.GetToken("testID", "testSecret", ts.URL, nil, nil, false)
+		token, err := oauthClient.GetToken(context.Background(), "testID", "testSecret", ts.URL, nil, nil, false)
 
 		// then
 		require.Error(t, err)
@@ -172,10 +180,10 @@ func TestOauthClient_GetToken(t *testing.T) {
 		tokenCache := mocks.TokenCache{}
 		tokenCache.On("Get", tokenKey).Return("", false)
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		oauthClient := NewOauthClient(10, &tokenCache, nil)
 
 		// when
-		token, err := oauthClient.GetToken("testID", "testSecret", ts.URL, nil, nil, false)
+		token, err := oauthClient.GetToken(context.Background(), "testID", "testSecret", ts.URL, nil, nil, false)
 
 		// then
 		require.Error(t, err)
@@ -190,10 +198,10 @@ func TestOauthClient_GetToken(t *testing.T) {
 		tokenCache := mocks.TokenCache{}
 		tokenCache.On("Get", tokenKey).Return("", false)
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		oauthClient := NewOauthClient(10, &tokenCache, nil)
 
 		// when
-		token, err := oauthClient.GetToken("testID", "testSecret", "http://some_no_existent_address.com/token", nil, nil, false)
+		token, err := oauthClient.GetToken(context.Background(), "testID", "testSecret", "http://some_no_existent_address.com/token", nil, nil, false)
 
 		// then
 		require.Error(t, err)
@@ -216,10 +224,10 @@ func TestOauthClient_GetToken(t *testing.T) {
 		tokenCache.On("Get", tokenKey).Return("", false)
 		//tokenCache.On("Add", mock.Anything, mock.Anything, mock.Anything).Times(0)
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		oauthClient := NewOauthClient(10, &tokenCache, nil)
 
 		// when
-		_, err := oauthClient.GetToken("testID", "testSecret", ts.URL, nil, nil, false)
+		_, err := oauthClient.GetToken(context.Background(), "testID", "testSecret", ts.URL, nil, nil, false)
 
 		// then
 		require.Error(t, err)
@@ -236,10 +244,10 @@ func TestOauthClient_GetTokenMTLS(t *testing.T) {
 		tokenCache := mocks.TokenCache{}
 		tokenCache.On("Get", "testID-"+certSHA+"-"+keySHA+"-testURL").Return("123456789", true)
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		oauthClient := NewOauthClient(10, &tokenCache, nil)
 
 		// when
-		token, err := oauthClient.GetTokenMTLS("testID", "testURL", []byte("test"), []byte("test"), nil, nil, false)
+		token, err := oauthClient.GetTokenMTLS(context.Background(), "testID", "testURL", []byte("test"), []byte("test"), nil, nil, false)
 
 		// then
 		require.NoError(t, err)
@@ -252,10 +260,10 @@ func TestOauthClient_GetTokenMTLS(t *testing.T) {
 		tokenCache := mocks.TokenCache{}
 		tokenCache.On("Get", "testID-"+certSHA+"-"+keySHA+"-testURL").Return("", false)
 
-		oauthClient := NewOauthClient(10, &tokenCache)
+		oauthClient := NewOauthClient(10, &tokenCache, nil)
 
 		// when
-		token, err := oauthClient.GetTokenMTLS("testID", "testURL", []byte("test"), []byte("test"), nil, nil, false)
+		token, err := oauthClient.GetTokenMTLS(context.Background(), "testID", "testURL", []byte("test"), []byte("test"), nil, nil, false)
 
 		// then
 		assert.Error(t, err, apperrors.Internal("Failed to prepare certificate, %s", err.Error()))
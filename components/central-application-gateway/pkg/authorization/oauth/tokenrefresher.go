@@ -0,0 +1,129 @@
+package oauth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth/tokencache"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httptools"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// maxRefreshWindow is the longest time before the token expiry when the new token is fetched
+	maxRefreshWindow = 30 * time.Second
+	// minTokenLifetime is the shortest token lifetime for which the token is refreshed in the background.
+	// The refresh window of shorter tokens would end after the token is removed from the cache
+	minTokenLifetime = 30 * time.Second
+)
+
+type tokenRequest func(ctx context.Context) (*oauthResponse, apperrors.AppError)
+
+// tokenRefresher fetches the new token in the background shortly before the cached token expires,
+// so that the requests don't wait for the token endpoint. Tokens which weren't used since they were fetched aren't refreshed
+type tokenRefresher struct {
+	tokenCache tokencache.TokenCache
+	mutex      sync.Mutex
+	refreshes  map[string]*scheduledRefresh
+	afterFunc  func(d time.Duration, f func()) *time.Timer
+}
+
+type scheduledRefresh struct {
+	timer *time.Timer
+	used  bool
+}
+
+func newTokenRefresher(tokenCache tokencache.TokenCache) *tokenRefresher {
+	return &tokenRefresher{
+		tokenCache: tokenCache,
+		refreshes:  map[string]*scheduledRefresh{},
+		afterFunc:  time.AfterFunc,
+	}
+}
+
+// get returns the cached token and marks it as used
+func (r *tokenRefresher) get(key string) (string, bool) {
+	token, found := r.tokenCache.Get(key)
+	if !found {
+		return "", false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if refresh, scheduled := r.refreshes[key]; scheduled {
+		refresh.used = true
+	}
+
+	return token, true
+}
+
+// add caches the token and schedules its refresh, the token isn't refreshed if the request is nil
+func (r *tokenRefresher) add(ctx context.Context, key string, response *oauthResponse, request tokenRequest) {
+	r.tokenCache.Add(key, response.AccessToken, response.ExpiresIn)
+
+	lifetime := time.Duration(response.ExpiresIn) * time.Second
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cancel(key)
+	if request == nil || lifetime < minTokenLifetime {
+		return
+	}
+
+	refresh := &scheduledRefresh{}
+	refresh.timer = r.afterFunc(lifetime-refreshWindow(lifetime), func() {
+		r.refresh(httptools.DetachedContext(ctx), key, refresh, request)
+	})
+	r.refreshes[key] = refresh
+}
+
+// remove removes the token from the cache and cancels its refresh
+func (r *tokenRefresher) remove(key string) {
+	r.tokenCache.Remove(key)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cancel(key)
+}
+
+func (r *tokenRefresher) refresh(ctx context.Context, key string, refresh *scheduledRefresh, request tokenRequest) {
+	r.mutex.Lock()
+	current := r.refreshes[key] == refresh
+	used := refresh.used
+	if current {
+		delete(r.refreshes, key)
+	}
+	r.mutex.Unlock()
+
+	if !current || !used {
+		return
+	}
+
+	response, err := request(ctx)
+	if err != nil {
+		log.Warnf("Failed to refresh token, the token will be fetched again when it expires: %s", err)
+		return
+	}
+
+	r.add(ctx, key, response, request)
+}
+
+func (r *tokenRefresher) cancel(key string) {
+	if refresh, scheduled := r.refreshes[key]; scheduled {
+		refresh.timer.Stop()
+		delete(r.refreshes, key)
+	}
+}
+
+func refreshWindow(lifetime time.Duration) time.Duration {
+	window := lifetime / 10
+	if window > maxRefreshWindow {
+		return maxRefreshWindow
+	}
+	return window
+}
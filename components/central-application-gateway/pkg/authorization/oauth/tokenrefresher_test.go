@@ -0,0 +1,127 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth/tokencache"
+)
+
+func TestTokenRefresher(t *testing.T) {
+	const key = "client"
+
+	newRefresher := func() (*tokenRefresher, *[]func(), *[]time.Duration) {
+		var scheduled []func()
+		var delays []time.Duration
+		refresher := newTokenRefresher(tokencache.NewTokenCache())
+		refresher.afterFunc = func(d time.Duration, f func()) *time.Timer {
+			scheduled = append(scheduled, f)
+			delays = append(delays, d)
+			return time.NewTimer(time.Hour)
+		}
+		return refresher, &scheduled, &delays
+	}
+
+	newRequest := func(requests *int) tokenRequest {
+		return func(ctx context.Context) (*oauthResponse, apperrors.AppError) {
+			*requests++
+			return &oauthResponse{AccessToken: fmt.Sprintf("token-%d", *requests), ExpiresIn: 3600}, nil
+		}
+	}
+
+	t.Run("should refresh used token before it expires", func(t *testing.T) {
+		// given
+		refresher, scheduled, delays := newRefresher()
+		requests := 0
+		request := newRequest(&requests)
+
+		response, _ := request(context.Background())
+		refresher.add(context.Background(), key, response, request)
+
+		_, found := refresher.get(key)
+		require.True(t, found)
+
+		// when
+		require.Len(t, *scheduled, 1)
+		(*scheduled)[0]()
+
+		// then
+		token, found := refresher.get(key)
+		require.True(t, found)
+		assert.Equal(t, "token-2", token)
+		assert.Equal(t, 3600*time.Second-maxRefreshWindow, (*delays)[0])
+		assert.Len(t, *scheduled, 2, "refreshed token should be refreshed again")
+	})
+
+	t.Run("should not refresh unused token", func(t *testing.T) {
+		// given
+		refresher, scheduled, _ := newRefresher()
+		requests := 0
+		request := newRequest(&requests)
+
+		response, _ := request(context.Background())
+		refresher.add(context.Background(), key, response, request)
+
+		// when
+		(*scheduled)[0]()
+
+		// then
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("should not refresh removed token", func(t *testing.T) {
+		// given
+		refresher, scheduled, _ := newRefresher()
+		requests := 0
+		request := newRequest(&requests)
+
+		response, _ := request(context.Background())
+		refresher.add(context.Background(), key, response, request)
+		refresher.get(key)
+
+		// when
+		refresher.remove(key)
+		(*scheduled)[0]()
+
+		// then
+		assert.Equal(t, 1, requests)
+		_, found := refresher.get(key)
+		assert.False(t, found)
+	})
+
+	t.Run("should not schedule refresh of short-lived token", func(t *testing.T) {
+		// given
+		refresher, scheduled, _ := newRefresher()
+
+		// when
+		refresher.add(context.Background(), key, &oauthResponse{AccessToken: "token", ExpiresIn: 20}, nil)
+
+		// then
+		assert.Empty(t, *scheduled)
+	})
+
+	t.Run("should keep token when refresh fails", func(t *testing.T) {
+		// given
+		refresher, scheduled, _ := newRefresher()
+		failing := func(ctx context.Context) (*oauthResponse, apperrors.AppError) {
+			return nil, apperrors.UpstreamServerCallFailed("failed")
+		}
+
+		refresher.add(context.Background(), key, &oauthResponse{AccessToken: "token", ExpiresIn: 3600}, failing)
+		refresher.get(key)
+
+		// when
+		(*scheduled)[0]()
+
+		// then
+		token, found := refresher.get(key)
+		require.True(t, found)
+		assert.Equal(t, "token", token)
+	})
+}
@@ -37,7 +37,7 @@ func newOAuthWithCertStrategy(oauthClient OAuthClient, clientId string, clientSe
 func (o oauthWithCertStrategy) AddAuthorization(r *http.Request, _ clientcert.SetClientCertificateFunc, skipTLSVerification bool) apperrors.AppError {
 	log.Infof("Passing skipTLSVerification=%v to GetTokenMTLS", skipTLSVerification)
 	headers, queryParameters := o.requestParameters.unpack()
-	token, err := o.oauthClient.GetTokenMTLS(o.clientId, o.url, o.certificate, o.privateKey, headers, queryParameters, skipTLSVerification)
+	token, err := o.oauthClient.GetTokenMTLS(r.Context(), o.clientId, o.url, o.certificate, o.privateKey, headers, queryParameters, skipTLSVerification)
 	if err != nil {
 		log.Errorf("failed to get token : '%s'", err)
 		return apperrors.Internal("Failed to get token: %s", err.Error())
@@ -48,6 +48,6 @@ func (o oauthWithCertStrategy) AddAuthorization(r *http.Request, _ clientcert.Se
 	return nil
 }
 
-func (o oauthWithCertStrategy) Invalidate() {
+func (o oauthWithCertStrategy) Invalidate(_ string) {
 	o.oauthClient.InvalidateTokenCacheMTLS(o.clientId, o.url, o.certificate, o.privateKey)
 }
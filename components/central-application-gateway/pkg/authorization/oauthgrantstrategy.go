@@ -0,0 +1,49 @@
+package authorization
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/clientcert"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	log "github.com/sirupsen/logrus"
+)
+
+type oauthGrantStrategy struct {
+	oauthClient       OAuthClient
+	clientId          string
+	clientSecret      string
+	url               string
+	grant             oauth.Grant
+	requestParameters *RequestParameters
+}
+
+func newOAuthGrantStrategy(oauthClient OAuthClient, clientId, clientSecret, url string, grant oauth.Grant, requestParameters *RequestParameters) oauthGrantStrategy {
+	return oauthGrantStrategy{
+		oauthClient:       oauthClient,
+		clientId:          clientId,
+		clientSecret:      clientSecret,
+		url:               url,
+		grant:             grant,
+		requestParameters: requestParameters,
+	}
+}
+
+func (o oauthGrantStrategy) AddAuthorization(r *http.Request, _ clientcert.SetClientCertificateFunc, skipTLSVerification bool) apperrors.AppError {
+	headers, queryParameters := o.requestParameters.unpack()
+	token, err := o.oauthClient.GetTokenWithGrant(r.Context(), o.clientId, o.clientSecret, o.url, o.grant, headers, queryParameters, skipTLSVerification)
+	if err != nil {
+		log.Errorf("failed to get token with '%s' grant : '%s'", o.grant.Type, err)
+		return err
+	}
+
+	r.Header.Set(httpconsts.HeaderAuthorization, fmt.Sprintf("Bearer %s", token))
+
+	return nil
+}
+
+func (o oauthGrantStrategy) Invalidate(_ string) {
+	o.oauthClient.InvalidateTokenCacheWithGrant(o.clientId, o.clientSecret, o.url, o.grant)
+}
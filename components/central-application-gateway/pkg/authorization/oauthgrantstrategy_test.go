@@ -0,0 +1,71 @@
+package authorization
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
+	oauthMocks "github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth/mocks"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthGrantStrategy(t *testing.T) {
+	grant := oauth.Grant{Type: oauth.GrantTypeSAMLBearer, Assertion: "assertion"}
+
+	t.Run("should add Authorization header", func(t *testing.T) {
+		// given
+		oauthClientMock := &oauthMocks.Client{}
+		oauthClientMock.On("GetTokenWithGrant", mock.Anything, "clientId", "clientSecret", "www.example.com/token", grant, (*map[string][]string)(nil), (*map[string][]string)(nil), true).Return("token", nil)
+
+		oauthStrategy := newOAuthGrantStrategy(oauthClientMock, "clientId", "clientSecret", "www.example.com/token", grant, nil)
+
+		request, err := http.NewRequest("GET", "www.example.com", nil)
+		require.NoError(t, err)
+
+		// when
+		err = oauthStrategy.AddAuthorization(request, nil, true)
+
+		// then
+		require.NoError(t, err)
+		authHeader := request.Header.Get(httpconsts.HeaderAuthorization)
+		assert.Equal(t, "Bearer token", authHeader)
+	})
+
+	t.Run("should invalidate cache", func(t *testing.T) {
+		// given
+		oauthClientMock := &oauthMocks.Client{}
+		oauthClientMock.On("InvalidateTokenCacheWithGrant", "clientId", "clientSecret", "www.example.com/token", grant).Return().Once()
+
+		oauthStrategy := newOAuthGrantStrategy(oauthClientMock, "clientId", "clientSecret", "www.example.com/token", grant, nil)
+
+		// when
+		oauthStrategy.Invalidate("")
+
+		// then
+		oauthClientMock.AssertExpectations(t)
+	})
+
+	t.Run("should not add Authorization header when getting token failed", func(t *testing.T) {
+		// given
+		oauthClientMock := &oauthMocks.Client{}
+		oauthClientMock.On("GetTokenWithGrant", mock.Anything, "clientId", "clientSecret", "www.example.com/token", grant, (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("", apperrors.Internal("failed")).Once()
+
+		oauthStrategy := newOAuthGrantStrategy(oauthClientMock, "clientId", "clientSecret", "www.example.com/token", grant, nil)
+
+		request, err := http.NewRequest("GET", "www.example.com", nil)
+		require.NoError(t, err)
+
+		// when
+		err = oauthStrategy.AddAuthorization(request, nil, false)
+
+		// then
+		require.Error(t, err)
+		authHeader := request.Header.Get(httpconsts.HeaderAuthorization)
+		assert.Equal(t, "", authHeader)
+		oauthClientMock.AssertExpectations(t)
+	})
+}
@@ -31,7 +31,7 @@ func newOAuthStrategy(oauthClient OAuthClient, clientId, clientSecret, url strin
 
 func (o oauthStrategy) AddAuthorization(r *http.Request, _ clientcert.SetClientCertificateFunc, skipTLSVerification bool) apperrors.AppError {
 	headers, queryParameters := o.requestParameters.unpack()
-	token, err := o.oauthClient.GetToken(o.clientId, o.clientSecret, o.url, headers, queryParameters, skipTLSVerification)
+	token, err := o.oauthClient.GetToken(r.Context(), o.clientId, o.clientSecret, o.url, headers, queryParameters, skipTLSVerification)
 	if err != nil {
 		log.Errorf("failed to get token : '%s'", err)
 		return err
@@ -42,6 +42,6 @@ func (o oauthStrategy) AddAuthorization(r *http.Request, _ clientcert.SetClientC
 	return nil
 }
 
-func (o oauthStrategy) Invalidate() {
+func (o oauthStrategy) Invalidate(_ string) {
 	o.oauthClient.InvalidateTokenCache(o.clientId, o.clientSecret, o.url)
 }
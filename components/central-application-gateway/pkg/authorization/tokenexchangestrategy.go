@@ -0,0 +1,75 @@
+package authorization
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/clientcert"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	log "github.com/sirupsen/logrus"
+)
+
+type tokenExchangeStrategy struct {
+	oauthClient       OAuthClient
+	clientId          string
+	clientSecret      string
+	url               string
+	grantType         oauth.GrantType
+	audience          string
+	requestParameters *RequestParameters
+}
+
+func newTokenExchangeStrategy(oauthClient OAuthClient, clientId, clientSecret, url string, grantType oauth.GrantType, audience string, requestParameters *RequestParameters) *tokenExchangeStrategy {
+	if grantType == "" {
+		grantType = oauth.GrantTypeTokenExchange
+	}
+
+	return &tokenExchangeStrategy{
+		oauthClient:       oauthClient,
+		clientId:          clientId,
+		clientSecret:      clientSecret,
+		url:               url,
+		grantType:         grantType,
+		audience:          audience,
+		requestParameters: requestParameters,
+	}
+}
+
+func (o *tokenExchangeStrategy) AddAuthorization(r *http.Request, _ clientcert.SetClientCertificateFunc, skipTLSVerification bool) apperrors.AppError {
+	userToken := UserTokenFromContext(r.Context())
+	if userToken == "" {
+		return apperrors.Unauthorized("bearer token of the caller is required to call the API")
+	}
+
+	grant := o.grant(userToken)
+	headers, queryParameters := o.requestParameters.unpack()
+	token, err := o.oauthClient.GetTokenWithGrant(r.Context(), o.clientId, o.clientSecret, o.url, grant, headers, queryParameters, skipTLSVerification)
+	if err != nil {
+		log.Errorf("failed to exchange token of the caller : '%s'", err)
+		return err
+	}
+
+	r.Header.Set(httpconsts.HeaderAuthorization, fmt.Sprintf("Bearer %s", token))
+
+	return nil
+}
+
+// Invalidate removes the token exchanged for the caller of the rejected request from the cache,
+// the tokens of other callers are kept
+func (o *tokenExchangeStrategy) Invalidate(subjectToken string) {
+	if subjectToken == "" {
+		return
+	}
+	o.oauthClient.InvalidateTokenCacheWithGrant(o.clientId, o.clientSecret, o.url, o.grant(subjectToken))
+}
+
+func (o *tokenExchangeStrategy) grant(subjectToken string) oauth.Grant {
+	return oauth.Grant{
+		Type:      o.grantType,
+		Assertion: subjectToken,
+		Audience:  o.audience,
+		UserBound: true,
+	}
+}
@@ -0,0 +1,105 @@
+package authorization
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
+	oauthMocks "github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth/mocks"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenExchangeStrategy(t *testing.T) {
+	grant := oauth.Grant{Type: oauth.GrantTypeTokenExchange, Assertion: "user-token", Audience: "target", UserBound: true}
+
+	newRequest := func(t *testing.T, authorizationHeader string) *http.Request {
+		request, err := http.NewRequest("GET", "www.example.com", nil)
+		require.NoError(t, err)
+		if authorizationHeader != "" {
+			request.Header.Set(httpconsts.HeaderAuthorization, authorizationHeader)
+		}
+		return request.WithContext(ContextWithUserToken(request.Context(), request))
+	}
+
+	t.Run("should replace token of the caller with exchanged token", func(t *testing.T) {
+		// given
+		oauthClientMock := &oauthMocks.Client{}
+		oauthClientMock.On("GetTokenWithGrant", mock.Anything, "clientId", "clientSecret", "www.example.com/token", grant, (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("exchanged", nil).Once()
+
+		strategy := newTokenExchangeStrategy(oauthClientMock, "clientId", "clientSecret", "www.example.com/token", "", "target", nil)
+		request := newRequest(t, "Bearer user-token")
+
+		// when
+		err := strategy.AddAuthorization(request, nil, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer exchanged", request.Header.Get(httpconsts.HeaderAuthorization))
+		oauthClientMock.AssertExpectations(t)
+	})
+
+	t.Run("should use JWT bearer grant for user token flow", func(t *testing.T) {
+		// given
+		jwtBearerGrant := oauth.Grant{Type: oauth.GrantTypeJWTBearer, Assertion: "user-token", UserBound: true}
+		oauthClientMock := &oauthMocks.Client{}
+		oauthClientMock.On("GetTokenWithGrant", mock.Anything, "clientId", "clientSecret", "www.example.com/token", jwtBearerGrant, (*map[string][]string)(nil), (*map[string][]string)(nil), false).Return("exchanged", nil).Once()
+
+		strategy := newTokenExchangeStrategy(oauthClientMock, "clientId", "clientSecret", "www.example.com/token", oauth.GrantTypeJWTBearer, "", nil)
+		request := newRequest(t, "Bearer user-token")
+
+		// when
+		err := strategy.AddAuthorization(request, nil, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer exchanged", request.Header.Get(httpconsts.HeaderAuthorization))
+		oauthClientMock.AssertExpectations(t)
+	})
+
+	t.Run("should return unauthorized error when caller didn't send bearer token", func(t *testing.T) {
+		// given
+		oauthClientMock := &oauthMocks.Client{}
+
+		strategy := newTokenExchangeStrategy(oauthClientMock, "clientId", "clientSecret", "www.example.com/token", "", "target", nil)
+		request := newRequest(t, "Basic dXNlcjpwYXNz")
+
+		// when
+		err := strategy.AddAuthorization(request, nil, false)
+
+		// then
+		require.Error(t, err)
+		assert.Equal(t, apperrors.CodeUnauthorized, err.Code())
+		oauthClientMock.AssertNotCalled(t, "GetTokenWithGrant")
+	})
+
+	t.Run("should invalidate cached token of the caller of the rejected request", func(t *testing.T) {
+		// given
+		oauthClientMock := &oauthMocks.Client{}
+		oauthClientMock.On("InvalidateTokenCacheWithGrant", "clientId", "clientSecret", "www.example.com/token", grant).Return().Once()
+
+		strategy := newTokenExchangeStrategy(oauthClientMock, "clientId", "clientSecret", "www.example.com/token", "", "target", nil)
+
+		// when
+		strategy.Invalidate("user-token")
+
+		// then
+		oauthClientMock.AssertExpectations(t)
+	})
+
+	t.Run("should not invalidate cached tokens without the token of the caller", func(t *testing.T) {
+		// given
+		oauthClientMock := &oauthMocks.Client{}
+
+		strategy := newTokenExchangeStrategy(oauthClientMock, "clientId", "clientSecret", "www.example.com/token", "", "target", nil)
+
+		// when
+		strategy.Invalidate("")
+
+		// then
+		oauthClientMock.AssertNotCalled(t, "InvalidateTokenCacheWithGrant", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,29 @@
+package authorization
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/httpconsts"
+)
+
+const bearerPrefix = "Bearer "
+
+type userTokenKey struct{}
+
+// ContextWithUserToken returns the context of the proxied request carrying the bearer token of the caller,
+// so that it can be exchanged even after the Authorization header of the request was overwritten
+func ContextWithUserToken(ctx context.Context, r *http.Request) context.Context {
+	authorizationHeader := r.Header.Get(httpconsts.HeaderAuthorization)
+	if len(authorizationHeader) <= len(bearerPrefix) || !strings.EqualFold(authorizationHeader[:len(bearerPrefix)], bearerPrefix) {
+		return ctx
+	}
+	return context.WithValue(ctx, userTokenKey{}, authorizationHeader[len(bearerPrefix):])
+}
+
+// UserTokenFromContext returns the bearer token of the caller of the proxied request or an empty string
+func UserTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(userTokenKey{}).(string)
+	return token
+}
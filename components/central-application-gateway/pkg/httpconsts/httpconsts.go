@@ -16,6 +16,18 @@ const (
 	HeaderCacheControl         = "cache-control"
 	HeaderCacheControlVal      = "no-cache"
 	HeaderCookie               = "Cookie"
+	HeaderSetCookie            = "Set-Cookie"
+	HeaderETag                 = "ETag"
+	HeaderLastModified         = "Last-Modified"
+	HeaderIfNoneMatch          = "If-None-Match"
+	HeaderIfModifiedSince      = "If-Modified-Since"
+	HeaderExpires              = "Expires"
+	HeaderDate                 = "Date"
+	HeaderAge                  = "Age"
+	HeaderVary                 = "Vary"
+	HeaderConnection           = "Connection"
+	HeaderUpgrade              = "Upgrade"
+	HeaderCallerToken          = "X-Caller-Token"
 )
 
 const (
@@ -0,0 +1,32 @@
+package httptools
+
+import (
+	"context"
+	"time"
+)
+
+// DetachedContext returns the context which keeps the values of the parent, for example the labels of the target API
+// or the token of the caller, but not its deadline and cancellation
+func DetachedContext(parent context.Context) context.Context {
+	return detachedContext{parent: parent}
+}
+
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
@@ -0,0 +1,26 @@
+package httptools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetachedContext(t *testing.T) {
+	// given
+	type key struct{}
+	parent, cancel := context.WithTimeout(context.WithValue(context.Background(), key{}, "value"), time.Minute)
+	cancel()
+
+	// when
+	ctx := DetachedContext(parent)
+
+	// then
+	assert.Equal(t, "value", ctx.Value(key{}))
+	assert.NoError(t, ctx.Err())
+	assert.Nil(t, ctx.Done())
+	_, hasDeadline := ctx.Deadline()
+	assert.False(t, hasDeadline)
+}
@@ -3,6 +3,7 @@ package proxyconfig
 import (
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/apperrors"
 	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization"
+	"github.com/kyma-project/kyma/components/central-application-gateway/pkg/authorization/oauth"
 )
 
 //go:generate mockery --name=TargetConfigProvider
@@ -21,6 +22,8 @@ const (
 	Oauth       AuthType = "oauth"
 	Basic       AuthType = "basicauth"
 	Certificate AuthType = "certificate"
+	// TokenExchange exchanges the token of the caller for the token of the target API user
+	TokenExchange AuthType = "tokenexchange"
 )
 
 // ProxyDestinationConfig is Proxy configuration for specific target
@@ -54,15 +57,56 @@ type OauthConfig struct {
 	ClientSecret      string                          `json:"clientSecret"`
 	TokenURL          string                          `json:"tokenUrl"`
 	RequestParameters authorization.RequestParameters `json:"requestParameters,omitempty"`
+	GrantType         oauth.GrantType                 `json:"grantType,omitempty"`
+	Username          string                          `json:"username,omitempty"`
+	Password          string                          `json:"password,omitempty"`
+	Assertion         string                          `json:"assertion,omitempty"`
+	AssertionKey      []byte                          `json:"assertionKey,omitempty"`
+	AssertionSubject  string                          `json:"assertionSubject,omitempty"`
 }
 
 func (oc OauthConfig) ToCredentials() *authorization.Credentials {
+	var grant *oauth.Grant
+	if !oc.GrantType.IsClientCredentials() {
+		grant = &oauth.Grant{
+			Type:             oc.GrantType,
+			Username:         oc.Username,
+			Password:         oc.Password,
+			Assertion:        oc.Assertion,
+			AssertionKey:     oc.AssertionKey,
+			AssertionSubject: oc.AssertionSubject,
+		}
+	}
+
 	return &authorization.Credentials{
 		OAuth: &authorization.OAuth{
 			URL:               oc.TokenURL,
 			ClientID:          oc.ClientId,
 			ClientSecret:      oc.ClientSecret,
 			RequestParameters: &oc.RequestParameters,
+			Grant:             grant,
+		},
+	}
+}
+
+type TokenExchangeConfig struct {
+	ClientId          string                          `json:"clientId"`
+	ClientSecret      string                          `json:"clientSecret"`
+	TokenURL          string                          `json:"tokenUrl"`
+	GrantType         oauth.GrantType                 `json:"grantType,omitempty"`
+	Audience          string                          `json:"audience,omitempty"`
+	RequestParameters authorization.RequestParameters `json:"requestParameters,omitempty"`
+}
+
+func (tc TokenExchangeConfig) ToCredentials() *authorization.Credentials {
+	return &authorization.Credentials{
+		TokenExchange: &authorization.TokenExchange{
+			URL:               tc.TokenURL,
+			ClientID:          tc.ClientId,
+			ClientSecret:      tc.ClientSecret,
+			GrantType:         tc.GrantType,
+			Audience:          tc.Audience,
+			RequestParameters: &tc.RequestParameters,
 		},
 	}
 }
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/compass/director"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/compass/director/fake"
+	confProvider "github.com/kyma-project/kyma/components/compass-runtime-agent/internal/config"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/dryrun"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/graphql"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma"
+	kymamodel "github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma/model"
+)
+
+// runDryRun fetches the configuration from the Director stand-in serving the payload file, applies it to the copy of the cluster state,
+// and prints the differences. The configuration is written to the cluster only if it's explicitly requested
+func runDryRun(options Config) error {
+	log.Infof("Running in dry run mode with the Director payload from %s", options.DryRun.Payload)
+	ctx := context.Background()
+
+	applications, err := fetchConfigurationFromFile(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	var clusterClients *k8sResourceClientSets
+	initialState := dryrun.State{}
+	if options.DryRun.ReadsClusterState() {
+		log.Info("Reading Applications and Secrets from the cluster")
+		cfg, err := config.GetConfig()
+		if err != nil {
+			return errors.Wrap(err, "Failed to set up client config")
+		}
+
+		clusterClients, err = k8sResourceClients(cfg)
+		if err != nil {
+			return errors.Wrap(err, "Failed to initialize K8s resource clients")
+		}
+
+		initialState, err = dryrun.ReadState(ctx, clusterClients.application, clusterClients.core, options.IntegrationNamespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	applicationClientset, coreClientset := dryrun.NewClientsets(initialState)
+	if err := applyConfiguration(&k8sResourceClientSets{core: coreClientset, application: applicationClientset}, options, applications); err != nil {
+		return err
+	}
+
+	resultState, err := dryrun.ReadState(ctx, applicationClientset, coreClientset, options.IntegrationNamespace)
+	if err != nil {
+		return err
+	}
+
+	diff, err := dryrun.Diff(initialState, resultState, options.DryRun.ShowSecretData)
+	if err != nil {
+		return errors.Wrap(err, "Failed to compare Applications and Secrets")
+	}
+	fmt.Print(diff)
+
+	if !options.DryRun.Apply {
+		return nil
+	}
+
+	log.Info("Applying configuration to the cluster")
+	return applyConfiguration(clusterClients, options, applications)
+}
+
+func fetchConfigurationFromFile(ctx context.Context, options Config) ([]kymamodel.Application, error) {
+	fakeDirector, err := fake.NewDirectorFromFile(options.DryRun.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to start Director stand-in")
+	}
+	server := &http.Server{Handler: fakeDirector}
+	go server.Serve(listener)
+	defer server.Close()
+
+	gqlClient, err := graphql.New(&http.Client{}, fmt.Sprintf("http://%s/graphql", listener.Addr()), options.QueryLogging)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create GraphQL client")
+	}
+
+	applications, _, err := director.NewConfigurationClient(gqlClient, confProvider.RuntimeConfig{}).FetchConfiguration(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch configuration from Director stand-in")
+	}
+
+	return applications, nil
+}
+
+func applyConfiguration(clients *k8sResourceClientSets, options Config, applications []kymamodel.Application) error {
+	syncService, err := createSynchronisationService(clients, options)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create synchronization service")
+	}
+
+	results, appErr := syncService.Apply(applications)
+	if appErr != nil {
+		return errors.Wrap(appErr, "Failed to apply configuration")
+	}
+
+	logResults(results)
+	return nil
+}
+
+func logResults(results []kyma.Result) {
+	for _, result := range results {
+		if result.Error != nil {
+			log.Errorf("Failed to %s Application %s: %s", result.Operation, result.ApplicationName, result.Error.Error())
+			continue
+		}
+		log.Infof("Application %s: %s", result.ApplicationName, result.Operation)
+	}
+}
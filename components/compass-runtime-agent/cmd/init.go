@@ -1,6 +1,7 @@
 package main
 
 import (
+	"crypto/rand"
 	"time"
 
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/k8sconsts"
@@ -14,6 +15,8 @@ import (
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma/applications"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/metrics"
 	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
@@ -21,8 +24,8 @@ import (
 )
 
 type k8sResourceClientSets struct {
-	core        *kubernetes.Clientset
-	application *appclient.Clientset
+	core        kubernetes.Interface
+	application appclient.Interface
 	dynamic     dynamic.Interface
 }
 
@@ -49,7 +52,12 @@ func k8sResourceClients(k8sConfig *restclient.Config) (*k8sResourceClientSets, e
 	}, nil
 }
 
-func createKymaService(k8sResourceClients *k8sResourceClientSets, integrationNamespace string, centralGatewayServiceUrl string, appTLSSkipVerify bool) (kyma.Service, error) {
+const (
+	configurationHashKeyField  = "key"
+	configurationHashKeyLength = 32
+)
+
+func createKymaService(k8sResourceClients *k8sResourceClientSets, integrationNamespace, hashKeySecret, centralGatewayServiceUrl string, appTLSSkipVerify bool) (kyma.Service, error) {
 	nameResolver := k8sconsts.NewNameResolver()
 	secretsManagerConstructor := func(namespace string) secrets.Manager {
 		return k8sResourceClients.core.CoreV1().Secrets(namespace)
@@ -58,14 +66,43 @@ func createKymaService(k8sResourceClients *k8sResourceClientSets, integrationNam
 
 	applicationManager := newApplicationManager(k8sResourceClients.application)
 
-	converter := applications.NewConverter(nameResolver, centralGatewayServiceUrl, appTLSSkipVerify)
+	hashKey, err := configurationHashKey(secrets.NewRepository(secretsManagerConstructor), types.NamespacedName{Namespace: integrationNamespace, Name: hashKeySecret})
+	if err != nil {
+		return nil, err
+	}
+
+	converter := applications.NewConverter(nameResolver, centralGatewayServiceUrl, appTLSSkipVerify, hashKey)
 	credentialsService := appsecrets.NewCredentialsService(repository, strategy.NewSecretsStrategyFactory(), nameResolver)
 	requestParametersService := appsecrets.NewRequestParametersService(repository, nameResolver)
 
 	return kyma.NewService(applicationManager, converter, credentialsService, requestParametersService), nil
 }
 
-func newApplicationManager(appClientset *appclient.Clientset) applications.Repository {
+// configurationHashKey reads the HMAC key of the configuration hashes from the Secret. The key is generated on the first start of the agent,
+// so the hashes stay the same after the restarts and the unchanged Applications aren't updated
+func configurationHashKey(repository secrets.Repository, secretName types.NamespacedName) ([]byte, error) {
+	data, err := repository.Get(secretName)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, apperrors.Internal("Failed to read configuration hash key from %s secret, %s", secretName, err)
+	}
+
+	if key := data[configurationHashKeyField]; len(key) > 0 {
+		return key, nil
+	}
+
+	key := make([]byte, configurationHashKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, apperrors.Internal("Failed to generate configuration hash key, %s", err)
+	}
+
+	if err := repository.UpsertWithMerge(secretName, map[string][]byte{configurationHashKeyField: key}); err != nil {
+		return nil, apperrors.Internal("Failed to save configuration hash key in %s secret, %s", secretName, err)
+	}
+
+	return key, nil
+}
+
+func newApplicationManager(appClientset appclient.Interface) applications.Repository {
 	appInterface := appClientset.ApplicationconnectorV1alpha1().Applications()
 	return applications.NewRepository(appInterface)
 }
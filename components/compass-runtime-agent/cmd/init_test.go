@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets"
+)
+
+func TestConfigurationHashKey(t *testing.T) {
+	secretName := types.NamespacedName{Namespace: "kyma-integration", Name: "compass-agent-configuration-hash-key"}
+
+	t.Run("should generate key and reuse it after restart", func(t *testing.T) {
+		// given
+		clientset := fake.NewSimpleClientset()
+		repository := secrets.NewRepository(func(namespace string) secrets.Manager {
+			return clientset.CoreV1().Secrets(namespace)
+		})
+
+		// when
+		key, err := configurationHashKey(repository, secretName)
+		require.NoError(t, err)
+		restartedKey, err := configurationHashKey(repository, secretName)
+		require.NoError(t, err)
+
+		// then
+		assert.Len(t, key, configurationHashKeyLength)
+		assert.Equal(t, key, restartedKey)
+
+		data, err := repository.Get(secretName)
+		require.NoError(t, err)
+		assert.Equal(t, key, data[configurationHashKeyField])
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/kyma-incubator/compass/components/director/pkg/str"
 
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/compass"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/compass/cache"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/compass/director"
@@ -39,6 +40,12 @@ func main() {
 
 	log.Infof("Env config: %s", options.String())
 
+	if options.DryRun.Enabled() {
+		err = runDryRun(options)
+		exitOnError(err, "Failed to run in dry run mode")
+		return
+	}
+
 	// Get a config to talk to the apiserver
 	log.Info("Setting up client for manager")
 	cfg, err := config.GetConfig()
@@ -61,7 +68,10 @@ func main() {
 	exitOnError(err, "Failed to migrate ")
 
 	log.Info("Setting up manager")
-	mgr, err := manager.New(cfg, manager.Options{SyncPeriod: &options.ControllerSyncPeriod})
+	mgr, err := manager.New(cfg, manager.Options{
+		SyncPeriod:         &options.ControllerSyncPeriod,
+		MetricsBindAddress: ":" + options.MetricsPort,
+	})
 	exitOnError(err, "Failed to set up overall controller manager")
 
 	// Setup Scheme for all resources
@@ -71,7 +81,14 @@ func main() {
 
 	log.Info("Registering Components.")
 
-	certManager := certificates.NewCredentialsManager(clusterCertSecret, caCertSecret, secretsRepository)
+	keyAlgorithm, err := keys.ParseAlgorithm(options.ClientKey.Algorithm)
+	exitOnError(err, "Failed to parse client key algorithm")
+
+	keyStore, err := keys.NewKeyStore(options.ClientKey, secretsRepository, clusterCertSecret)
+	exitOnError(err, "Failed to create client key store")
+
+	certManager := certificates.NewCredentialsManager(clusterCertSecret, caCertSecret, secretsRepository, keyStore)
+	csrProvider := certificates.NewCSRProvider(keyStore, keyAlgorithm, options.ClientKey.Rotate)
 
 	syncService, err := createSynchronisationService(k8sResourceClientSets, options)
 	exitOnError(err, "Failed to create synchronization service")
@@ -96,6 +113,7 @@ func main() {
 		ControllerManager:            mgr,
 		ClientsProvider:              clientsProvider,
 		CredentialsManager:           certManager,
+		CSRProvider:                  csrProvider,
 		SynchronizationService:       syncService,
 		ConfigProvider:               configProvider,
 		ConnectionDataCache:          connectionDataCache,
@@ -175,7 +193,7 @@ func createSynchronisationService(k8sResourceClients *k8sResourceClientSets, opt
 	var syncService kyma.Service
 	var err error
 
-	syncService, err = createKymaService(k8sResourceClients, options.IntegrationNamespace, options.CentralGatewayServiceUrl, options.SkipAppsTLSVerify)
+	syncService, err = createKymaService(k8sResourceClients, options.IntegrationNamespace, options.ConfigurationHashKeySecret, options.CentralGatewayServiceUrl, options.SkipAppsTLSVerify)
 
 	if err != nil {
 		return nil, err
@@ -5,7 +5,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/compass/director"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/dryrun"
 
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -28,11 +30,15 @@ type Config struct {
 	QueryLogging                 bool          `envconfig:"default=false"`
 	DirectorProxy                director.ProxyConfig
 	MetricsLoggingTimeInterval   time.Duration `envconfig:"default=30m"`
+	MetricsPort                  string        `envconfig:"default=8080"`
 	HealthPort                   string        `envconfig:"default=8090"`
 	IntegrationNamespace         string        `envconfig:"default=kyma-integration"`
+	ConfigurationHashKeySecret   string        `envconfig:"default=compass-agent-configuration-hash-key"`
 	CaCertSecretToMigrate        string        `envconfig:"default=''"`
 	CaCertSecretKeysToMigrate    string        `envconfig:"default='cacert'"`
 	Runtime                      director.RuntimeURLsConfig
+	ClientKey                    keys.Config
+	DryRun                       dryrun.Config
 }
 
 func (o *Config) String() string {
@@ -41,19 +47,23 @@ func (o *Config) String() string {
 		"CertValidityRenewalThreshold=%f, ClusterCertificatesSecret=%s, CaCertificatesSecret=%s, "+
 		"SkipCompassTLSVerify=%v, GatewayPort=%d,"+
 		"SkipAppTLSVerify=%v, "+
-		"QueryLogging=%v, MetricsLoggingTimeInterval=%s, "+
+		"QueryLogging=%v, MetricsLoggingTimeInterval=%s, MetricsPort=%s, "+
 		"RuntimeEventsURL=%s, RuntimeConsoleURL=%s"+
-		"DirectorProxyPort=%v,  DirectorProxyInsecureSkipVerify=%v, HealthPort=%s, IntegrationNamespace=%s, CaCertSecretToMigrate=%s, caCertificateSecretKeysToMigrate=%s"+
-		"CentralGatewayServiceUrl=%v",
+		"DirectorProxyPort=%v,  DirectorProxyInsecureSkipVerify=%v, HealthPort=%s, IntegrationNamespace=%s, ConfigurationHashKeySecret=%s, CaCertSecretToMigrate=%s, caCertificateSecretKeysToMigrate=%s"+
+		"CentralGatewayServiceUrl=%v, "+
+		"ClientKeyAlgorithm=%s, ClientKeyRotate=%v, ClientKeyStore=%s, ClientKeyFile=%s, ClientKeyPKCS11Module=%s, ClientKeyPKCS11TokenLabel=%s, "+
+		"DryRunPayload=%s, DryRunClusterState=%v, DryRunApply=%v",
 		o.AgentConfigurationSecret,
 		o.ControllerSyncPeriod.String(), o.MinimalCompassSyncTime.String(),
 		o.CertValidityRenewalThreshold, o.ClusterCertificatesSecret, o.CaCertificatesSecret,
 		o.SkipCompassTLSVerify, o.GatewayPort,
 		o.SkipAppsTLSVerify,
-		o.QueryLogging, o.MetricsLoggingTimeInterval,
+		o.QueryLogging, o.MetricsLoggingTimeInterval, o.MetricsPort,
 		o.Runtime.EventsURL, o.Runtime.ConsoleURL,
-		o.DirectorProxy.Port, o.DirectorProxy.InsecureSkipVerify, o.HealthPort, o.IntegrationNamespace, o.CaCertSecretToMigrate, o.CaCertSecretKeysToMigrate,
-		o.CentralGatewayServiceUrl)
+		o.DirectorProxy.Port, o.DirectorProxy.InsecureSkipVerify, o.HealthPort, o.IntegrationNamespace, o.ConfigurationHashKeySecret, o.CaCertSecretToMigrate, o.CaCertSecretKeysToMigrate,
+		o.CentralGatewayServiceUrl,
+		o.ClientKey.Algorithm, o.ClientKey.Rotate, o.ClientKey.Store, o.ClientKey.File, o.ClientKey.PKCS11.Module, o.ClientKey.PKCS11.TokenLabel,
+		o.DryRun.Payload, o.DryRun.ClusterState, o.DryRun.Apply)
 }
 
 func parseNamespacedName(value string) types.NamespacedName {
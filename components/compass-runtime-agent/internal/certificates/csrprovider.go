@@ -1,35 +1,41 @@
 package certificates
 
 import (
+	"crypto"
 	"crypto/rand"
-	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/pem"
 
 	"github.com/pkg/errors"
-)
 
-const (
-	rsaKeySize = 4096
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys"
 )
 
 //go:generate mockery --name=CSRProvider
 type CSRProvider interface {
-	CreateCSR(subject pkix.Name) (string, *rsa.PrivateKey, error)
+	CreateCSR(subject pkix.Name) (string, crypto.Signer, error)
 }
 
 type csrProvider struct {
+	keyStore     keys.KeyStore
+	keyAlgorithm keys.Algorithm
+	rotateKey    bool
 }
 
-func NewCSRProvider() CSRProvider {
-	return &csrProvider{}
+func NewCSRProvider(keyStore keys.KeyStore, keyAlgorithm keys.Algorithm, rotateKey bool) CSRProvider {
+	return &csrProvider{
+		keyStore:     keyStore,
+		keyAlgorithm: keyAlgorithm,
+		rotateKey:    rotateKey,
+	}
 }
 
-// CreateCSR generates private key and returns it along with base 64 encoded CSR
-func (cp *csrProvider) CreateCSR(subject pkix.Name) (string, *rsa.PrivateKey, error) {
-	clusterPrivateKey, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+// CreateCSR returns the private key along with base 64 encoded CSR.
+// The new key is generated if the key rotation is enabled or the key store doesn't contain any key
+func (cp *csrProvider) CreateCSR(subject pkix.Name) (string, crypto.Signer, error) {
+	clusterPrivateKey, err := cp.privateKey()
 	if err != nil {
 		return "", nil, err
 	}
@@ -42,7 +48,27 @@ func (cp *csrProvider) CreateCSR(subject pkix.Name) (string, *rsa.PrivateKey, er
 	return base64.StdEncoding.EncodeToString(csr), clusterPrivateKey, nil
 }
 
-func createCSR(subject pkix.Name, key *rsa.PrivateKey) ([]byte, error) {
+func (cp *csrProvider) privateKey() (crypto.Signer, error) {
+	if !cp.rotateKey {
+		key, err := cp.keyStore.Key()
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to read cluster private key")
+		}
+
+		if key != nil {
+			return key, nil
+		}
+	}
+
+	key, err := cp.keyStore.GenerateKey(cp.keyAlgorithm)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate cluster private key")
+	}
+
+	return key, nil
+}
+
+func createCSR(subject pkix.Name, key crypto.Signer) ([]byte, error) {
 	csrTemplate := x509.CertificateRequest{
 		Subject: subject,
 	}
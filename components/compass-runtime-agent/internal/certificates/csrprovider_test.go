@@ -1,14 +1,19 @@
 package certificates
 
 import (
+	"crypto/ecdsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/pem"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys"
+	keysMocks "github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys/mocks"
 )
 
 func TestCsrProvider_CreateCSR(t *testing.T) {
@@ -22,9 +27,15 @@ func TestCsrProvider_CreateCSR(t *testing.T) {
 		CommonName:         "test-app",
 	}
 
+	existingKey, err := keys.GenerateKey(keys.ECDSAP256)
+	require.NoError(t, err)
+
 	t.Run("should create CSR with new key", func(t *testing.T) {
 		// given
-		csrProvider := NewCSRProvider()
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("GenerateKey", keys.ECDSAP256).Return(keys.GenerateKey(keys.ECDSAP256))
+
+		csrProvider := NewCSRProvider(keyStore, keys.ECDSAP256, true)
 
 		// when
 		csr, key, err := csrProvider.CreateCSR(subject)
@@ -33,13 +44,66 @@ func TestCsrProvider_CreateCSR(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, csr)
 		require.NotEmpty(t, key)
+		assert.NotEqual(t, existingKey, key)
+		assert.IsType(t, &ecdsa.PrivateKey{}, key)
 
 		receivedCSR := decodeCSR(t, csr)
 
 		require.NotNil(t, receivedCSR)
 		assertSubject(t, receivedCSR)
+		assert.Equal(t, x509.ECDSA, receivedCSR.PublicKeyAlgorithm)
+		keyStore.AssertNotCalled(t, "Key")
+	})
+
+	t.Run("should create CSR with existing key when rotation is disabled", func(t *testing.T) {
+		// given
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(existingKey, nil)
+
+		csrProvider := NewCSRProvider(keyStore, keys.ECDSAP256, false)
+
+		// when
+		csr, key, err := csrProvider.CreateCSR(subject)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, existingKey, key)
+
+		receivedCSR := decodeCSR(t, csr)
+		assert.Equal(t, existingKey.Public(), receivedCSR.PublicKey)
+		keyStore.AssertNotCalled(t, "GenerateKey", keys.ECDSAP256)
 	})
 
+	t.Run("should create CSR with new key when rotation is disabled and key doesn't exist", func(t *testing.T) {
+		// given
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(nil, nil)
+		keyStore.On("GenerateKey", keys.RSA2048).Return(keys.GenerateKey(keys.RSA2048))
+
+		csrProvider := NewCSRProvider(keyStore, keys.RSA2048, false)
+
+		// when
+		csr, _, err := csrProvider.CreateCSR(subject)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, x509.RSA, decodeCSR(t, csr).PublicKeyAlgorithm)
+		keyStore.AssertExpectations(t)
+	})
+
+	t.Run("should return error when failed to generate key", func(t *testing.T) {
+		// given
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("GenerateKey", keys.ECDSAP256).Return(nil, errors.New("error"))
+
+		csrProvider := NewCSRProvider(keyStore, keys.ECDSAP256, true)
+
+		// when
+		_, _, err := csrProvider.CreateCSR(subject)
+
+		// then
+		require.Error(t, err)
+	})
 }
 
 func assertSubject(t *testing.T, csr *x509.CertificateRequest) {
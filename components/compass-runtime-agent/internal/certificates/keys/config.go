@@ -0,0 +1,57 @@
+package keys
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets"
+)
+
+const (
+	// SecretStore keeps the key in the Secret with the client certificate
+	SecretStore = "secret"
+	// FileStore keeps the key in the file, for example mounted by a CSI driver
+	FileStore = "file"
+	// PKCS11Store keeps the key in the token of the PKCS#11 module, for example the SoftHSM software token
+	PKCS11Store = "pkcs11"
+)
+
+// Config holds configuration of the private key of the client certificate
+type Config struct {
+	Algorithm string `envconfig:"default=RSA-4096"`
+	// Rotate enables generating the new key on every certificate renewal. If disabled, the stored key is reused
+	Rotate bool   `envconfig:"default=true"`
+	Store  string `envconfig:"default=secret"`
+	File   string `envconfig:"optional"`
+	PKCS11 PKCS11Config
+}
+
+// PKCS11Config holds configuration of the PKCS#11 token which keeps the key
+type PKCS11Config struct {
+	// Module is the path to the PKCS#11 module library, for example /usr/lib/softhsm/libsofthsm2.so
+	Module     string `envconfig:"optional"`
+	TokenLabel string `envconfig:"optional"`
+	Pin        string `envconfig:"optional"`
+	// KeyLabel is the CKA_LABEL of the keys generated in the token
+	KeyLabel string `envconfig:"default=compass-runtime-agent"`
+}
+
+// NewKeyStore creates the key store selected in the configuration
+func NewKeyStore(config Config, secretsRepository secrets.Repository, clusterCertificatesSecret types.NamespacedName) (KeyStore, error) {
+	switch config.Store {
+	case SecretStore:
+		return NewSecretKeyStore(secretsRepository, clusterCertificatesSecret), nil
+	case FileStore:
+		if config.File == "" {
+			return nil, errors.New("Key file path is required for the file key store")
+		}
+		return NewFileKeyStore(config.File), nil
+	case PKCS11Store:
+		if config.PKCS11.Module == "" || config.PKCS11.TokenLabel == "" {
+			return nil, errors.New("PKCS#11 module path and token label are required for the PKCS#11 key store")
+		}
+		return NewPKCS11KeyStore(config.PKCS11, secretsRepository, clusterCertificatesSecret)
+	default:
+		return nil, errors.Errorf("Unsupported key store %s, supported stores are %s, %s, and %s", config.Store, SecretStore, FileStore, PKCS11Store)
+	}
+}
@@ -0,0 +1,44 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets/mocks"
+)
+
+func TestNewKeyStore(t *testing.T) {
+	t.Run("should create secret key store", func(t *testing.T) {
+		keyStore, err := NewKeyStore(Config{Store: SecretStore}, &mocks.Repository{}, secretName)
+
+		require.NoError(t, err)
+		assert.IsType(t, &secretKeyStore{}, keyStore)
+	})
+
+	t.Run("should create file key store", func(t *testing.T) {
+		keyStore, err := NewKeyStore(Config{Store: FileStore, File: "/etc/client-key/key.pem"}, nil, secretName)
+
+		require.NoError(t, err)
+		assert.IsType(t, &fileKeyStore{}, keyStore)
+	})
+
+	t.Run("should fail when file path is missing", func(t *testing.T) {
+		_, err := NewKeyStore(Config{Store: FileStore}, nil, secretName)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail when PKCS#11 module is missing", func(t *testing.T) {
+		_, err := NewKeyStore(Config{Store: PKCS11Store, PKCS11: PKCS11Config{TokenLabel: "compass"}}, nil, secretName)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail for unsupported store", func(t *testing.T) {
+		_, err := NewKeyStore(Config{Store: "vault"}, nil, secretName)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+package keys
+
+import (
+	"crypto"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+type fileKeyStore struct {
+	path string
+}
+
+// NewFileKeyStore creates the key store which keeps the PEM encoded key in the file.
+// The file is read on every use, so the key rotated by the CSI driver which mounts the file is picked up.
+// If the file is mounted read-only, the key rotation must be disabled
+func NewFileKeyStore(path string) KeyStore {
+	return &fileKeyStore{
+		path: path,
+	}
+}
+
+func (s *fileKeyStore) Key() (crypto.Signer, error) {
+	keyData, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Failed to read key from %s", s.path)
+	}
+
+	return ParsePEM(keyData)
+}
+
+func (s *fileKeyStore) GenerateKey(algorithm Algorithm) (crypto.Signer, error) {
+	return GenerateKey(algorithm)
+}
+
+// SaveKey writes the key to the temporary file and renames it, so that the key file is never partially written
+func (s *fileKeyStore) SaveKey(key crypto.Signer) error {
+	keyData, err := EncodePEM(key)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save key to %s", s.path)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(keyData)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save key to %s", s.path)
+	}
+
+	if err := os.Rename(file.Name(), s.path); err != nil {
+		return errors.Wrapf(err, "Failed to save key to %s", s.path)
+	}
+
+	return nil
+}
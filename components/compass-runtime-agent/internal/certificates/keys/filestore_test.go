@@ -0,0 +1,73 @@
+package keys
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileKeyStore(t *testing.T) {
+	key, err := GenerateKey(ECDSAP256)
+	require.NoError(t, err)
+
+	t.Run("should return nil when file doesn't exist", func(t *testing.T) {
+		// when
+		storedKey, err := NewFileKeyStore(filepath.Join(t.TempDir(), "key.pem")).Key()
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, storedKey)
+	})
+
+	t.Run("should save and read key", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "key.pem")
+		keyStore := NewFileKeyStore(path)
+
+		// when
+		err := keyStore.SaveKey(key)
+		require.NoError(t, err)
+		storedKey, err := keyStore.Key()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, key, storedKey)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("should replace key", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		keyStore := NewFileKeyStore(filepath.Join(dir, "key.pem"))
+		require.NoError(t, keyStore.SaveKey(key))
+		newKey, err := keyStore.GenerateKey(ECDSAP256)
+		require.NoError(t, err)
+
+		// when
+		err = keyStore.SaveKey(newKey)
+		require.NoError(t, err)
+		storedKey, err := keyStore.Key()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, newKey, storedKey)
+
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, files, 1, "temporary files should be removed")
+	})
+
+	t.Run("should fail when directory doesn't exist", func(t *testing.T) {
+		// when
+		err := NewFileKeyStore(filepath.Join(t.TempDir(), "missing", "key.pem")).SaveKey(key)
+
+		// then
+		assert.Error(t, err)
+	})
+}
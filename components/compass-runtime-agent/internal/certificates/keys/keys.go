@@ -0,0 +1,135 @@
+// Package keys contains the generation and the storage of the private key of the client certificate
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Algorithm is the algorithm of the private key
+type Algorithm string
+
+const (
+	RSA2048   Algorithm = "RSA-2048"
+	RSA4096   Algorithm = "RSA-4096"
+	ECDSAP256 Algorithm = "ECDSA-P256"
+	ECDSAP384 Algorithm = "ECDSA-P384"
+
+	rsaKeyBlockType   = "RSA PRIVATE KEY"
+	ecKeyBlockType    = "EC PRIVATE KEY"
+	pkcs8KeyBlockType = "PRIVATE KEY"
+)
+
+// KeyStore holds the private key of the client certificate.
+// The key is returned as a crypto.Signer, so the stores may keep keys which can't be exported
+//
+//go:generate mockery --name=KeyStore
+type KeyStore interface {
+	// Key returns the current key, or nil if the store doesn't contain any key
+	Key() (crypto.Signer, error)
+	// GenerateKey generates the new key. The key becomes the current key after it's saved
+	GenerateKey(algorithm Algorithm) (crypto.Signer, error)
+	// SaveKey makes the key the current key
+	SaveKey(key crypto.Signer) error
+}
+
+// SecretKeyStore is the key store which keeps the key, or its reference, in the Secret,
+// so the key can be saved in the same update as the certificate
+type SecretKeyStore interface {
+	KeyStore
+	// SecretName returns the name of the Secret which keeps the key
+	SecretName() types.NamespacedName
+	// SecretData returns the Secret fields which make the key the current key
+	SecretData(key crypto.Signer) (map[string][]byte, error)
+}
+
+// ParseAlgorithm returns the algorithm with the name
+func ParseAlgorithm(name string) (Algorithm, error) {
+	switch algorithm := Algorithm(name); algorithm {
+	case RSA2048, RSA4096, ECDSAP256, ECDSAP384:
+		return algorithm, nil
+	default:
+		return "", errors.Errorf("Unsupported key algorithm %s, supported algorithms are %s, %s, %s, and %s", name, RSA2048, RSA4096, ECDSAP256, ECDSAP384)
+	}
+}
+
+// GenerateKey generates the private key in memory
+func GenerateKey(algorithm Algorithm) (crypto.Signer, error) {
+	switch algorithm {
+	case RSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case RSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case ECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	default:
+		return nil, errors.Errorf("Unsupported key algorithm %s", algorithm)
+	}
+}
+
+// EncodePEM encodes the RSA key in the PKCS #1 form and the ECDSA key in the SEC 1 form
+func EncodePEM(key crypto.Signer) ([]byte, error) {
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return pem.EncodeToMemory(&pem.Block{Type: rsaKeyBlockType, Bytes: x509.MarshalPKCS1PrivateKey(key)}), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to encode ECDSA key")
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: ecKeyBlockType, Bytes: der}), nil
+	default:
+		return nil, errors.Errorf("Key of type %T can't be encoded", key)
+	}
+}
+
+// ParsePEM parses the RSA key in the PKCS #1 form, the ECDSA key in the SEC 1 form, or any of them in the PKCS #8 form
+func ParsePEM(data []byte) (crypto.Signer, error) {
+	if data == nil {
+		return nil, errors.New("Private key data is empty")
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("Failed to decode client key pem")
+	}
+
+	switch block.Type {
+	case rsaKeyBlockType:
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case ecKeyBlockType:
+		return x509.ParseECPrivateKey(block.Bytes)
+	case pkcs8KeyBlockType:
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.Errorf("Key of type %T can't sign", key)
+		}
+		return signer, nil
+	default:
+		return nil, errors.Errorf("Unsupported pem block type %s", block.Type)
+	}
+}
+
+// Equal returns true if both keys are nil or have the same public key. Keys which don't expose the comparison are treated as different
+func Equal(key, other crypto.Signer) bool {
+	if key == nil || other == nil {
+		return key == nil && other == nil
+	}
+
+	publicKey, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && publicKey.Equal(other.Public())
+}
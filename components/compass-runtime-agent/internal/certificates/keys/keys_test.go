@@ -0,0 +1,118 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlgorithm(t *testing.T) {
+	t.Run("should parse supported algorithm", func(t *testing.T) {
+		algorithm, err := ParseAlgorithm("ECDSA-P256")
+
+		require.NoError(t, err)
+		assert.Equal(t, ECDSAP256, algorithm)
+	})
+
+	t.Run("should fail for unsupported algorithm", func(t *testing.T) {
+		_, err := ParseAlgorithm("DSA-1024")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGenerateKey(t *testing.T) {
+	t.Run("should generate RSA key", func(t *testing.T) {
+		key, err := GenerateKey(RSA2048)
+
+		require.NoError(t, err)
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		require.True(t, ok)
+		assert.Equal(t, 2048, rsaKey.N.BitLen())
+	})
+
+	t.Run("should generate ECDSA key", func(t *testing.T) {
+		key, err := GenerateKey(ECDSAP256)
+
+		require.NoError(t, err)
+		ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+		require.True(t, ok)
+		assert.Equal(t, elliptic.P256(), ecdsaKey.Curve)
+	})
+
+	t.Run("should fail for unsupported algorithm", func(t *testing.T) {
+		_, err := GenerateKey("DSA-1024")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestEncodeAndParsePEM(t *testing.T) {
+	for _, algorithm := range []Algorithm{RSA2048, ECDSAP256, ECDSAP384} {
+		t.Run("should encode and parse "+string(algorithm)+" key", func(t *testing.T) {
+			// given
+			key, err := GenerateKey(algorithm)
+			require.NoError(t, err)
+
+			// when
+			encoded, err := EncodePEM(key)
+			require.NoError(t, err)
+			parsed, err := ParsePEM(encoded)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, key, parsed)
+		})
+	}
+
+	t.Run("should parse PKCS #8 key", func(t *testing.T) {
+		// given
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		// when
+		parsed, err := ParsePEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, key, parsed)
+	})
+
+	t.Run("should fail to parse invalid key", func(t *testing.T) {
+		_, err := ParsePEM([]byte("key"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail to parse empty key", func(t *testing.T) {
+		_, err := ParsePEM(nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestEqual(t *testing.T) {
+	key, err := GenerateKey(ECDSAP256)
+	require.NoError(t, err)
+	encoded, err := EncodePEM(key)
+	require.NoError(t, err)
+	parsed, err := ParsePEM(encoded)
+	require.NoError(t, err)
+	other, err := GenerateKey(ECDSAP256)
+	require.NoError(t, err)
+
+	assert.True(t, Equal(key, parsed))
+	assert.True(t, Equal(nil, nil))
+	assert.False(t, Equal(key, other))
+	assert.False(t, Equal(key, nil))
+	assert.False(t, Equal(nil, key))
+}
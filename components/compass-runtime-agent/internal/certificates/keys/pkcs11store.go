@@ -0,0 +1,167 @@
+//go:build cgo
+
+package keys
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/elliptic"
+	"crypto/rand"
+
+	"github.com/ThalesIgnite/crypto11"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets"
+)
+
+const (
+	secretKeyID = "keyId"
+
+	pkcs11KeyIDLength = 16
+)
+
+type pkcs11KeyStore struct {
+	context           *crypto11.Context
+	keyLabel          []byte
+	secretsRepository secrets.Repository
+	secretName        types.NamespacedName
+}
+
+// NewPKCS11KeyStore creates the key store which generates the keys in the token of the PKCS#11 module, for example the SoftHSM software token.
+// The keys never leave the token. The CKA_ID of the current key is kept in the keyId field of the Secret, next to the certificate,
+// so that the previous key can be restored if the certificate isn't saved. The keys which aren't current are deleted before a new key is generated
+func NewPKCS11KeyStore(config PKCS11Config, secretsRepository secrets.Repository, secretName types.NamespacedName) (SecretKeyStore, error) {
+	context, err := crypto11.Configure(&crypto11.Config{
+		Path:       config.Module,
+		TokenLabel: config.TokenLabel,
+		Pin:        config.Pin,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to open %s token of %s PKCS#11 module", config.TokenLabel, config.Module)
+	}
+
+	return &pkcs11KeyStore{
+		context:           context,
+		keyLabel:          []byte(config.KeyLabel),
+		secretsRepository: secretsRepository,
+		secretName:        secretName,
+	}, nil
+}
+
+func (s *pkcs11KeyStore) Key() (crypto.Signer, error) {
+	keyID, err := s.currentKeyID()
+	if err != nil || keyID == nil {
+		return nil, err
+	}
+
+	key, err := s.context.FindKeyPair(keyID, s.keyLabel)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to find key %x in PKCS#11 token", keyID)
+	}
+	if key == nil {
+		return nil, nil
+	}
+
+	return key, nil
+}
+
+func (s *pkcs11KeyStore) GenerateKey(algorithm Algorithm) (crypto.Signer, error) {
+	if err := s.deleteUnusedKeys(); err != nil {
+		return nil, err
+	}
+
+	keyID := make([]byte, pkcs11KeyIDLength)
+	if _, err := rand.Read(keyID); err != nil {
+		return nil, errors.Wrap(err, "Failed to generate key ID")
+	}
+
+	var key crypto.Signer
+	var err error
+	switch algorithm {
+	case RSA2048:
+		key, err = s.context.GenerateRSAKeyPairWithLabel(keyID, s.keyLabel, 2048)
+	case RSA4096:
+		key, err = s.context.GenerateRSAKeyPairWithLabel(keyID, s.keyLabel, 4096)
+	case ECDSAP256:
+		key, err = s.context.GenerateECDSAKeyPairWithLabel(keyID, s.keyLabel, elliptic.P256())
+	case ECDSAP384:
+		key, err = s.context.GenerateECDSAKeyPairWithLabel(keyID, s.keyLabel, elliptic.P384())
+	default:
+		return nil, errors.Errorf("Unsupported key algorithm %s", algorithm)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate key in PKCS#11 token")
+	}
+
+	return key, nil
+}
+
+// SaveKey makes the key the current key. Only the keys generated in the token can be saved
+func (s *pkcs11KeyStore) SaveKey(key crypto.Signer) error {
+	secretData, err := s.SecretData(key)
+	if err != nil {
+		return err
+	}
+
+	err = s.secretsRepository.UpsertWithMerge(s.secretName, secretData)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save key ID in %s secret", s.secretName)
+	}
+
+	return nil
+}
+
+func (s *pkcs11KeyStore) SecretName() types.NamespacedName {
+	return s.secretName
+}
+
+func (s *pkcs11KeyStore) SecretData(key crypto.Signer) (map[string][]byte, error) {
+	keyID, err := s.context.GetAttribute(key, crypto11.CkaId)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read ID of key in PKCS#11 token")
+	}
+
+	return map[string][]byte{secretKeyID: keyID.Value}, nil
+}
+
+func (s *pkcs11KeyStore) currentKeyID() ([]byte, error) {
+	secretData, err := s.secretsRepository.Get(s.secretName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Failed to read %s secret with key ID", s.secretName)
+	}
+
+	return secretData[secretKeyID], nil
+}
+
+// deleteUnusedKeys deletes the keys replaced on the previous renewals and the keys generated on the failed renewals
+func (s *pkcs11KeyStore) deleteUnusedKeys() error {
+	currentKeyID, err := s.currentKeyID()
+	if err != nil {
+		return err
+	}
+
+	keys, err := s.context.FindKeyPairs(nil, s.keyLabel)
+	if err != nil {
+		return errors.Wrap(err, "Failed to find keys in PKCS#11 token")
+	}
+
+	for _, key := range keys {
+		keyID, err := s.context.GetAttribute(key, crypto11.CkaId)
+		if err != nil {
+			return errors.Wrap(err, "Failed to read ID of key in PKCS#11 token")
+		}
+		if bytes.Equal(keyID.Value, currentKeyID) {
+			continue
+		}
+		if err := key.Delete(); err != nil {
+			return errors.Wrapf(err, "Failed to delete unused key %x from PKCS#11 token", keyID.Value)
+		}
+	}
+
+	return nil
+}
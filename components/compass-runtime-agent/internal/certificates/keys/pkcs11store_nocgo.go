@@ -0,0 +1,15 @@
+//go:build !cgo
+
+package keys
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets"
+)
+
+// NewPKCS11KeyStore fails, because the PKCS#11 module can be loaded only by the binary built with cgo
+func NewPKCS11KeyStore(_ PKCS11Config, _ secrets.Repository, _ types.NamespacedName) (SecretKeyStore, error) {
+	return nil, errors.New("PKCS#11 key store requires Runtime Agent built with cgo")
+}
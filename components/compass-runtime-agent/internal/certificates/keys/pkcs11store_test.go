@@ -0,0 +1,128 @@
+//go:build cgo
+
+package keys
+
+import (
+	"crypto"
+	"os"
+	"testing"
+
+	"github.com/ThalesIgnite/crypto11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets"
+)
+
+// TestPKCS11KeyStore runs against the initialized token of the PKCS#11 module, for example:
+//
+//	softhsm2-util --init-token --free --label test --pin 1234 --so-pin 1234
+//	PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so PKCS11_TOKEN_LABEL=test PKCS11_PIN=1234 go test ./internal/certificates/keys/...
+func TestPKCS11KeyStore(t *testing.T) {
+	config := PKCS11Config{
+		Module:     os.Getenv("PKCS11_MODULE"),
+		TokenLabel: os.Getenv("PKCS11_TOKEN_LABEL"),
+		Pin:        os.Getenv("PKCS11_PIN"),
+		KeyLabel:   "compass-runtime-agent-test",
+	}
+	if config.Module == "" {
+		t.Skip("PKCS11_MODULE isn't set")
+	}
+
+	clientset := fake.NewSimpleClientset()
+	secretsRepository := secrets.NewRepository(func(namespace string) secrets.Manager {
+		return clientset.CoreV1().Secrets(namespace)
+	})
+	keyStore, err := NewPKCS11KeyStore(config, secretsRepository, secretName)
+	require.NoError(t, err)
+
+	t.Run("should return nil when no key is saved", func(t *testing.T) {
+		// when
+		storedKey, err := keyStore.Key()
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, storedKey)
+	})
+
+	t.Run("should save key and restore previous key", func(t *testing.T) {
+		// given
+		previousKey, err := keyStore.GenerateKey(ECDSAP256)
+		require.NoError(t, err)
+		require.NoError(t, keyStore.SaveKey(previousKey))
+		newKey, err := keyStore.GenerateKey(RSA2048)
+		require.NoError(t, err)
+
+		// when
+		err = keyStore.SaveKey(newKey)
+		require.NoError(t, err)
+		storedKey, err := keyStore.Key()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, Equal(newKey, storedKey))
+
+		// when
+		err = keyStore.SaveKey(previousKey)
+		require.NoError(t, err)
+		storedKey, err = keyStore.Key()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, Equal(previousKey, storedKey))
+	})
+
+	t.Run("should not export key", func(t *testing.T) {
+		// given
+		key, err := keyStore.GenerateKey(ECDSAP256)
+		require.NoError(t, err)
+
+		// when
+		_, err = EncodePEM(key)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail to save key generated outside token", func(t *testing.T) {
+		// given
+		key, err := GenerateKey(ECDSAP256)
+		require.NoError(t, err)
+
+		// when
+		err = keyStore.SaveKey(key)
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should delete unused keys before generating key", func(t *testing.T) {
+		// given
+		currentKey, err := keyStore.Key()
+		require.NoError(t, err)
+		unusedKey, err := keyStore.GenerateKey(ECDSAP256)
+		require.NoError(t, err)
+
+		// when
+		_, err = keyStore.GenerateKey(ECDSAP256)
+		require.NoError(t, err)
+
+		// then
+		store := keyStore.(*pkcs11KeyStore)
+		keys, err := store.context.FindKeyPairs(nil, store.keyLabel)
+		require.NoError(t, err)
+		assert.Len(t, keys, 2)
+		assert.True(t, containsKey(keys, currentKey))
+		assert.False(t, containsKey(keys, unusedKey))
+	})
+}
+
+func containsKey(keys []crypto11.Signer, key crypto.Signer) bool {
+	for _, k := range keys {
+		if Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
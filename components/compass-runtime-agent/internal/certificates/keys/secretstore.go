@@ -0,0 +1,74 @@
+package keys
+
+import (
+	"crypto"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets"
+)
+
+const secretKey = "key"
+
+type secretKeyStore struct {
+	secretsRepository secrets.Repository
+	secretName        types.NamespacedName
+}
+
+// NewSecretKeyStore creates the key store which keeps the key in the key field of the Secret
+func NewSecretKeyStore(secretsRepository secrets.Repository, secretName types.NamespacedName) SecretKeyStore {
+	return &secretKeyStore{
+		secretsRepository: secretsRepository,
+		secretName:        secretName,
+	}
+}
+
+func (s *secretKeyStore) Key() (crypto.Signer, error) {
+	secretData, err := s.secretsRepository.Get(s.secretName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Failed to read %s secret with key", s.secretName)
+	}
+
+	keyData, found := secretData[secretKey]
+	if !found {
+		return nil, nil
+	}
+
+	return ParsePEM(keyData)
+}
+
+func (s *secretKeyStore) GenerateKey(algorithm Algorithm) (crypto.Signer, error) {
+	return GenerateKey(algorithm)
+}
+
+func (s *secretKeyStore) SaveKey(key crypto.Signer) error {
+	secretData, err := s.SecretData(key)
+	if err != nil {
+		return err
+	}
+
+	err = s.secretsRepository.UpsertWithMerge(s.secretName, secretData)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save key in %s secret", s.secretName)
+	}
+
+	return nil
+}
+
+func (s *secretKeyStore) SecretName() types.NamespacedName {
+	return s.secretName
+}
+
+func (s *secretKeyStore) SecretData(key crypto.Signer) (map[string][]byte, error) {
+	keyData, err := EncodePEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{secretKey: keyData}, nil
+}
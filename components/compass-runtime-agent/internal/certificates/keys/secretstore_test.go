@@ -0,0 +1,100 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets/mocks"
+)
+
+var secretName = types.NamespacedName{Name: "cluster-certificate", Namespace: "compass-system"}
+
+func TestSecretKeyStore(t *testing.T) {
+	key, err := GenerateKey(ECDSAP256)
+	require.NoError(t, err)
+	keyData, err := EncodePEM(key)
+	require.NoError(t, err)
+
+	t.Run("should read key from secret", func(t *testing.T) {
+		// given
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("Get", secretName).Return(map[string][]byte{"key": keyData, "crt": []byte("certificate")}, nil)
+
+		// when
+		storedKey, err := NewSecretKeyStore(secretsRepository, secretName).Key()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, key, storedKey)
+	})
+
+	t.Run("should return nil when secret doesn't exist", func(t *testing.T) {
+		// given
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("Get", secretName).Return(nil, k8serrors.NewNotFound(schema.GroupResource{}, secretName.Name))
+
+		// when
+		storedKey, err := NewSecretKeyStore(secretsRepository, secretName).Key()
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, storedKey)
+	})
+
+	t.Run("should return nil when secret doesn't contain key", func(t *testing.T) {
+		// given
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("Get", secretName).Return(map[string][]byte{"crt": []byte("certificate")}, nil)
+
+		// when
+		storedKey, err := NewSecretKeyStore(secretsRepository, secretName).Key()
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, storedKey)
+	})
+
+	t.Run("should fail when secret can't be read", func(t *testing.T) {
+		// given
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("Get", secretName).Return(nil, errors.New("error"))
+
+		// when
+		_, err := NewSecretKeyStore(secretsRepository, secretName).Key()
+
+		// then
+		assert.Error(t, err)
+	})
+
+	t.Run("should save key in secret", func(t *testing.T) {
+		// given
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("UpsertWithMerge", secretName, map[string][]byte{"key": keyData}).Return(nil)
+
+		// when
+		err := NewSecretKeyStore(secretsRepository, secretName).SaveKey(key)
+
+		// then
+		require.NoError(t, err)
+		secretsRepository.AssertExpectations(t)
+	})
+
+	t.Run("should fail when key can't be saved", func(t *testing.T) {
+		// given
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("UpsertWithMerge", secretName, mock.Anything).Return(errors.New("error"))
+
+		// when
+		err := NewSecretKeyStore(secretsRepository, secretName).SaveKey(key)
+
+		// then
+		assert.Error(t, err)
+	})
+}
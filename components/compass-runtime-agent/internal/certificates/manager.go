@@ -1,18 +1,20 @@
 package certificates
 
 import (
+	"crypto"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
 
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
 	clusterCertificateSecretKey = "crt"
-	clusterKeySecretKey         = "key"
 	certificateChainSecretKey   = "crtChain"
 
 	caCertificateSecretKey = "cacert"
@@ -25,11 +27,12 @@ type Manager interface {
 	CredentialsExist() (bool, error)
 }
 
-func NewCredentialsManager(clusterCertificateSecretName, caCertSecretName types.NamespacedName, secretsRepository secrets.Repository) *credentialsManager {
+func NewCredentialsManager(clusterCertificateSecretName, caCertSecretName types.NamespacedName, secretsRepository secrets.Repository, keyStore keys.KeyStore) *credentialsManager {
 	return &credentialsManager{
 		caCertSecretName:              caCertSecretName,
 		clusterCertificatesSecretName: clusterCertificateSecretName,
 		secretsRepository:             secretsRepository,
+		keyStore:                      keyStore,
 	}
 }
 
@@ -37,6 +40,7 @@ type credentialsManager struct {
 	caCertSecretName              types.NamespacedName
 	clusterCertificatesSecretName types.NamespacedName
 	secretsRepository             secrets.Repository
+	keyStore                      keys.KeyStore
 }
 
 func (cm *credentialsManager) GetClientCredentials() (ClientCredentials, error) {
@@ -45,40 +49,87 @@ func (cm *credentialsManager) GetClientCredentials() (ClientCredentials, error)
 		return ClientCredentials{}, errors.Wrap(err, fmt.Sprintf("Failed to read %s secret with certificates", cm.clusterCertificatesSecretName))
 	}
 
+	clientKey, err := cm.keyStore.Key()
+	if err != nil {
+		return ClientCredentials{}, errors.Wrap(err, "Failed to read client key")
+	}
+	if clientKey == nil {
+		return ClientCredentials{}, errors.New("Client key not found in key store")
+	}
+
 	pemCredentials := PemEncodedCredentials{
-		ClientKey:         secretData[clusterKeySecretKey],
 		CertificateChain:  secretData[certificateChainSecretKey],
 		ClientCertificate: secretData[clusterCertificateSecretKey],
 	}
 
-	return pemCredentials.AsClientCredentials()
+	return pemCredentials.withClientKey(clientKey)
 }
 
 func (cm *credentialsManager) CredentialsExist() (bool, error) {
 	return cm.secretsRepository.Exists(cm.caCertSecretName)
 }
 
+// PreserveCredentials saves the client key in the key store and the certificates in the secrets.
+// The key isn't saved if it didn't change, so that read-only key stores can be used when the key isn't rotated.
+// If the key store keeps the key in the Secret with the client certificate, both are saved in one update.
+// Otherwise, if the client certificate can't be saved, the previous key is restored, so that the stored key matches the stored certificate
 func (cm *credentialsManager) PreserveCredentials(credentials Credentials) error {
+	previousKey, err := cm.keyStore.Key()
+	if err != nil {
+		return errors.Wrap(err, "Failed to read client key")
+	}
+
 	pemCredentials := credentials.AsPemEncoded()
+	clusterSecretData := map[string][]byte{
+		clusterCertificateSecretKey: pemCredentials.ClientCertificate,
+		certificateChainSecretKey:   pemCredentials.CertificateChain,
+	}
+
+	keyChanged := !keys.Equal(previousKey, credentials.ClientKey)
+	secretKeyStore, keyInClusterSecret := cm.keyStore.(keys.SecretKeyStore)
+	keyInClusterSecret = keyInClusterSecret && secretKeyStore.SecretName() == cm.clusterCertificatesSecretName
+
+	switch {
+	case keyChanged && keyInClusterSecret:
+		keyData, err := secretKeyStore.SecretData(credentials.ClientKey)
+		if err != nil {
+			return errors.Wrap(err, "Failed to preserve client key")
+		}
+		for field, value := range keyData {
+			clusterSecretData[field] = value
+		}
+	case keyChanged:
+		err = cm.keyStore.SaveKey(credentials.ClientKey)
+		if err != nil {
+			return errors.Wrap(err, "Failed to preserve client key")
+		}
+	}
 
-	err := cm.saveClusterCertificateAndKey(pemCredentials.ClientKey, pemCredentials.ClientCertificate, pemCredentials.CertificateChain)
+	err = cm.saveClusterCertificate(clusterSecretData)
 	if err != nil {
+		if keyChanged && !keyInClusterSecret {
+			cm.restoreKey(previousKey)
+		}
 		return err
 	}
 
 	return cm.saveCACertificate(pemCredentials.CACertificates)
 }
 
-func (cm *credentialsManager) saveClusterCertificateAndKey(clientKey, clientCert, certificateChain []byte) error {
-	clusterSecretData := map[string][]byte{
-		clusterCertificateSecretKey: clientCert,
-		clusterKeySecretKey:         clientKey,
-		certificateChainSecretKey:   certificateChain,
+func (cm *credentialsManager) restoreKey(previousKey crypto.Signer) {
+	if previousKey == nil {
+		return
 	}
 
+	if err := cm.keyStore.SaveKey(previousKey); err != nil {
+		logrus.Errorf("Failed to restore previous client key: %s", err)
+	}
+}
+
+func (cm *credentialsManager) saveClusterCertificate(clusterSecretData map[string][]byte) error {
 	err := cm.secretsRepository.UpsertWithMerge(cm.clusterCertificatesSecretName, clusterSecretData)
 	if err != nil {
-		return errors.Wrap(err, "Failed to preserve client certificate and key in secret")
+		return errors.Wrap(err, "Failed to preserve client certificate in secret")
 	}
 
 	return nil
@@ -7,9 +7,14 @@ import (
 
 	"github.com/pkg/errors"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys"
+	keysMocks "github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys/mocks"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/secrets/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,7 +45,7 @@ func TestCredentialsExist(t *testing.T) {
 		secretsRepository.On("Exists", caCertSecretNamespaceName).Return(false, expectedErr)
 
 		// when
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, &keysMocks.KeyStore{})
 
 		// then
 		exists, err := credentialsManager.CredentialsExist()
@@ -54,7 +59,7 @@ func TestCredentialsExist(t *testing.T) {
 		secretsRepository.On("Exists", caCertSecretNamespaceName).Return(true, nil)
 
 		// when
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, &keysMocks.KeyStore{})
 
 		// then
 		exists, err := credentialsManager.CredentialsExist()
@@ -75,20 +80,26 @@ func TestCertificatePreserver_PreserveCertificates(t *testing.T) {
 	credentials, err := pemCredentials.AsCredentials()
 	require.NoError(t, err)
 
+	previousKey, err := keys.GenerateKey(keys.ECDSAP256)
+	require.NoError(t, err)
+
+	clusterSecretData := map[string][]byte{
+		clusterCertificateSecretKey: clientCRT,
+		certificateChainSecretKey:   crtChain,
+	}
+	caSecretData := map[string][]byte{caCertificateSecretKey: caCRT}
+
 	t.Run("should preserve certificates", func(t *testing.T) {
 		// given
-		clusterSecretData := map[string][]byte{
-			clusterCertificateSecretKey: clientCRT,
-			clusterKeySecretKey:         clientKey,
-			certificateChainSecretKey:   crtChain,
-		}
-		caSecretData := map[string][]byte{caCertificateSecretKey: caCRT}
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(nil, nil)
+		keyStore.On("SaveKey", credentials.ClientKey).Return(nil)
 
 		secretsRepository := &mocks.Repository{}
 		secretsRepository.On("UpsertWithMerge", clusterCertSecretNamespaceName, clusterSecretData).Return(nil)
 		secretsRepository.On("UpsertWithMerge", caCertSecretNamespaceName, caSecretData).Return(nil)
 
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
 
 		// when
 		err := credentialsManager.PreserveCredentials(credentials)
@@ -96,20 +107,58 @@ func TestCertificatePreserver_PreserveCertificates(t *testing.T) {
 		// then
 		require.NoError(t, err)
 		secretsRepository.AssertExpectations(t)
+		keyStore.AssertExpectations(t)
 	})
 
-	t.Run("should return error when failed to save cluster secret", func(t *testing.T) {
+	t.Run("should not save unchanged key", func(t *testing.T) {
 		// given
-		clusterSecretData := map[string][]byte{
-			clusterCertificateSecretKey: clientCRT,
-			clusterKeySecretKey:         clientKey,
-			certificateChainSecretKey:   crtChain,
-		}
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(credentials.ClientKey, nil)
+
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("UpsertWithMerge", clusterCertSecretNamespaceName, clusterSecretData).Return(nil)
+		secretsRepository.On("UpsertWithMerge", caCertSecretNamespaceName, caSecretData).Return(nil)
+
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
+
+		// when
+		err := credentialsManager.PreserveCredentials(credentials)
+
+		// then
+		require.NoError(t, err)
+		secretsRepository.AssertExpectations(t)
+		keyStore.AssertNotCalled(t, "SaveKey", credentials.ClientKey)
+	})
+
+	t.Run("should return error when failed to save key", func(t *testing.T) {
+		// given
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(nil, nil)
+		keyStore.On("SaveKey", credentials.ClientKey).Return(errors.New("error"))
+
+		secretsRepository := &mocks.Repository{}
+
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
+
+		// when
+		err := credentialsManager.PreserveCredentials(credentials)
+
+		// then
+		require.Error(t, err)
+		secretsRepository.AssertNotCalled(t, "UpsertWithMerge", clusterCertSecretNamespaceName, clusterSecretData)
+	})
+
+	t.Run("should return error and restore previous key when failed to save cluster secret", func(t *testing.T) {
+		// given
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(previousKey, nil)
+		keyStore.On("SaveKey", credentials.ClientKey).Return(nil).Once()
+		keyStore.On("SaveKey", previousKey).Return(nil).Once()
 
 		secretsRepository := &mocks.Repository{}
 		secretsRepository.On("UpsertWithMerge", clusterCertSecretNamespaceName, clusterSecretData).Return(errors.New("error"))
 
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
 
 		// when
 		err := credentialsManager.PreserveCredentials(credentials)
@@ -117,22 +166,63 @@ func TestCertificatePreserver_PreserveCertificates(t *testing.T) {
 		// then
 		require.Error(t, err)
 		secretsRepository.AssertExpectations(t)
+		keyStore.AssertExpectations(t)
 	})
 
-	t.Run("should return error when failed to save ca secret", func(t *testing.T) {
+	t.Run("should save key and cluster certificate in one update when key is kept in cluster secret", func(t *testing.T) {
 		// given
-		clusterSecretData := map[string][]byte{
+		keyData, err := keys.EncodePEM(credentials.ClientKey)
+		require.NoError(t, err)
+
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("Get", clusterCertSecretNamespaceName).Return(nil, k8serrors.NewNotFound(schema.GroupResource{}, clusterCertSecretName))
+		secretsRepository.On("UpsertWithMerge", clusterCertSecretNamespaceName, map[string][]byte{
 			clusterCertificateSecretKey: clientCRT,
-			clusterKeySecretKey:         clientKey,
 			certificateChainSecretKey:   crtChain,
-		}
-		caSecretData := map[string][]byte{caCertificateSecretKey: caCRT}
+			"key":                       keyData,
+		}).Return(nil).Once()
+		secretsRepository.On("UpsertWithMerge", caCertSecretNamespaceName, caSecretData).Return(nil)
+
+		keyStore := keys.NewSecretKeyStore(secretsRepository, clusterCertSecretNamespaceName)
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
+
+		// when
+		err = credentialsManager.PreserveCredentials(credentials)
+
+		// then
+		require.NoError(t, err)
+		secretsRepository.AssertExpectations(t)
+	})
+
+	t.Run("should return error without saving key when failed to save cluster secret with key", func(t *testing.T) {
+		// given
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("Get", clusterCertSecretNamespaceName).Return(nil, k8serrors.NewNotFound(schema.GroupResource{}, clusterCertSecretName))
+		secretsRepository.On("UpsertWithMerge", clusterCertSecretNamespaceName, mock.Anything).Return(errors.New("error")).Once()
+
+		keyStore := keys.NewSecretKeyStore(secretsRepository, clusterCertSecretNamespaceName)
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
+
+		// when
+		err := credentialsManager.PreserveCredentials(credentials)
+
+		// then
+		require.Error(t, err)
+		secretsRepository.AssertExpectations(t)
+		secretsRepository.AssertNumberOfCalls(t, "UpsertWithMerge", 1)
+	})
+
+	t.Run("should return error when failed to save ca secret", func(t *testing.T) {
+		// given
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(nil, nil)
+		keyStore.On("SaveKey", credentials.ClientKey).Return(nil)
 
 		secretsRepository := &mocks.Repository{}
 		secretsRepository.On("UpsertWithMerge", clusterCertSecretNamespaceName, clusterSecretData).Return(nil)
 		secretsRepository.On("UpsertWithMerge", caCertSecretNamespaceName, caSecretData).Return(errors.New("error"))
 
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
 
 		// when
 		err := credentialsManager.PreserveCredentials(credentials)
@@ -152,6 +242,9 @@ func TestCertificateProvider_GetClientCredentials(t *testing.T) {
 		CACertificates:    caCRT,
 	}
 
+	key, err := ParsePrivateKey(clientKey)
+	require.NoError(t, err)
+
 	t.Run("should get client credentials", func(t *testing.T) {
 		// given
 		expectedCreds, err := pemCredentials.AsClientCredentials()
@@ -159,14 +252,16 @@ func TestCertificateProvider_GetClientCredentials(t *testing.T) {
 
 		secretData := map[string][]byte{
 			clusterCertificateSecretKey: clientCRT,
-			clusterKeySecretKey:         clientKey,
 			certificateChainSecretKey:   crtChain,
 		}
 
 		secretsRepository := &mocks.Repository{}
 		secretsRepository.On("Get", clusterCertSecretNamespaceName).Return(secretData, nil)
 
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(key, nil)
+
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
 
 		// when
 		clientCreds, err := credentialsManager.GetClientCredentials()
@@ -182,7 +277,7 @@ func TestCertificateProvider_GetClientCredentials(t *testing.T) {
 		secretsRepository := &mocks.Repository{}
 		secretsRepository.On("Get", clusterCertSecretNamespaceName).Return(nil, errors.New("error"))
 
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, &keysMocks.KeyStore{})
 
 		// when
 		_, err := credentialsManager.GetClientCredentials()
@@ -198,7 +293,10 @@ func TestCertificateProvider_GetClientCredentials(t *testing.T) {
 		secretsRepository := &mocks.Repository{}
 		secretsRepository.On("Get", clusterCertSecretNamespaceName).Return(secretData, nil)
 
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(key, nil)
+
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
 
 		// when
 		_, err := credentialsManager.GetClientCredentials()
@@ -211,14 +309,16 @@ func TestCertificateProvider_GetClientCredentials(t *testing.T) {
 		// given
 		secretData := map[string][]byte{
 			clusterCertificateSecretKey: []byte("invalid pem"),
-			clusterKeySecretKey:         clientKey,
 			certificateChainSecretKey:   crtChain,
 		}
 
 		secretsRepository := &mocks.Repository{}
 		secretsRepository.On("Get", clusterCertSecretNamespaceName).Return(secretData, nil)
 
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(key, nil)
+
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
 
 		// when
 		_, err := credentialsManager.GetClientCredentials()
@@ -231,14 +331,38 @@ func TestCertificateProvider_GetClientCredentials(t *testing.T) {
 		// given
 		secretData := map[string][]byte{
 			clusterCertificateSecretKey: clientCRT,
-			clusterKeySecretKey:         clientKey,
 			certificateChainSecretKey:   []byte("invalid pem"),
 		}
 
 		secretsRepository := &mocks.Repository{}
 		secretsRepository.On("Get", clusterCertSecretNamespaceName).Return(secretData, nil)
 
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(key, nil)
+
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
+
+		// when
+		_, err := credentialsManager.GetClientCredentials()
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("should return error when failed to read client key", func(t *testing.T) {
+		// given
+		secretData := map[string][]byte{
+			clusterCertificateSecretKey: clientCRT,
+			certificateChainSecretKey:   crtChain,
+		}
+
+		secretsRepository := &mocks.Repository{}
+		secretsRepository.On("Get", clusterCertSecretNamespaceName).Return(secretData, nil)
+
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(nil, errors.New("invalid pem"))
+
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
 
 		// when
 		_, err := credentialsManager.GetClientCredentials()
@@ -247,18 +371,20 @@ func TestCertificateProvider_GetClientCredentials(t *testing.T) {
 		require.Error(t, err)
 	})
 
-	t.Run("should return error when failed to decode client key", func(t *testing.T) {
+	t.Run("should return error when key store doesn't contain client key", func(t *testing.T) {
 		// given
 		secretData := map[string][]byte{
 			clusterCertificateSecretKey: clientCRT,
-			clusterKeySecretKey:         []byte("invalid pem"),
 			certificateChainSecretKey:   crtChain,
 		}
 
 		secretsRepository := &mocks.Repository{}
 		secretsRepository.On("Get", clusterCertSecretNamespaceName).Return(secretData, nil)
 
-		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository)
+		keyStore := &keysMocks.KeyStore{}
+		keyStore.On("Key").Return(nil, nil)
+
+		credentialsManager := NewCredentialsManager(clusterCertSecretNamespaceName, caCertSecretNamespaceName, secretsRepository, keyStore)
 
 		// when
 		_, err := credentialsManager.GetClientCredentials()
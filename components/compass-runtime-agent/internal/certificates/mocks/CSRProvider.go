@@ -7,7 +7,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
-	rsa "crypto/rsa"
+	crypto "crypto"
 )
 
 // CSRProvider is an autogenerated mock type for the CSRProvider type
@@ -16,7 +16,7 @@ type CSRProvider struct {
 }
 
 // CreateCSR provides a mock function with given fields: subject
-func (_m *CSRProvider) CreateCSR(subject pkix.Name) (string, *rsa.PrivateKey, error) {
+func (_m *CSRProvider) CreateCSR(subject pkix.Name) (string, crypto.Signer, error) {
 	ret := _m.Called(subject)
 
 	var r0 string
@@ -26,12 +26,12 @@ func (_m *CSRProvider) CreateCSR(subject pkix.Name) (string, *rsa.PrivateKey, er
 		r0 = ret.Get(0).(string)
 	}
 
-	var r1 *rsa.PrivateKey
-	if rf, ok := ret.Get(1).(func(pkix.Name) *rsa.PrivateKey); ok {
+	var r1 crypto.Signer
+	if rf, ok := ret.Get(1).(func(pkix.Name) crypto.Signer); ok {
 		r1 = rf(subject)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*rsa.PrivateKey)
+			r1 = ret.Get(1).(crypto.Signer)
 		}
 	}
 
@@ -1,7 +1,7 @@
 package certificates
 
 import (
-	"crypto/rsa"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
@@ -9,6 +9,8 @@ import (
 
 	gqlschema "github.com/kyma-incubator/compass/components/connector/pkg/graphql/externalschema"
 	"github.com/pkg/errors"
+
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys"
 )
 
 type Credentials struct {
@@ -17,12 +19,12 @@ type Credentials struct {
 }
 
 type ClientCredentials struct {
-	ClientKey         *rsa.PrivateKey
+	ClientKey         crypto.Signer
 	CertificateChain  []*x509.Certificate
 	ClientCertificate *x509.Certificate
 }
 
-func NewCredentials(key *rsa.PrivateKey, certificateResponse gqlschema.CertificationResult) (Credentials, error) {
+func NewCredentials(key crypto.Signer, certificateResponse gqlschema.CertificationResult) (Credentials, error) {
 	pemCertChain, err := base64.StdEncoding.DecodeString(certificateResponse.CertificateChain)
 	if err != nil {
 		return Credentials{}, errors.Wrap(err, "Failed to decode base 64 certificate chain")
@@ -58,17 +60,8 @@ func NewCredentials(key *rsa.PrivateKey, certificateResponse gqlschema.Certifica
 	}, nil
 }
 
-func ParsePrivateKey(clusterKey []byte) (*rsa.PrivateKey, error) {
-	if clusterKey == nil {
-		return nil, errors.New("Private key data is empty")
-	}
-
-	block, _ := pem.Decode(clusterKey)
-	if block == nil {
-		return nil, errors.New("Failed to decode client key pem")
-	}
-
-	return x509.ParsePKCS1PrivateKey(block.Bytes)
+func ParsePrivateKey(clusterKey []byte) (crypto.Signer, error) {
+	return keys.ParsePEM(clusterKey)
 }
 
 type PemEncodedCredentials struct {
@@ -91,9 +84,12 @@ func (c ClientCredentials) AsTLSCertificate() tls.Certificate {
 	}
 }
 
+// AsPemEncoded encodes the credentials. The client key is empty if the key can't be exported from the key store
 func (c Credentials) AsPemEncoded() PemEncodedCredentials {
+	clientKey, _ := keys.EncodePEM(c.ClientKey)
+
 	return PemEncodedCredentials{
-		ClientKey:         pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(c.ClientKey)}),
+		ClientKey:         clientKey,
 		CertificateChain:  toPem(c.CertificateChain...),
 		ClientCertificate: toPem(c.ClientCertificate),
 		CACertificates:    toPem(c.CACertificates...),
@@ -111,6 +107,16 @@ func toPem(certificates ...*x509.Certificate) []byte {
 }
 
 func (c PemEncodedCredentials) AsClientCredentials() (ClientCredentials, error) {
+	clientKey, err := ParsePrivateKey(c.ClientKey)
+	if err != nil {
+		return ClientCredentials{}, errors.Wrap(err, "Failed to decode client key")
+	}
+
+	return c.withClientKey(clientKey)
+}
+
+// withClientKey decodes the client certificate and the certificate chain, and returns them with the key
+func (c PemEncodedCredentials) withClientKey(clientKey crypto.Signer) (ClientCredentials, error) {
 	certificateChain, err := decodeCertificates(c.CertificateChain)
 	if err != nil {
 		return ClientCredentials{}, errors.Wrap(err, "Failed to decode certificate chain")
@@ -121,11 +127,6 @@ func (c PemEncodedCredentials) AsClientCredentials() (ClientCredentials, error)
 		return ClientCredentials{}, errors.Wrap(err, "Failed to decode client certificate")
 	}
 
-	clientKey, err := ParsePrivateKey(c.ClientKey)
-	if err != nil {
-		return ClientCredentials{}, errors.Wrap(err, "Failed to decode client key")
-	}
-
 	return ClientCredentials{
 		ClientKey:         clientKey,
 		CertificateChain:  certificateChain,
@@ -32,6 +32,7 @@ func NewClientsProvider(gqlClientConstr graphql.ClientConstructor, skipCompassTL
 		gqlClientConstructor:       gqlClientConstr,
 		skipCompassTLSVerification: skipCompassTLSVerification,
 		enableLogging:              enableLogging,
+		applicationsCache:          director.NewApplicationsCache(),
 
 		httpClient: &http.Client{
 			Timeout:   30 * time.Second,
@@ -45,6 +46,7 @@ type clientsProvider struct {
 	skipCompassTLSVerification bool
 	enableLogging              bool
 	httpClient                 *http.Client
+	applicationsCache          *director.ApplicationsCache
 
 	// lazy init after establishing connection
 	mtlsHTTPClient      *http.Client
@@ -79,7 +81,7 @@ func (cp *clientsProvider) GetDirectorClient(runtimeConfig config.RuntimeConfig)
 		return nil, errors.Wrap(err, "Failed to create GraphQL client")
 	}
 
-	return director.NewConfigurationClient(gqlClient, runtimeConfig), nil
+	return director.NewCachingConfigurationClient(gqlClient, runtimeConfig, cp.applicationsCache), nil
 }
 
 func (cp *clientsProvider) GetConnectorTokensClient(url string) (connector.Client, error) {
@@ -0,0 +1,43 @@
+package director
+
+import (
+	"sync"
+
+	kymamodel "github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma/model"
+)
+
+// ApplicationsCache keeps the Applications fetched from the Director between synchronizations,
+// so that only the Applications which changed since the last synchronization are fetched again
+type ApplicationsCache struct {
+	mutex        sync.Mutex
+	applications map[string]cachedApplication
+}
+
+type cachedApplication struct {
+	revision    string
+	application kymamodel.Application
+}
+
+func NewApplicationsCache() *ApplicationsCache {
+	return &ApplicationsCache{applications: map[string]cachedApplication{}}
+}
+
+// get returns the cached Application if it has the given revision
+func (c *ApplicationsCache) get(id, revision string) (kymamodel.Application, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cached, found := c.applications[id]
+	if !found || revision == "" || cached.revision != revision {
+		return kymamodel.Application{}, false
+	}
+	return cached.application, true
+}
+
+// replace replaces the cached Applications, so that the Applications removed from the Director are forgotten
+func (c *ApplicationsCache) replace(applications map[string]cachedApplication) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.applications = applications
+}
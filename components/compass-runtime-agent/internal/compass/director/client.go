@@ -2,11 +2,13 @@ package director
 
 import (
 	"context"
+	"time"
 
 	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/config"
 	gql "github.com/kyma-project/kyma/components/compass-runtime-agent/internal/graphql"
 	kymamodel "github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma/model"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/metrics"
 	gcli "github.com/machinebox/graphql"
 	"github.com/pkg/errors"
 )
@@ -30,27 +32,41 @@ type DirectorClient interface {
 }
 
 func NewConfigurationClient(gqlClient gql.Client, runtimeConfig config.RuntimeConfig) DirectorClient {
+	return NewCachingConfigurationClient(gqlClient, runtimeConfig, nil)
+}
+
+// NewCachingConfigurationClient creates the client which fetches only the Applications
+// which changed since they were stored in the cache. All Applications are fetched if the cache is nil
+func NewCachingConfigurationClient(gqlClient gql.Client, runtimeConfig config.RuntimeConfig, applicationsCache *ApplicationsCache) DirectorClient {
 	return &directorClient{
-		gqlClient:     gqlClient,
-		queryProvider: queryProvider{},
-		runtimeConfig: runtimeConfig,
+		gqlClient:         gqlClient,
+		queryProvider:     queryProvider{},
+		runtimeConfig:     runtimeConfig,
+		applicationsCache: applicationsCache,
 	}
 }
 
 type directorClient struct {
-	gqlClient     gql.Client
-	queryProvider queryProvider
-	runtimeConfig config.RuntimeConfig
+	gqlClient         gql.Client
+	queryProvider     queryProvider
+	runtimeConfig     config.RuntimeConfig
+	applicationsCache *ApplicationsCache
 }
 
 func (cc *directorClient) FetchConfiguration(ctx context.Context) ([]kymamodel.Application, graphql.Labels, error) {
+	if cc.applicationsCache != nil {
+		return cc.fetchChangedConfiguration(ctx)
+	}
+
 	response := ApplicationsAndLabelsForRuntimeResponse{}
 
 	appsAndLabelsForRuntimeQuery := cc.queryProvider.applicationsAndLabelsForRuntimeQuery(cc.runtimeConfig.RuntimeId)
 	req := gcli.NewRequest(appsAndLabelsForRuntimeQuery)
 	req.Header.Set(TenantHeader, cc.runtimeConfig.Tenant)
 
+	start := time.Now()
 	err := cc.gqlClient.Do(ctx, req, &response)
+	metrics.ObserveDirectorRequest(metrics.DirectorOperationFetchConfiguration, start, err)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Failed to fetch Applications and Labels")
 	}
@@ -70,6 +86,82 @@ func (cc *directorClient) FetchConfiguration(ctx context.Context) ([]kymamodel.A
 	return applications, response.Runtime.Labels, nil
 }
 
+// fetchChangedConfiguration fetches the revisions of the Applications first
+// and then only the Applications which aren't cached with the same revision
+func (cc *directorClient) fetchChangedConfiguration(ctx context.Context) ([]kymamodel.Application, graphql.Labels, error) {
+	response := ApplicationRevisionsAndLabelsForRuntimeResponse{}
+
+	req := gcli.NewRequest(cc.queryProvider.applicationRevisionsAndLabelsForRuntimeQuery(cc.runtimeConfig.RuntimeId))
+	req.Header.Set(TenantHeader, cc.runtimeConfig.Tenant)
+
+	start := time.Now()
+	err := cc.gqlClient.Do(ctx, req, &response)
+	metrics.ObserveDirectorRequest(metrics.DirectorOperationFetchRevisions, start, err)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Failed to fetch Application revisions and Labels")
+	}
+
+	// Nil check is necessary due to GraphQL client not checking response code
+	if response.Runtime == nil || response.ApplicationsPage == nil {
+		return nil, nil, errors.Errorf("Failed fetch Application revisions or Labels for Runtime from Director: received nil response.")
+	}
+
+	applications := make([]kymamodel.Application, 0, len(response.ApplicationsPage.Data))
+	cached := make(map[string]cachedApplication, len(response.ApplicationsPage.Data))
+	changedIDs := make([]string, 0)
+	revisions := make(map[string]string, len(response.ApplicationsPage.Data))
+	for _, app := range response.ApplicationsPage.Data {
+		revision := app.Revision()
+		if application, found := cc.applicationsCache.get(app.ID, revision); found {
+			applications = append(applications, application)
+			cached[app.ID] = cachedApplication{revision: revision, application: application}
+			continue
+		}
+		changedIDs = append(changedIDs, app.ID)
+		revisions[app.ID] = revision
+	}
+
+	changedApplications, err := cc.fetchApplications(ctx, changedIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, application := range changedApplications {
+		applications = append(applications, application)
+		cached[application.ID] = cachedApplication{revision: revisions[application.ID], application: application}
+	}
+	cc.applicationsCache.replace(cached)
+
+	return applications, response.Runtime.Labels, nil
+}
+
+func (cc *directorClient) fetchApplications(ctx context.Context, applicationIDs []string) ([]kymamodel.Application, error) {
+	if len(applicationIDs) == 0 {
+		return nil, nil
+	}
+
+	response := ApplicationsResponse{}
+
+	req := gcli.NewRequest(cc.queryProvider.applicationsQuery(applicationIDs))
+	req.Header.Set(TenantHeader, cc.runtimeConfig.Tenant)
+
+	start := time.Now()
+	err := cc.gqlClient.Do(ctx, req, &response)
+	metrics.ObserveDirectorRequest(metrics.DirectorOperationFetchApplications, start, err)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch Applications")
+	}
+
+	applications := make([]kymamodel.Application, 0, len(applicationIDs))
+	for i := range applicationIDs {
+		// the Application removed after its revision was fetched is synchronized next time
+		if app := response[applicationAlias(i)]; app != nil {
+			applications = append(applications, app.ToApplication())
+		}
+	}
+
+	return applications, nil
+}
+
 func (cc *directorClient) SetURLsLabels(ctx context.Context, urlsCfg RuntimeURLsConfig, currentLabels graphql.Labels) (graphql.Labels, error) {
 	targetLabels := map[string]string{
 		eventsURLLabelKey:  urlsCfg.EventsURL,
@@ -98,7 +190,9 @@ func (cc *directorClient) setURLLabel(ctx context.Context, key, value string) (*
 	req := gcli.NewRequest(setLabelQuery)
 	req.Header.Set(TenantHeader, cc.runtimeConfig.Tenant)
 
+	start := time.Now()
 	err := cc.gqlClient.Do(ctx, req, &response)
+	metrics.ObserveDirectorRequest(metrics.DirectorOperationSetRuntimeLabel, start, err)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "Failed to set %s Runtime label to value %s", key, value)
 	}
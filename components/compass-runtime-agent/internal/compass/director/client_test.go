@@ -2,6 +2,7 @@ package director
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
@@ -311,6 +312,111 @@ func TestConfigClient_FetchConfiguration(t *testing.T) {
 	})
 }
 
+func TestConfigClient_FetchChangedConfiguration(t *testing.T) {
+	revision := func(id, updatedAt string) *ApplicationRevision {
+		return &ApplicationRevision{
+			ID:        id,
+			UpdatedAt: &updatedAt,
+			Bundles:   &BundleRevisionPage{Data: []*BundleRevision{{ID: id + "-bundle", UpdatedAt: &updatedAt}}},
+		}
+	}
+
+	isRevisionsQuery := mock.MatchedBy(func(req *gcli.Request) bool {
+		return strings.Contains(req.Query(), "applicationsForRuntime") && req.Header.Get(TenantHeader) == tenant
+	})
+	isApplicationsQuery := func(ids ...string) interface{} {
+		return mock.MatchedBy(func(req *gcli.Request) bool {
+			return req.Query() == queryProvider{}.applicationsQuery(ids) && req.Header.Get(TenantHeader) == tenant
+		})
+	}
+	setRevisions := func(revisions ...*ApplicationRevision) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			response, ok := args[2].(*ApplicationRevisionsAndLabelsForRuntimeResponse)
+			require.True(t, ok)
+			response.Runtime = &Runtime{Labels: graphql.Labels{eventsURLLabelKey: "eventsURL"}}
+			response.ApplicationsPage = &ApplicationRevisionPage{Data: revisions, TotalCount: len(revisions)}
+		}
+	}
+	setApplications := func(applications ...*Application) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			response, ok := args[2].(*ApplicationsResponse)
+			require.True(t, ok)
+			*response = ApplicationsResponse{}
+			for i, app := range applications {
+				(*response)[applicationAlias(i)] = app
+			}
+		}
+	}
+
+	t.Run("should fetch only Applications changed since the last synchronization", func(t *testing.T) {
+		// given
+		client := &mocks.Client{}
+		client.On("Do", context.Background(), isRevisionsQuery, &ApplicationRevisionsAndLabelsForRuntimeResponse{}).
+			Return(nil).Run(setRevisions(revision("app1", "t1"), revision("app2", "t1"))).Once()
+		client.On("Do", context.Background(), isApplicationsQuery("app1", "app2"), &ApplicationsResponse{}).
+			Return(nil).Run(setApplications(&Application{ID: "app1", Name: "App1"}, &Application{ID: "app2", Name: "App2"})).Once()
+		client.On("Do", context.Background(), isRevisionsQuery, &ApplicationRevisionsAndLabelsForRuntimeResponse{}).
+			Return(nil).Run(setRevisions(revision("app1", "t1"), revision("app2", "t2"))).Once()
+		client.On("Do", context.Background(), isApplicationsQuery("app2"), &ApplicationsResponse{}).
+			Return(nil).Run(setApplications(&Application{ID: "app2", Name: "App2", Description: stringPtr("changed")})).Once()
+
+		configClient := NewCachingConfigurationClient(client, runtimeConfig, NewApplicationsCache())
+
+		// when
+		firstApplications, labels, err := configClient.FetchConfiguration(context.Background())
+		require.NoError(t, err)
+		secondApplications, _, err := configClient.FetchConfiguration(context.Background())
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, graphql.Labels{eventsURLLabelKey: "eventsURL"}, labels)
+		require.Len(t, firstApplications, 2)
+		require.Len(t, secondApplications, 2)
+		assert.Equal(t, firstApplications[0], secondApplications[0])
+		assert.Equal(t, "app2", secondApplications[1].ID)
+		assert.Equal(t, "changed", secondApplications[1].Description)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("should fetch Applications without known revision every time", func(t *testing.T) {
+		// given
+		client := &mocks.Client{}
+		client.On("Do", context.Background(), isRevisionsQuery, &ApplicationRevisionsAndLabelsForRuntimeResponse{}).
+			Return(nil).Run(setRevisions(&ApplicationRevision{ID: "app1"})).Twice()
+		client.On("Do", context.Background(), isApplicationsQuery("app1"), &ApplicationsResponse{}).
+			Return(nil).Run(setApplications(&Application{ID: "app1", Name: "App1"})).Twice()
+
+		configClient := NewCachingConfigurationClient(client, runtimeConfig, NewApplicationsCache())
+
+		// when
+		for i := 0; i < 2; i++ {
+			applications, _, err := configClient.FetchConfiguration(context.Background())
+			require.NoError(t, err)
+			require.Len(t, applications, 1)
+		}
+
+		// then
+		client.AssertExpectations(t)
+	})
+
+	t.Run("should not fetch Applications if none changed", func(t *testing.T) {
+		// given
+		client := &mocks.Client{}
+		client.On("Do", context.Background(), isRevisionsQuery, &ApplicationRevisionsAndLabelsForRuntimeResponse{}).
+			Return(nil).Run(setRevisions()).Once()
+
+		configClient := NewCachingConfigurationClient(client, runtimeConfig, NewApplicationsCache())
+
+		// when
+		applications, _, err := configClient.FetchConfiguration(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, applications)
+		client.AssertExpectations(t)
+	})
+}
+
 func TestConfigClient_SetURLsLabels(t *testing.T) {
 	runtimeURLsConfig := RuntimeURLsConfig{
 		EventsURL:  "https://gateway.kyma.local",
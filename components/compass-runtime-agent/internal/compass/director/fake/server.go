@@ -0,0 +1,113 @@
+// Package fake contains the Director stand-in which serves the Runtime configuration from a local payload
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var setRuntimeLabelPattern = regexp.MustCompile(`setRuntimeLabel\(runtimeID: "[^"]*", key: "([^"]*)", value: "([^"]*)"\)`)
+
+type graphQLRequest struct {
+	Query string `json:"query"`
+}
+
+type graphQLResponse struct {
+	Data   interface{}    `json:"data,omitempty"`
+	Errors []graphQLError `json:"errors,omitempty"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type label struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Director answers the queries of the Runtime Agent with the payload.
+// The payload has the format of the data returned by the Director for the applicationsForRuntime and runtime query.
+// The setRuntimeLabel mutations are accepted but the labels aren't stored
+type Director struct {
+	payload json.RawMessage
+}
+
+// NewDirector creates the Director stand-in serving the payload
+func NewDirector(payload []byte) (*Director, error) {
+	var data struct {
+		Runtime          json.RawMessage `json:"runtime"`
+		ApplicationsPage json.RawMessage `json:"applicationsForRuntime"`
+	}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, errors.Wrap(err, "Failed to parse Director payload")
+	}
+	if data.Runtime == nil || data.ApplicationsPage == nil {
+		return nil, errors.New("Director payload must contain runtime and applicationsForRuntime fields")
+	}
+
+	return &Director{payload: payload}, nil
+}
+
+// NewDirectorFromFile creates the Director stand-in serving the payload read from the file
+func NewDirectorFromFile(path string) (*Director, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read Director payload from %s", path)
+	}
+
+	return NewDirector(payload)
+}
+
+func (d *Director) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request, err := readRequest(r)
+	if err != nil {
+		logrus.Warnf("Fake Director received invalid request: %s", err)
+		respond(w, http.StatusBadRequest, graphQLResponse{Errors: []graphQLError{{Message: err.Error()}}})
+		return
+	}
+
+	if strings.Contains(request.Query, "setRuntimeLabel") {
+		matches := setRuntimeLabelPattern.FindStringSubmatch(request.Query)
+		if matches == nil {
+			respond(w, http.StatusOK, graphQLResponse{Errors: []graphQLError{{Message: "invalid setRuntimeLabel mutation"}}})
+			return
+		}
+		respond(w, http.StatusOK, graphQLResponse{Data: map[string]label{"setRuntimeLabel": {Key: matches[1], Value: matches[2]}}})
+		return
+	}
+
+	respond(w, http.StatusOK, graphQLResponse{Data: d.payload})
+}
+
+func readRequest(r *http.Request) (graphQLRequest, error) {
+	var request graphQLRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		request.Query = r.FormValue("query")
+		return request, nil
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return request, fmt.Errorf("failed to decode GraphQL request: %s", err)
+	}
+
+	return request, nil
+}
+
+func respond(w http.ResponseWriter, statusCode int, response graphQLResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logrus.Errorf("Failed to write fake Director response: %s", err)
+	}
+}
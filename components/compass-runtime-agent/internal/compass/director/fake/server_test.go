@@ -0,0 +1,103 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payload = `{
+	"runtime": {"labels": {"runtime_consoleUrl": "https://console.kyma.local"}},
+	"applicationsForRuntime": {"data": [{"id": "abcd", "name": "commerce"}], "totalCount": 1}
+}`
+
+func TestDirector(t *testing.T) {
+	director, err := NewDirector([]byte(payload))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(director)
+	defer server.Close()
+
+	t.Run("should return payload", func(t *testing.T) {
+		// given
+		var response struct {
+			Runtime struct {
+				Labels map[string]interface{} `json:"labels"`
+			} `json:"runtime"`
+			ApplicationsPage struct {
+				Data []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"data"`
+			} `json:"applicationsForRuntime"`
+		}
+
+		// when
+		err := query(server.URL, `query { runtime(id: "1") { labels } }`, &response)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "https://console.kyma.local", response.Runtime.Labels["runtime_consoleUrl"])
+		require.Len(t, response.ApplicationsPage.Data, 1)
+		assert.Equal(t, "commerce", response.ApplicationsPage.Data[0].Name)
+	})
+
+	t.Run("should accept setRuntimeLabel mutation", func(t *testing.T) {
+		// given
+		var response struct {
+			Result struct {
+				Key   string `json:"key"`
+				Value string `json:"value"`
+			} `json:"setRuntimeLabel"`
+		}
+		mutation := `mutation { setRuntimeLabel(runtimeID: "1", key: "runtime_eventServiceUrl", value: "https://gateway.kyma.local") { key value } }`
+
+		// when
+		err := query(server.URL, mutation, &response)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "runtime_eventServiceUrl", response.Result.Key)
+		assert.Equal(t, "https://gateway.kyma.local", response.Result.Value)
+	})
+}
+
+func TestNewDirector(t *testing.T) {
+	t.Run("should fail when payload is not JSON", func(t *testing.T) {
+		_, err := NewDirector([]byte("applications"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail when payload doesn't contain applications", func(t *testing.T) {
+		_, err := NewDirector([]byte(`{"runtime": {"labels": {}}}`))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail when file doesn't exist", func(t *testing.T) {
+		_, err := NewDirectorFromFile("not-existing.json")
+
+		assert.Error(t, err)
+	})
+}
+
+func query(url, query string, data interface{}) error {
+	body, err := json.Marshal(graphQLRequest{Query: query})
+	if err != nil {
+		return err
+	}
+
+	response, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return json.NewDecoder(response.Body).Decode(&graphQLResponse{Data: data})
+}
@@ -1,6 +1,9 @@
 package director
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/kyma-incubator/compass/components/director/pkg/graphql"
 )
 
@@ -9,6 +12,14 @@ type ApplicationsAndLabelsForRuntimeResponse struct {
 	ApplicationsPage *ApplicationPage `json:"applicationsForRuntime"`
 }
 
+type ApplicationRevisionsAndLabelsForRuntimeResponse struct {
+	Runtime          *Runtime                 `json:"runtime"`
+	ApplicationsPage *ApplicationRevisionPage `json:"applicationsForRuntime"`
+}
+
+// ApplicationsResponse contains the Applications queried by their IDs under the aliases of the query
+type ApplicationsResponse map[string]*Application
+
 type SetRuntimeLabelResponse struct {
 	Result *graphql.Label `json:"setRuntimeLabel"`
 }
@@ -32,3 +43,79 @@ type Application struct {
 type Runtime struct {
 	Labels map[string]interface{} `json:"labels"`
 }
+
+type ApplicationRevisionPage struct {
+	Data       []*ApplicationRevision `json:"data"`
+	PageInfo   *graphql.PageInfo      `json:"pageInfo"`
+	TotalCount int                    `json:"totalCount"`
+}
+
+// ApplicationRevision contains the modification times of the Application and of its bundles and definitions
+type ApplicationRevision struct {
+	ID        string              `json:"id"`
+	UpdatedAt *string             `json:"updatedAt"`
+	Bundles   *BundleRevisionPage `json:"bundles"`
+}
+
+type BundleRevisionPage struct {
+	Data []*BundleRevision `json:"data"`
+}
+
+type BundleRevision struct {
+	ID               string              `json:"id"`
+	UpdatedAt        *string             `json:"updatedAt"`
+	APIDefinitions   *EntityRevisionPage `json:"apiDefinitions"`
+	EventDefinitions *EntityRevisionPage `json:"eventDefinitions"`
+}
+
+type EntityRevisionPage struct {
+	Data []*EntityRevision `json:"data"`
+}
+
+type EntityRevision struct {
+	ID        string  `json:"id"`
+	UpdatedAt *string `json:"updatedAt"`
+}
+
+// Revision returns the modification times of the Application, its bundles and definitions,
+// or an empty string if any of them is unknown
+func (r ApplicationRevision) Revision() string {
+	revision := &strings.Builder{}
+	if !writeRevision(revision, "application", r.ID, r.UpdatedAt) {
+		return ""
+	}
+	if r.Bundles == nil {
+		return revision.String()
+	}
+
+	for _, bundle := range r.Bundles.Data {
+		if bundle == nil || !writeRevision(revision, "bundle", bundle.ID, bundle.UpdatedAt) {
+			return ""
+		}
+		if !writeDefinitionRevisions(revision, "api", bundle.APIDefinitions) ||
+			!writeDefinitionRevisions(revision, "event", bundle.EventDefinitions) {
+			return ""
+		}
+	}
+	return revision.String()
+}
+
+func writeDefinitionRevisions(revision *strings.Builder, kind string, definitions *EntityRevisionPage) bool {
+	if definitions == nil {
+		return true
+	}
+	for _, definition := range definitions.Data {
+		if definition == nil || !writeRevision(revision, kind, definition.ID, definition.UpdatedAt) {
+			return false
+		}
+	}
+	return true
+}
+
+func writeRevision(revision *strings.Builder, kind, id string, updatedAt *string) bool {
+	if updatedAt == nil || *updatedAt == "" {
+		return false
+	}
+	fmt.Fprintf(revision, "%s/%s@%s;", kind, id, *updatedAt)
+	return true
+}
@@ -15,6 +15,33 @@ func (qp queryProvider) applicationsAndLabelsForRuntimeQuery(runtimeID string) s
 	}`, runtimeID, labels(), runtimeID, applicationsQueryData())
 }
 
+func (qp queryProvider) applicationRevisionsAndLabelsForRuntimeQuery(runtimeID string) string {
+	return fmt.Sprintf(`query {
+		runtime(id: "%s") {
+			%s
+		}
+		applicationsForRuntime(runtimeID: "%s") {
+			%s
+		}
+	}`, runtimeID, labels(), runtimeID, pageData(applicationRevisionData()))
+}
+
+func (qp queryProvider) applicationsQuery(applicationIDs []string) string {
+	query := "query {"
+	for i, id := range applicationIDs {
+		query += fmt.Sprintf(`
+		%s: application(id: "%s") {
+			%s
+		}`, applicationAlias(i), id, applicationData())
+	}
+	return query + `
+	}`
+}
+
+func applicationAlias(i int) string {
+	return fmt.Sprintf("app%d", i)
+}
+
 func (qp queryProvider) setRuntimeLabelMutation(runtimeId, key, value string) string {
 	return fmt.Sprintf(`mutation {
 		setRuntimeLabel(runtimeID: "%s", key: "%s", value: "%s") {
@@ -62,6 +89,22 @@ func applicationData() string {
 	`, systemAuthData(), pageData(bundlesData()))
 }
 
+func applicationRevisionData() string {
+	return fmt.Sprintf(`id
+		updatedAt
+		bundles {%s}
+	`, pageData(fmt.Sprintf(`id
+		updatedAt
+		apiDefinitions {%s}
+		eventDefinitions {%s}
+		`, pageData(revisionData()), pageData(revisionData()))))
+}
+
+func revisionData() string {
+	return `id
+		updatedAt`
+}
+
 func systemAuthData() string {
 	return fmt.Sprintf(`id`)
 }
@@ -26,6 +26,7 @@ type DependencyConfig struct {
 
 	ClientsProvider        compass.ClientsProvider
 	CredentialsManager     certificates.Manager
+	CSRProvider            certificates.CSRProvider
 	SynchronizationService kyma.Service
 	ConfigProvider         config.Provider
 	ConnectionDataCache    cache.ConnectionDataCache
@@ -41,8 +42,7 @@ func (config DependencyConfig) InitializeController() (Supervisor, error) {
 		return nil, errors.Wrap(err, "Unable to setup Compass Connection CR client")
 	}
 
-	csrProvider := certificates.NewCSRProvider()
-	compassConnector := NewCompassConnector(csrProvider, config.ClientsProvider)
+	compassConnector := NewCompassConnector(config.CSRProvider, config.ClientsProvider)
 
 	connectionSupervisor := NewSupervisor(
 		compassConnector,
@@ -1,7 +1,7 @@
 package compassconnection
 
 import (
-	"crypto/rsa"
+	"crypto"
 	"encoding/base64"
 	"os"
 	"path/filepath"
@@ -34,7 +34,7 @@ var (
 	crtChain    []byte
 	clientCRT   []byte
 	caCRT       []byte
-	clientKey   *rsa.PrivateKey
+	clientKey   crypto.Signer
 	credentials certificates.Credentials
 )
 
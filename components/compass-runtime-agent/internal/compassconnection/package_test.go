@@ -3,6 +3,7 @@ package compassconnection
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/apperrors"
 
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates/keys"
 
 	"github.com/stretchr/testify/assert"
 
@@ -171,6 +173,7 @@ func TestCompassConnectionController(t *testing.T) {
 
 		ClientsProvider:              clientsProviderMock,
 		CredentialsManager:           credentialsManagerMock,
+		CSRProvider:                  certificates.NewCSRProvider(keys.NewFileKeyStore(filepath.Join(t.TempDir(), "key.pem")), keys.RSA4096, true),
 		SynchronizationService:       synchronizationServiceMock,
 		ConfigProvider:               configProviderMock,
 		CertValidityRenewalThreshold: 0.3,
@@ -204,6 +207,7 @@ func TestCompassConnectionController(t *testing.T) {
 		assertCompassConnectionState(t, v1alpha1.Synchronized)
 		assertConnectionStatusSet(t)
 		assertManagementInfoSetInCR(t)
+		assertApplicationResultsSet(t)
 
 		mock.AssertExpectationsForObjects(t,
 			tokensConnectorClientMock,
@@ -459,6 +463,7 @@ func TestFailedToInitializeConnection(t *testing.T) {
 
 		ClientsProvider:              clientsProviderMock,
 		CredentialsManager:           credentialsManagerMock,
+		CSRProvider:                  certificates.NewCSRProvider(keys.NewFileKeyStore(filepath.Join(t.TempDir(), "key.pem")), keys.RSA4096, true),
 		SynchronizationService:       nil,
 		ConfigProvider:               configProviderMock,
 		CertValidityRenewalThreshold: 0.3,
@@ -639,6 +644,15 @@ func assertSynchronizationStatusError(t *testing.T) {
 	assert.NotEmpty(t, connectedConnection.Status.SynchronizationStatus.Error)
 }
 
+func assertApplicationResultsSet(t *testing.T) {
+	connectedConnection, err := compassConnectionCRClient.Get(context.Background(), compassConnectionName, v1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, connectedConnection.Status.SynchronizationStatus)
+	assert.Equal(t, []v1alpha1.ApplicationSynchronizationResult{
+		{ApplicationName: "App-1", ApplicationID: "abcd-efgh", Result: v1alpha1.ApplicationCreated},
+	}, connectedConnection.Status.SynchronizationStatus.Applications)
+}
+
 func assertManagementInfoSetInCR(t *testing.T) {
 	connectedConnection, err := compassConnectionCRClient.Get(context.Background(), compassConnectionName, v1.GetOptions{})
 	require.NoError(t, err)
@@ -15,6 +15,7 @@ import (
 
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/certificates"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/metrics"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/pkg/apis/compass/v1alpha1"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -139,6 +140,11 @@ func (s *crSupervisor) MaintainCompassConnection(ctx context.Context, connection
 func (s *crSupervisor) SynchronizeWithCompass(ctx context.Context, connection *v1alpha1.CompassConnection) (*v1alpha1.CompassConnection, error) {
 	s.log = s.log.WithField("CompassConnection", connection.Name)
 
+	start := time.Now()
+	defer func() {
+		metrics.SynchronizationDuration.Observe(time.Since(start).Seconds())
+	}()
+
 	s.log.Infof("Reading configuration required to fetch Runtime configuration...")
 	runtimeConfig, err := s.configProvider.GetRuntimeConfig()
 	if err != nil {
@@ -175,10 +181,10 @@ func (s *crSupervisor) SynchronizeWithCompass(ctx context.Context, connection *v
 		return s.updateCompassConnection(connection)
 	}
 
-	// TODO: save result to CR and possibly log in better manner
+	applicationResults := toApplicationResults(results)
 	s.log.Infof("Config application results: ")
-	for _, res := range results {
-		s.log.Info(res)
+	for _, res := range applicationResults {
+		s.log.Infof("Application %s (%s): %s %s", res.ApplicationName, res.ApplicationID, res.Result, res.Error)
 	}
 
 	s.log.Infof("Labeling Runtime with URLs...")
@@ -190,12 +196,13 @@ func (s *crSupervisor) SynchronizeWithCompass(ctx context.Context, connection *v
 			LastAttempt:         syncAttemptTime,
 			LastSuccessfulFetch: syncAttemptTime,
 			Error:               fmt.Sprintf("Failed to reconcile Runtime labels with proper URLs: %s", err.Error()),
+			Applications:        applicationResults,
 		}
 		return s.updateCompassConnection(connection)
 	}
 
 	// TODO: decide the approach of setting this status. Should it be success even if one App failed?
-	s.setConnectionSynchronizedStatus(connection, metav1.Now())
+	s.setConnectionSynchronizedStatus(connection, metav1.Now(), applicationResults)
 	connection.Spec.ResyncNow = false
 
 	return s.updateCompassConnection(connection)
@@ -266,6 +273,7 @@ func (s *crSupervisor) newCompassConnection(ctx context.Context) (*v1alpha1.Comp
 	}
 
 	s.establishConnection(ctx, connectionCR)
+	recordConnectionMetrics(connectionCR)
 
 	return s.crManager.Create(context.Background(), connectionCR, v1.CreateOptions{})
 }
@@ -321,13 +329,14 @@ func (s *crSupervisor) setConnectionFailedStatus(connectionCR *v1alpha1.CompassC
 	connectionCR.Status.ConnectionStatus.Error = connStatusError
 }
 
-func (s *crSupervisor) setConnectionSynchronizedStatus(connectionCR *v1alpha1.CompassConnection, attemptTime metav1.Time) {
+func (s *crSupervisor) setConnectionSynchronizedStatus(connectionCR *v1alpha1.CompassConnection, attemptTime metav1.Time, applicationResults []v1alpha1.ApplicationSynchronizationResult) {
 	s.log.Infof("Setting Compass Connection to Synchronized state")
 	connectionCR.Status.State = v1alpha1.Synchronized
 	connectionCR.Status.SynchronizationStatus = &v1alpha1.SynchronizationStatus{
 		LastAttempt:               attemptTime,
 		LastSuccessfulFetch:       attemptTime,
 		LastSuccessfulApplication: attemptTime,
+		Applications:              applicationResults,
 	}
 }
 
@@ -344,6 +353,7 @@ func (s *crSupervisor) setConnectionMaintenanceFailedStatus(connectionCR *v1alph
 
 func (s *crSupervisor) updateCompassConnection(connectionCR *v1alpha1.CompassConnection) (*v1alpha1.CompassConnection, error) {
 	// TODO: with retries
+	recordConnectionMetrics(connectionCR)
 
 	return s.crManager.Update(context.Background(), connectionCR, v1.UpdateOptions{})
 }
@@ -358,3 +368,38 @@ func (s *crSupervisor) setSyncFailedStatus(connectionCR *v1alpha1.CompassConnect
 	connectionCR.Status.SynchronizationStatus.LastAttempt = attemptTime
 	connectionCR.Status.SynchronizationStatus.Error = errorMsg
 }
+
+func recordConnectionMetrics(connectionCR *v1alpha1.CompassConnection) {
+	metrics.SetConnectionState(string(connectionCR.Status.State))
+
+	if connectionCR.Status.ConnectionStatus != nil && !connectionCR.Status.ConnectionStatus.CertificateStatus.NotAfter.IsZero() {
+		metrics.SetCertificateExpiration(connectionCR.Status.ConnectionStatus.CertificateStatus.NotAfter.Time)
+	}
+}
+
+func toApplicationResults(results []kyma.Result) []v1alpha1.ApplicationSynchronizationResult {
+	applicationResults := make([]v1alpha1.ApplicationSynchronizationResult, 0, len(results))
+
+	for _, result := range results {
+		applicationResult := v1alpha1.ApplicationSynchronizationResult{
+			ApplicationName: result.ApplicationName,
+			ApplicationID:   result.ApplicationID,
+		}
+
+		switch {
+		case result.Error != nil:
+			applicationResult.Result = v1alpha1.ApplicationFailed
+			applicationResult.Error = fmt.Sprintf("Failed to %s Application: %s", result.Operation, result.Error.Error())
+		case result.Operation == kyma.Create:
+			applicationResult.Result = v1alpha1.ApplicationCreated
+		case result.Operation == kyma.Update:
+			applicationResult.Result = v1alpha1.ApplicationUpdated
+		case result.Operation == kyma.Delete:
+			applicationResult.Result = v1alpha1.ApplicationDeleted
+		}
+
+		applicationResults = append(applicationResults, applicationResult)
+	}
+
+	return applicationResults
+}
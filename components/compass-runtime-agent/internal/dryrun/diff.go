@@ -0,0 +1,128 @@
+package dryrun
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const noFile = "/dev/null"
+
+// Diff returns the unified diff of the Applications and the Secrets which differ between the states.
+// The Secret values are replaced with their hashes unless showSecretData is set
+func Diff(before, after State, showSecretData bool) (string, error) {
+	beforeObjects, err := printObjects(before, showSecretData)
+	if err != nil {
+		return "", err
+	}
+
+	afterObjects, err := printObjects(after, showSecretData)
+	if err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(beforeObjects)+len(afterObjects))
+	for key := range beforeObjects {
+		keys = append(keys, key)
+	}
+	for key := range afterObjects {
+		if _, found := beforeObjects[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var diff strings.Builder
+	for _, key := range keys {
+		beforeObject, afterObject := beforeObjects[key], afterObjects[key]
+		if beforeObject == afterObject {
+			continue
+		}
+
+		unifiedDiff := difflib.UnifiedDiff{
+			A:        difflib.SplitLines(beforeObject),
+			B:        difflib.SplitLines(afterObject),
+			FromFile: fileName("a", key, beforeObject),
+			ToFile:   fileName("b", key, afterObject),
+			Context:  3,
+		}
+		if err := difflib.WriteUnifiedDiff(&diff, unifiedDiff); err != nil {
+			return "", errors.Wrapf(err, "Failed to compare %s", key)
+		}
+	}
+
+	return diff.String(), nil
+}
+
+func fileName(prefix, key, object string) string {
+	if object == "" {
+		return noFile
+	}
+	return prefix + "/" + key
+}
+
+func printObjects(state State, showSecretData bool) (map[string]string, error) {
+	objects := make(map[string]string, len(state.Applications)+len(state.Secrets))
+
+	for _, application := range state.Applications {
+		application := application.DeepCopy()
+		clearServerFields(&application.ObjectMeta)
+
+		key := "Application/" + application.Name
+		printed, err := yaml.Marshal(application)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to print %s", key)
+		}
+		objects[key] = string(printed)
+	}
+
+	for _, secret := range state.Secrets {
+		secret := secret.DeepCopy()
+		clearServerFields(&secret.ObjectMeta)
+		showData(secret, showSecretData)
+
+		key := "Secret/" + secret.Namespace + "/" + secret.Name
+		printed, err := yaml.Marshal(secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to print %s", key)
+		}
+		objects[key] = string(printed)
+	}
+
+	return objects, nil
+}
+
+// clearServerFields removes the fields set by the API server which aren't affected by the synchronization
+func clearServerFields(meta *metav1.ObjectMeta) {
+	meta.UID = ""
+	meta.ResourceVersion = ""
+	meta.Generation = 0
+	meta.CreationTimestamp = metav1.Time{}
+	meta.ManagedFields = nil
+}
+
+// showData moves the Secret data to the string data, so that the values are readable, or replaces the values with their hashes
+func showData(secret *v1.Secret, showSecretData bool) {
+	if len(secret.Data) == 0 {
+		return
+	}
+
+	if secret.StringData == nil {
+		secret.StringData = make(map[string]string, len(secret.Data))
+	}
+	for key, value := range secret.Data {
+		if showSecretData {
+			secret.StringData[key] = string(value)
+		} else {
+			secret.StringData[key] = fmt.Sprintf("sha256:%x", sha256.Sum256(value))
+		}
+	}
+	secret.Data = nil
+}
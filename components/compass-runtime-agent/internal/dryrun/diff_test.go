@@ -0,0 +1,83 @@
+package dryrun
+
+import (
+	"testing"
+
+	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiff(t *testing.T) {
+	application := v1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "commerce", ResourceVersion: "10", UID: "abcd"},
+		Spec:       v1alpha1.ApplicationSpec{Description: "Commerce"},
+	}
+	secret := v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "commerce-credentials", Namespace: "kyma-integration"},
+		Data:       map[string][]byte{"clientSecret": []byte("secret")},
+	}
+
+	t.Run("should return empty diff when state didn't change", func(t *testing.T) {
+		// given
+		state := State{Applications: []v1alpha1.Application{application}, Secrets: []v1.Secret{secret}}
+
+		// when
+		diff, err := Diff(state, state, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("should return diff of created and deleted objects", func(t *testing.T) {
+		// given
+		before := State{Applications: []v1alpha1.Application{application}}
+		after := State{Secrets: []v1.Secret{secret}}
+
+		// when
+		diff, err := Diff(before, after, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, diff, "--- a/Application/commerce\n+++ /dev/null\n")
+		assert.Contains(t, diff, "-  description: Commerce\n")
+		assert.Contains(t, diff, "--- /dev/null\n+++ b/Secret/kyma-integration/commerce-credentials\n")
+		assert.NotContains(t, diff, "resourceVersion")
+	})
+
+	t.Run("should return diff of updated object", func(t *testing.T) {
+		// given
+		updated := application.DeepCopy()
+		updated.Spec.Description = "Commerce v2"
+		updated.ResourceVersion = "11"
+
+		// when
+		diff, err := Diff(State{Applications: []v1alpha1.Application{application}}, State{Applications: []v1alpha1.Application{*updated}}, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, diff, "-  description: Commerce\n+  description: Commerce v2\n")
+	})
+
+	t.Run("should hide secret data", func(t *testing.T) {
+		// when
+		diff, err := Diff(State{}, State{Secrets: []v1.Secret{secret}}, false)
+
+		// then
+		require.NoError(t, err)
+		assert.NotContains(t, diff, "clientSecret: secret")
+		assert.Contains(t, diff, "clientSecret: sha256:")
+	})
+
+	t.Run("should show secret data", func(t *testing.T) {
+		// when
+		diff, err := Diff(State{}, State{Secrets: []v1.Secret{secret}}, true)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, diff, "clientSecret: secret")
+	})
+}
@@ -0,0 +1,78 @@
+// Package dryrun contains the helpers of the dry run mode, in which the configuration fetched from the Director is applied
+// to the copy of the cluster state and the differences are printed instead of being written to the cluster
+package dryrun
+
+import (
+	"context"
+
+	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
+	appclient "github.com/kyma-project/kyma/components/application-operator/pkg/client/clientset/versioned"
+	appfake "github.com/kyma-project/kyma/components/application-operator/pkg/client/clientset/versioned/fake"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	corefake "k8s.io/client-go/kubernetes/fake"
+)
+
+// Config holds configuration of the dry run mode
+type Config struct {
+	// Payload is the path to the file with the Director response served by the Director stand-in. The dry run mode is enabled when it's set
+	Payload string `envconfig:"optional"`
+	// ClusterState enables comparing the configuration with the Applications and Secrets in the cluster instead of the empty state
+	ClusterState bool `envconfig:"default=false"`
+	// Apply enables writing the configuration to the cluster after the differences are printed
+	Apply bool `envconfig:"default=false"`
+	// ShowSecretData enables printing the Secret values instead of their hashes
+	ShowSecretData bool `envconfig:"default=false"`
+}
+
+// Enabled returns true if the agent should run in the dry run mode
+func (c Config) Enabled() bool {
+	return c.Payload != ""
+}
+
+// ReadsClusterState returns true if the configuration is compared with the cluster state
+func (c Config) ReadsClusterState() bool {
+	return c.ClusterState || c.Apply
+}
+
+// State contains the Applications and the Secrets in the integration namespace
+type State struct {
+	Applications []v1alpha1.Application
+	Secrets      []v1.Secret
+}
+
+// ReadState reads the Applications and the Secrets in the integration namespace
+func ReadState(ctx context.Context, applicationClientset appclient.Interface, coreClientset kubernetes.Interface, namespace string) (State, error) {
+	applications, err := applicationClientset.ApplicationconnectorV1alpha1().Applications().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return State{}, errors.Wrap(err, "Failed to list Applications")
+	}
+
+	secrets, err := coreClientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return State{}, errors.Wrapf(err, "Failed to list Secrets in %s namespace", namespace)
+	}
+
+	return State{
+		Applications: applications.Items,
+		Secrets:      secrets.Items,
+	}, nil
+}
+
+// NewClientsets creates the fake clientsets which contain the copy of the state
+func NewClientsets(state State) (appclient.Interface, kubernetes.Interface) {
+	applications := make([]runtime.Object, 0, len(state.Applications))
+	for i := range state.Applications {
+		applications = append(applications, state.Applications[i].DeepCopy())
+	}
+
+	secrets := make([]runtime.Object, 0, len(state.Secrets))
+	for i := range state.Secrets {
+		secrets = append(secrets, state.Secrets[i].DeepCopy())
+	}
+
+	return appfake.NewSimpleClientset(applications...), corefake.NewSimpleClientset(secrets...)
+}
@@ -0,0 +1,41 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReadState(t *testing.T) {
+	// given
+	state := State{
+		Applications: []v1alpha1.Application{{ObjectMeta: metav1.ObjectMeta{Name: "commerce"}}},
+		Secrets: []v1.Secret{
+			{ObjectMeta: metav1.ObjectMeta{Name: "commerce-credentials", Namespace: "kyma-integration"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}},
+		},
+	}
+	applicationClientset, coreClientset := NewClientsets(state)
+
+	// when
+	readState, err := ReadState(context.Background(), applicationClientset, coreClientset, "kyma-integration")
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, readState.Applications, 1)
+	assert.Equal(t, "commerce", readState.Applications[0].Name)
+	require.Len(t, readState.Secrets, 1)
+	assert.Equal(t, "commerce-credentials", readState.Secrets[0].Name)
+}
+
+func TestConfig(t *testing.T) {
+	assert.False(t, Config{}.Enabled())
+	assert.True(t, Config{Payload: "payload.json"}.Enabled())
+	assert.False(t, Config{Payload: "payload.json"}.ReadsClusterState())
+	assert.True(t, Config{Payload: "payload.json", Apply: true}.ReadsClusterState())
+}
@@ -1,6 +1,11 @@
 package applications
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
 	"github.com/kyma-project/kyma/components/application-operator/pkg/normalization"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +27,9 @@ const (
 	SpecEventsType = "Events"
 )
 
+// ConfigurationHashAnnotation stores the hash of the Director configuration the Application was synchronized with
+const ConfigurationHashAnnotation = "compass.kyma-project.io/configuration-hash"
+
 //go:generate mockery --name=Converter
 type Converter interface {
 	Do(application model.Application) v1alpha1.Application
@@ -31,12 +39,15 @@ type converter struct {
 	nameResolver             k8sconsts.NameResolver
 	centralGatewayServiceUrl string
 	appSkipTLSVerify         bool
+	hashKey                  []byte
 }
 
-func NewConverter(nameResolver k8sconsts.NameResolver, centralGatewayServiceUrl string, skipVerify bool) Converter {
+// NewConverter creates the converter, hashKey is the HMAC key of the configuration hash
+func NewConverter(nameResolver k8sconsts.NameResolver, centralGatewayServiceUrl string, skipVerify bool, hashKey []byte) Converter {
 	return converter{nameResolver: nameResolver,
 		centralGatewayServiceUrl: centralGatewayServiceUrl,
 		appSkipTLSVerify:         skipVerify,
+		hashKey:                  hashKey,
 	}
 }
 
@@ -58,8 +69,9 @@ func (c converter) Do(application model.Application) v1alpha1.Application {
 			APIVersion: "applicationconnector.kyma-project.io/v1alpha1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   application.Name,
-			Labels: map[string]string{managedByLabelKey: managedByLabelValue},
+			Name:        application.Name,
+			Labels:      map[string]string{managedByLabelKey: managedByLabelValue},
+			Annotations: map[string]string{ConfigurationHashAnnotation: ConfigurationHash(c.hashKey, application)},
 		},
 		Spec: v1alpha1.ApplicationSpec{
 			Description:      description,
@@ -72,6 +84,19 @@ func (c converter) Do(application model.Application) v1alpha1.Application {
 	}
 }
 
+// ConfigurationHash returns the HMAC of the Application configuration, including its credentials and request parameters,
+// so that the credentials can't be guessed from the annotation of the Application
+func ConfigurationHash(key []byte, application model.Application) string {
+	data, err := json.Marshal(application)
+	if err != nil {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 func (c converter) toServices(applicationName string, bundles []model.APIBundle) []v1alpha1.Service {
 	services := make([]v1alpha1.Service, 0, len(bundles))
 
@@ -15,10 +15,12 @@ const (
 	centralGatewayServiceUrl = "http://central-application-gateway.kyma-system.svc.cluster.local:8082"
 )
 
+var testHashKey = []byte("hash-key")
+
 func TestConverter(t *testing.T) {
 	t.Run("should convert application without API bundles", func(t *testing.T) {
 		// given
-		converter := NewConverter(k8sconsts.NewNameResolver(), centralGatewayServiceUrl, false)
+		converter := NewConverter(k8sconsts.NewNameResolver(), centralGatewayServiceUrl, false, testHashKey)
 
 		directorApp := model.Application{
 			ID:   "App1",
@@ -37,8 +39,9 @@ func TestConverter(t *testing.T) {
 				APIVersion: "applicationconnector.kyma-project.io/v1alpha1",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Name:   "Appname1",
-				Labels: map[string]string{managedByLabelKey: managedByLabelValue},
+				Name:        "Appname1",
+				Labels:      map[string]string{managedByLabelKey: managedByLabelValue},
+				Annotations: map[string]string{ConfigurationHashAnnotation: ConfigurationHash(testHashKey, directorApp)},
 			},
 			Spec: v1alpha1.ApplicationSpec{
 				Description:      "Description not provided",
@@ -60,7 +63,7 @@ func TestConverter(t *testing.T) {
 
 	t.Run("should convert application containing API Bundles with API Definitions", func(t *testing.T) {
 		// given
-		converter := NewConverter(k8sconsts.NewNameResolver(), centralGatewayServiceUrl, false)
+		converter := NewConverter(k8sconsts.NewNameResolver(), centralGatewayServiceUrl, false, testHashKey)
 		instanceAuthRequestInputSchema := "{}"
 
 		emptyDescription := ""
@@ -144,8 +147,9 @@ func TestConverter(t *testing.T) {
 				APIVersion: "applicationconnector.kyma-project.io/v1alpha1",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Name:   "Appname1",
-				Labels: map[string]string{managedByLabelKey: managedByLabelValue},
+				Name:        "Appname1",
+				Labels:      map[string]string{managedByLabelKey: managedByLabelValue},
+				Annotations: map[string]string{ConfigurationHashAnnotation: ConfigurationHash(testHashKey, directorApp)},
 			},
 			Spec: v1alpha1.ApplicationSpec{
 				Description:      "Description",
@@ -237,7 +241,7 @@ func TestConverter(t *testing.T) {
 
 	t.Run("should convert application with services containing events and API, and no System Auths", func(t *testing.T) {
 		// given
-		converter := NewConverter(k8sconsts.NewNameResolver(), centralGatewayServiceUrl, false)
+		converter := NewConverter(k8sconsts.NewNameResolver(), centralGatewayServiceUrl, false, testHashKey)
 
 		directorApp := model.Application{
 			ID:                  "App1",
@@ -274,8 +278,9 @@ func TestConverter(t *testing.T) {
 				APIVersion: "applicationconnector.kyma-project.io/v1alpha1",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Name:   "Appname1",
-				Labels: map[string]string{managedByLabelKey: managedByLabelValue},
+				Name:        "Appname1",
+				Labels:      map[string]string{managedByLabelKey: managedByLabelValue},
+				Annotations: map[string]string{ConfigurationHashAnnotation: ConfigurationHash(testHashKey, directorApp)},
 			},
 			Spec: v1alpha1.ApplicationSpec{
 				Description:      "Description",
@@ -316,4 +321,38 @@ func TestConverter(t *testing.T) {
 		// then
 		assert.Equal(t, expected, application)
 	})
+
+	t.Run("should change configuration hash when credentials change", func(t *testing.T) {
+		// given
+		converter := NewConverter(k8sconsts.NewNameResolver(), centralGatewayServiceUrl, false, testHashKey)
+
+		newDirectorApp := func(clientSecret string) model.Application {
+			return model.Application{
+				ID:   "App1",
+				Name: "Appname1",
+				ApiBundles: []model.APIBundle{{
+					ID:                  "bundle1",
+					DefaultInstanceAuth: &model.Auth{Credentials: &model.Credentials{Oauth: &model.Oauth{ClientID: "client", ClientSecret: clientSecret}}},
+				}},
+			}
+		}
+
+		// when
+		application := converter.Do(newDirectorApp("secret"))
+		sameApplication := converter.Do(newDirectorApp("secret"))
+		rotatedApplication := converter.Do(newDirectorApp("rotated"))
+
+		// then
+		assert.NotEmpty(t, application.Annotations[ConfigurationHashAnnotation])
+		assert.Equal(t, application.Annotations, sameApplication.Annotations)
+		assert.NotEqual(t, application.Annotations, rotatedApplication.Annotations)
+	})
+
+	t.Run("should not compute configuration hash without the key", func(t *testing.T) {
+		// given
+		directorApp := model.Application{ID: "App1", Name: "Appname1"}
+
+		// then
+		assert.NotEqual(t, ConfigurationHash(testHashKey, directorApp), ConfigurationHash([]byte("other-key"), directorApp))
+	})
 }
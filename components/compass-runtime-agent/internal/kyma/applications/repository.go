@@ -49,6 +49,12 @@ func (m manager) Update(application *v1alpha1.Application) (*v1alpha1.Applicatio
 	}
 
 	currentApp.Labels = application.Labels
+	for key, value := range application.Annotations {
+		if currentApp.Annotations == nil {
+			currentApp.Annotations = map[string]string{}
+		}
+		currentApp.Annotations[key] = value
+	}
 	currentApp.Spec.Description = application.Spec.Description
 	currentApp.Spec.Labels = application.Spec.Labels
 	currentApp.Spec.Services = application.Spec.Services
@@ -13,6 +13,7 @@ import (
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma/applications"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma/model"
 	appsecrets "github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma/secrets"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/metrics"
 )
 
 type service struct {
@@ -35,6 +36,19 @@ const (
 	Delete
 )
 
+func (o Operation) String() string {
+	switch o {
+	case Create:
+		return metrics.OperationCreate
+	case Update:
+		return metrics.OperationUpdate
+	case Delete:
+		return metrics.OperationDelete
+	default:
+		return fmt.Sprintf("Operation(%d)", int(o))
+	}
+}
+
 type Result struct {
 	ApplicationName string
 	ApplicationID   string
@@ -71,15 +85,28 @@ func (s *service) apply(runtimeApplications []v1alpha1.Application, directorAppl
 
 	created := s.createApplications(directorApplications, runtimeApplications)
 	deleted := s.deleteApplications(directorApplications, runtimeApplications)
-	updated := s.updateApplications(directorApplications, runtimeApplications)
+	updated, unchanged := s.updateApplications(directorApplications, runtimeApplications)
 
 	results = append(results, created...)
 	results = append(results, deleted...)
 	results = append(results, updated...)
 
+	recordResults(results, unchanged)
+
 	return results
 }
 
+func recordResults(results []Result, unchanged int) {
+	for _, result := range results {
+		outcome := metrics.ResultSucceeded
+		if result.Error != nil {
+			outcome = metrics.ResultFailed
+		}
+		metrics.ApplicationsSynchronized(result.Operation.String(), outcome, 1)
+	}
+	metrics.ApplicationsSynchronized(metrics.OperationUnchanged, metrics.ResultSucceeded, unchanged)
+}
+
 func (s *service) getExistingRuntimeApplications() ([]v1alpha1.Application, apperrors.AppError) {
 	applicationList, err := s.applicationRepository.List(v1.ListOptions{})
 	if err != nil {
@@ -292,19 +319,30 @@ func (s *service) getRequestParametersSecretNames(runtimeApplication v1alpha1.Ap
 	return secretNames
 }
 
-func (s *service) updateApplications(directorApplications []model.Application, runtimeApplications []v1alpha1.Application) []Result {
+// updateApplications updates the Applications whose configuration changed and returns the number of unchanged Applications
+func (s *service) updateApplications(directorApplications []model.Application, runtimeApplications []v1alpha1.Application) ([]Result, int) {
 	log.Info("Updating applications.")
 	results := make([]Result, 0)
+	unchanged := 0
 
 	for _, directorApplication := range directorApplications {
 		if ApplicationExists(directorApplication.Name, runtimeApplications) {
 			existentApplication := GetApplication(directorApplication.Name, runtimeApplications)
-			result := s.updateApplication(directorApplication, existentApplication, s.converter.Do(directorApplication))
+			newRuntimeApplication := s.converter.Do(directorApplication)
+			if ApplicationUnchanged(existentApplication, newRuntimeApplication) {
+				log.Debugf("Application '%s' is unchanged, skipping update.", directorApplication.Name)
+				unchanged++
+				continue
+			}
+
+			result := s.updateApplication(directorApplication, existentApplication, newRuntimeApplication)
 			results = append(results, result)
 		}
 	}
 
-	return results
+	log.Infof("Skipped %d unchanged applications.", unchanged)
+
+	return results, unchanged
 }
 
 func (s *service) updateApplication(directorApplication model.Application, existentRuntimeApplication v1alpha1.Application, newRuntimeApplication v1alpha1.Application) Result {
@@ -470,6 +470,40 @@ func TestKymaService(t *testing.T) {
 		applicationsManagerMock.AssertExpectations(t)
 	})
 
+	t.Run("should skip Update operation of unchanged application", func(t *testing.T) {
+		// given
+		applicationsManagerMock := &appMocks.Repository{}
+		converterMock := &appMocks.Converter{}
+		credentialsServiceMock := &appSecrets.CredentialsService{}
+		requestParametersServiceMock := &appSecrets.RequestParametersService{}
+
+		api1 := fixDirectorAPiDefinition("API1", "Name", "API 1 description")
+		apiBundle1 := fixAPIBundle("bundle1", []model.APIDefinition{api1}, nil, fixAuthOauth())
+		directorApplication := fixDirectorApplication("id1", "name1", apiBundle1)
+
+		runtimeApplication := getTestApplication("name1", "id1", []v1alpha1.Service{fixService("bundle1", fixServiceAPIEntryWithOauth("API1", "bundle1"))})
+		runtimeApplication.Annotations = map[string]string{applications.ConfigurationHashAnnotation: "hash1"}
+
+		existingRuntimeApplications := v1alpha1.ApplicationList{
+			Items: []v1alpha1.Application{runtimeApplication},
+		}
+
+		converterMock.On("Do", directorApplication).Return(runtimeApplication)
+		applicationsManagerMock.On("List", metav1.ListOptions{}).Return(&existingRuntimeApplications, nil)
+
+		// when
+		kymaService := NewService(applicationsManagerMock, converterMock, credentialsServiceMock, requestParametersServiceMock)
+		result, err := kymaService.Apply([]model.Application{directorApplication})
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		converterMock.AssertExpectations(t)
+		applicationsManagerMock.AssertExpectations(t)
+		credentialsServiceMock.AssertExpectations(t)
+		requestParametersServiceMock.AssertExpectations(t)
+	})
+
 	t.Run("should apply Update operation and update credentials", func(t *testing.T) {
 		// given
 		applicationsManagerMock := &appMocks.Repository{}
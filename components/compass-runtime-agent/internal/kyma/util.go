@@ -2,10 +2,12 @@ package kyma
 
 import (
 	memoize "github.com/kofalt/go-memoize"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/apperrors"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma/applications"
 )
 
 func newResult(application v1alpha1.Application, applicationID string, operation Operation, appError apperrors.AppError) Result {
@@ -45,6 +47,21 @@ func GetApplication(applicationName string, applicationList []v1alpha1.Applicati
 	return v1alpha1.Application{}
 }
 
+// ApplicationUnchanged returns true if the existing Application was synchronized with the same Director configuration
+// and the fields managed by the agent weren't modified since
+func ApplicationUnchanged(existing v1alpha1.Application, desired v1alpha1.Application) bool {
+	hash := desired.Annotations[applications.ConfigurationHashAnnotation]
+	if hash == "" || existing.Annotations[applications.ConfigurationHashAnnotation] != hash {
+		return false
+	}
+
+	return equality.Semantic.DeepEqual(existing.Labels, desired.Labels) &&
+		existing.Spec.Description == desired.Spec.Description &&
+		equality.Semantic.DeepEqual(existing.Spec.Labels, desired.Spec.Labels) &&
+		equality.Semantic.DeepEqual(existing.Spec.Services, desired.Spec.Services) &&
+		equality.Semantic.DeepEqual(existing.Spec.CompassMetadata, desired.Spec.CompassMetadata)
+}
+
 type getApplicationUIDResult struct {
 	AppUID   types.UID
 	AppError apperrors.AppError
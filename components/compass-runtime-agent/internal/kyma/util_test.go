@@ -3,7 +3,9 @@ package kyma
 import (
 	"testing"
 
+	"github.com/kyma-project/kyma/components/application-operator/pkg/apis/applicationconnector/v1alpha1"
 	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/apperrors"
+	"github.com/kyma-project/kyma/components/compass-runtime-agent/internal/kyma/applications"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -32,3 +34,30 @@ func TestCachingGetApplicationUIDFunc(t *testing.T) {
 		assert.Equal(t, 1, calls["app1"])
 	}
 }
+
+func TestApplicationUnchanged(t *testing.T) {
+	newApplication := func(hash, description string) v1alpha1.Application {
+		application := getTestApplication("name1", "id1", []v1alpha1.Service{fixService("bundle1", fixServiceAPIEntry("API1"))})
+		application.Spec.Description = description
+		if hash != "" {
+			application.Annotations = map[string]string{applications.ConfigurationHashAnnotation: hash}
+		}
+		return application
+	}
+
+	t.Run("should return true for application synchronized with the same configuration", func(t *testing.T) {
+		assert.True(t, ApplicationUnchanged(newApplication("hash1", "Description"), newApplication("hash1", "Description")))
+	})
+
+	t.Run("should return false when configuration changed", func(t *testing.T) {
+		assert.False(t, ApplicationUnchanged(newApplication("hash1", "Description"), newApplication("hash2", "Description")))
+	})
+
+	t.Run("should return false for application synchronized before hashes were stored", func(t *testing.T) {
+		assert.False(t, ApplicationUnchanged(newApplication("", "Description"), newApplication("hash1", "Description")))
+	})
+
+	t.Run("should return false when application was modified in the cluster", func(t *testing.T) {
+		assert.False(t, ApplicationUnchanged(newApplication("hash1", "Modified"), newApplication("hash1", "Description")))
+	})
+}
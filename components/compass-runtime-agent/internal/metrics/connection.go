@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	stateLabel = "state"
+
+	DirectorOperationFetchConfiguration = "fetch_configuration"
+	DirectorOperationFetchRevisions     = "fetch_revisions"
+	DirectorOperationFetchApplications  = "fetch_applications"
+	DirectorOperationSetRuntimeLabel    = "set_runtime_label"
+)
+
+var (
+	// ConnectionState reports the state of the Compass Connection. Only the gauge of the current state is set to 1
+	ConnectionState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "connection_state",
+		Help:      "State of the Compass Connection, the gauge of the current state is set to 1",
+	}, []string{stateLabel})
+
+	// CertificateExpiration reports when the client certificate used to connect to Compass expires
+	CertificateExpiration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "certificate_expiration_timestamp_seconds",
+		Help:      "Expiration time of the client certificate used to connect to Compass, in seconds since the Unix epoch",
+	})
+
+	// DirectorRequestDuration measures the requests sent to the Director
+	DirectorRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "director_request_duration_seconds",
+		Help:      "Duration of the requests sent to the Compass Director",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{operationLabel, resultLabel})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(ConnectionState, CertificateExpiration, DirectorRequestDuration)
+}
+
+// SetConnectionState sets the gauge of the current Compass Connection state and resets the gauges of the other states
+func SetConnectionState(state string) {
+	ConnectionState.Reset()
+	ConnectionState.WithLabelValues(state).Set(1)
+}
+
+// SetCertificateExpiration sets the expiration time of the client certificate
+func SetCertificateExpiration(notAfter time.Time) {
+	CertificateExpiration.Set(float64(notAfter.Unix()))
+}
+
+// ObserveDirectorRequest records the duration of the Director request started at the given time
+func ObserveDirectorRequest(operation string, start time.Time, err error) {
+	result := ResultSucceeded
+	if err != nil {
+		result = ResultFailed
+	}
+	DirectorRequestDuration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
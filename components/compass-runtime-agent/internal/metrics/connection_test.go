@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetConnectionState(t *testing.T) {
+	// when
+	SetConnectionState("Connected")
+	SetConnectionState("Synchronized")
+
+	// then
+	assert.Equal(t, 1, testutil.CollectAndCount(ConnectionState))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ConnectionState.WithLabelValues("Synchronized")))
+}
+
+func TestSetCertificateExpiration(t *testing.T) {
+	// given
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// when
+	SetCertificateExpiration(notAfter)
+
+	// then
+	assert.Equal(t, float64(notAfter.Unix()), testutil.ToFloat64(CertificateExpiration))
+}
+
+func TestObserveDirectorRequest(t *testing.T) {
+	// when
+	ObserveDirectorRequest(DirectorOperationFetchConfiguration, time.Now(), nil)
+	ObserveDirectorRequest(DirectorOperationSetRuntimeLabel, time.Now(), errors.New("error"))
+
+	// then
+	assert.Equal(t, 2, testutil.CollectAndCount(DirectorRequestDuration))
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "compass_runtime_agent"
+
+	operationLabel = "operation"
+	resultLabel    = "result"
+
+	OperationCreate    = "create"
+	OperationUpdate    = "update"
+	OperationDelete    = "delete"
+	OperationUnchanged = "unchanged"
+
+	ResultSucceeded = "succeeded"
+	ResultFailed    = "failed"
+)
+
+var (
+	// SynchronizationDuration measures the synchronization of the Applications with the Director
+	SynchronizationDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "synchronization_duration_seconds",
+		Help:      "Duration of the synchronization of the Applications with the Compass Director",
+		Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	})
+
+	// SynchronizedApplications counts the Applications by the operation applied during the synchronization and its result
+	SynchronizedApplications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "synchronized_applications_total",
+		Help:      "Number of Applications created, updated, deleted, or left unchanged during the synchronization with the Compass Director",
+	}, []string{operationLabel, resultLabel})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(SynchronizationDuration, SynchronizedApplications)
+}
+
+// ApplicationsSynchronized adds the number of Applications to which the operation was applied with the given result
+func ApplicationsSynchronized(operation, result string, count int) {
+	SynchronizedApplications.WithLabelValues(operation, result).Add(float64(count))
+}
@@ -150,4 +150,27 @@ type SynchronizationStatus struct {
 	// +nullable
 	LastSuccessfulApplication metav1.Time `json:"lastSuccessfulApplication"`
 	Error                     string      `json:"error,omitempty"`
+	// +optional
+	Applications []ApplicationSynchronizationResult `json:"applications,omitempty"`
+}
+
+// ApplicationSynchronizationResult represents the result of the last synchronization of the Application which was created, updated, or deleted
+type ApplicationSynchronizationResult struct {
+	ApplicationName string                          `json:"applicationName"`
+	ApplicationID   string                          `json:"applicationId,omitempty"`
+	Result          ApplicationSynchronizationState `json:"result"`
+	Error           string                          `json:"error,omitempty"`
 }
+
+type ApplicationSynchronizationState string
+
+const (
+	// Application was created in the Runtime
+	ApplicationCreated ApplicationSynchronizationState = "Created"
+	// Application was updated in the Runtime
+	ApplicationUpdated ApplicationSynchronizationState = "Updated"
+	// Application was deleted from the Runtime
+	ApplicationDeleted ApplicationSynchronizationState = "Deleted"
+	// Creating, updating, or deleting the Application failed
+	ApplicationFailed ApplicationSynchronizationState = "Failed"
+)
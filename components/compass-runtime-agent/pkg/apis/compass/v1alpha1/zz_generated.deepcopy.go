@@ -7,6 +7,22 @@ package v1alpha1
 
 import runtime "k8s.io/apimachinery/pkg/runtime"
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSynchronizationResult) DeepCopyInto(out *ApplicationSynchronizationResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSynchronizationResult.
+func (in *ApplicationSynchronizationResult) DeepCopy() *ApplicationSynchronizationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSynchronizationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 	*out = *in
@@ -189,6 +205,11 @@ func (in *SynchronizationStatus) DeepCopyInto(out *SynchronizationStatus) {
 	in.LastAttempt.DeepCopyInto(&out.LastAttempt)
 	in.LastSuccessfulFetch.DeepCopyInto(&out.LastSuccessfulFetch)
 	in.LastSuccessfulApplication.DeepCopyInto(&out.LastSuccessfulApplication)
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]ApplicationSynchronizationResult, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
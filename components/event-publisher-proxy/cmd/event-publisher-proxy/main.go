@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	golog "log"
 
 	"github.com/kelseyhightower/envconfig"
 	kymalogger "github.com/kyma-project/kyma/components/eventing-controller/logger"
+	ectracing "github.com/kyma-project/kyma/components/eventing-controller/pkg/tracing"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/commander"
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/commander/beb"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/commander/kafka"
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/commander/nats"
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/metrics"
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/metrics/latency"
@@ -16,12 +19,15 @@ import (
 )
 
 const (
-	backendBEB  = "beb"
-	backendNATS = "nats"
+	backendBEB   = "beb"
+	backendNATS  = "nats"
+	backendKafka = "kafka"
+
+	tracingServiceName = "eventing-publisher-proxy"
 )
 
 type Config struct {
-	// Backend used for Eventing. It could be "nats" or "beb".
+	// Backend used for Eventing. It could be "nats", "beb" or "kafka".
 	Backend string `envconfig:"BACKEND" required:"true"`
 
 	// AppLogFormat defines the log format.
@@ -29,6 +35,9 @@ type Config struct {
 
 	// AppLogLevel defines the log level.
 	AppLogLevel string `envconfig:"APP_LOG_LEVEL" default:"info"`
+
+	// TracingOTLPEndpoint is the OTLP/HTTP endpoint the spans are exported to. Tracing is disabled if it's empty.
+	TracingOTLPEndpoint string `envconfig:"TRACING_OTLP_ENDPOINT" default:""`
 }
 
 func main() {
@@ -52,6 +61,17 @@ func main() {
 	}()
 	setupLogger := logger.WithContext().With("backend", cfg.Backend)
 
+	// init the tracing
+	shutdownTracing, err := ectracing.InitTracerProvider(context.Background(), tracingServiceName, cfg.TracingOTLPEndpoint)
+	if err != nil {
+		setupLogger.Fatalw("Failed to initialize tracing", "error", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			setupLogger.Warnw("Failed to flush spans", "error", err)
+		}
+	}()
+
 	// metrics collector
 	metricsCollector := metrics.NewCollector(latency.NewBucketsProvider())
 	prometheus.MustRegister(metricsCollector)
@@ -63,6 +83,8 @@ func main() {
 		c = beb.NewCommander(opts, metricsCollector, logger)
 	case backendNATS:
 		c = nats.NewCommander(opts, metricsCollector, logger)
+	case backendKafka:
+		c = kafka.NewCommander(opts, metricsCollector, logger)
 	default:
 		setupLogger.Fatalf("Invalid publisher backend: %v", cfg.Backend)
 	}
@@ -0,0 +1,152 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"github.com/kelseyhightower/envconfig"
+	"golang.org/x/xerrors"
+
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+	"go.uber.org/zap"
+
+	"k8s.io/client-go/dynamic"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp" // TODO: remove as this is only required in a dev setup
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/application"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/cloudevents/eventtype"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/env"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/handler"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/informers"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/legacy"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/metrics"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/options"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/receiver"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/sender/kafka"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/signals"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/subscribed"
+)
+
+const (
+	kafkaBackend       = "kafka"
+	kafkaCommanderName = kafkaBackend + "-commander"
+)
+
+// Commander implements the Commander interface.
+type Commander struct {
+	cancel           context.CancelFunc
+	metricsCollector *metrics.Collector
+	logger           *logger.Logger
+	envCfg           *env.KafkaConfig
+	opts             *options.Options
+}
+
+// NewCommander creates the Commander for publisher to Kafka.
+func NewCommander(opts *options.Options, metricsCollector *metrics.Collector, logger *logger.Logger) *Commander {
+	return &Commander{
+		envCfg:           new(env.KafkaConfig),
+		logger:           logger,
+		metricsCollector: metricsCollector,
+		opts:             opts,
+	}
+}
+
+// Init implements the Commander interface and initializes the publisher to Kafka.
+func (c *Commander) Init() error {
+	if err := envconfig.Process("", c.envCfg); err != nil {
+		return xerrors.Errorf("failed to read configuration for %s : %v", kafkaCommanderName, err)
+	}
+	return nil
+}
+
+// Start implements the Commander interface and starts the publisher.
+func (c *Commander) Start() error {
+	c.namedLogger().Infow("Starting Event Publisher", "configuration", c.envCfg.String(), "startup arguments", c.opts)
+
+	// assure uniqueness
+	var ctx context.Context
+	ctx, c.cancel = context.WithCancel(signals.NewContext())
+
+	// configure message receiver
+	messageReceiver := receiver.NewHTTPMessageReceiver(c.envCfg.Port)
+
+	// connect to kafka
+	saramaConfig, err := kafka.NewSaramaConfig(c.envCfg)
+	if err != nil {
+		return xerrors.Errorf("failed to configure backend client for %s : %v", kafkaCommanderName, err)
+	}
+	client, err := sarama.NewClient(c.envCfg.Brokers, saramaConfig)
+	if err != nil {
+		return xerrors.Errorf("failed to connect to backend server for %s : %v", kafkaCommanderName, err)
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			c.namedLogger().Errorw("Failed to close Kafka client", "error", err)
+		}
+	}()
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		return xerrors.Errorf("failed to create producer for %s : %v", kafkaCommanderName, err)
+	}
+	defer func() {
+		if err := producer.Close(); err != nil {
+			c.namedLogger().Errorw("Failed to close Kafka producer", "error", err)
+		}
+	}()
+
+	// configure the message sender
+	messageSender := kafka.NewSender(ctx, client, producer, c.envCfg, c.logger)
+
+	// cluster config
+	k8sConfig := config.GetConfigOrDie()
+
+	// setup application lister
+	dynamicClient := dynamic.NewForConfigOrDie(k8sConfig)
+	applicationLister := application.NewLister(ctx, dynamicClient)
+
+	// configure legacyTransformer
+	legacyTransformer := legacy.NewTransformer(
+		c.envCfg.ToConfig().BEBNamespace,
+		c.envCfg.ToConfig().EventTypePrefix,
+		applicationLister,
+	)
+
+	// configure Subscription Lister
+	subDynamicSharedInfFactory := subscribed.GenerateSubscriptionInfFactory(k8sConfig)
+	subLister := subDynamicSharedInfFactory.ForResource(subscribed.GVR).Lister()
+	subscribedProcessor := &subscribed.Processor{
+		SubscriptionLister: &subLister,
+		Prefix:             c.envCfg.ToConfig().EventTypePrefix,
+		Namespace:          c.envCfg.ToConfig().BEBNamespace,
+		Logger:             c.logger,
+	}
+
+	// sync informer cache or die
+	c.namedLogger().Info("Waiting for informers caches to sync")
+	informers.WaitForCacheSyncOrDie(ctx, subDynamicSharedInfFactory, c.logger)
+	c.namedLogger().Info("Informers are synced successfully")
+
+	// configure event type cleaner
+	eventTypeCleaner := eventtype.NewCleaner(c.envCfg.EventTypePrefix, applicationLister, c.logger)
+
+	// start handler which blocks until it receives a shutdown signal
+	if err := handler.NewHandler(messageReceiver, messageSender, messageSender, c.envCfg.RequestTimeout, legacyTransformer, c.opts,
+		subscribedProcessor, c.logger, c.metricsCollector, eventTypeCleaner).Start(ctx); err != nil {
+		return xerrors.Errorf("failed to start handler for %s : %v", kafkaCommanderName, err)
+	}
+
+	c.namedLogger().Infof("Event Publisher was shut down")
+
+	return nil
+}
+
+// Stop implements the Commander interface and stops the publisher.
+func (c *Commander) Stop() error {
+	c.cancel()
+	return nil
+}
+
+func (c *Commander) namedLogger() *zap.SugaredLogger {
+	return c.logger.WithContext().Named(kafkaCommanderName).With("backend", kafkaBackend)
+}
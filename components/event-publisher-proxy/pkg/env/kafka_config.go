@@ -0,0 +1,41 @@
+package env
+
+import (
+	"fmt"
+	"time"
+)
+
+// compile time check
+var _ fmt.Stringer = &KafkaConfig{}
+
+// KafkaConfig represents the environment config for the Event Publisher to Kafka.
+type KafkaConfig struct {
+	Port           int           `envconfig:"INGRESS_PORT" default:"8080"`
+	Brokers        []string      `envconfig:"KAFKA_BROKERS" required:"true"`
+	Version        string        `envconfig:"KAFKA_VERSION" default:"2.8.0"`
+	ClientID       string        `envconfig:"KAFKA_CLIENT_ID" default:"eventing-publisher-proxy"`
+	RequestTimeout time.Duration `envconfig:"REQUEST_TIMEOUT" default:"5s"`
+
+	// TopicPrefix is prepended to the event type to get the name of the topic which stores the events
+	TopicPrefix string `envconfig:"KAFKA_TOPIC_PREFIX" default:"kyma"`
+
+	// Legacy Namespace is used as the event source for legacy events
+	LegacyNamespace string `envconfig:"LEGACY_NAMESPACE" default:"kyma"`
+	// EventTypePrefix is the prefix of each event as per the eventing specification
+	// It follows the eventType format: <eventTypePrefix>.<appName>.<event-name>.<version>
+	EventTypePrefix string `envconfig:"EVENT_TYPE_PREFIX" default:"kyma"`
+}
+
+// ToConfig converts to a default BEB BEBConfig
+func (c *KafkaConfig) ToConfig() *BEBConfig {
+	cfg := &BEBConfig{
+		BEBNamespace:    c.LegacyNamespace,
+		EventTypePrefix: c.EventTypePrefix,
+	}
+	return cfg
+}
+
+// String implements the fmt.Stringer interface
+func (c *KafkaConfig) String() string {
+	return fmt.Sprintf("%#v", c)
+}
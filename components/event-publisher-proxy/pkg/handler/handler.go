@@ -8,6 +8,8 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+	ectracing "github.com/kyma-project/kyma/components/eventing-controller/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
@@ -78,8 +80,8 @@ func NewHandler(receiver *receiver.HTTPMessageReceiver, sender sender.GenericSen
 // setupMux configures the request router for all required endpoints.
 func (h *Handler) setupMux() {
 	router := mux.NewRouter()
-	router.HandleFunc(PublishEndpoint, h.maxBytes(h.publishCloudEvents)).Methods(http.MethodPost)
-	router.HandleFunc(LegacyEndpointPattern, h.maxBytes(h.publishLegacyEventsAsCE)).Methods(http.MethodPost)
+	router.HandleFunc(PublishEndpoint, h.traced(h.maxBytes(h.publishCloudEvents))).Methods(http.MethodPost)
+	router.HandleFunc(LegacyEndpointPattern, h.traced(h.maxBytes(h.publishLegacyEventsAsCE))).Methods(http.MethodPost)
 	router.HandleFunc(SubscribedEndpointPattern, h.maxBytes(h.SubscribedProcessor.ExtractEventsFromSubscriptions)).Methods(http.MethodGet)
 	router.HandleFunc(health.ReadinessURI, h.maxBytes(h.HealthChecker.ReadinessCheck))
 	router.HandleFunc(health.LivenessURI, h.maxBytes(h.HealthChecker.LivenessCheck))
@@ -100,6 +102,27 @@ func (h *Handler) maxBytes(f http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// traced records the span of publishing the event of the request, which ends with the status code of the response.
+func (h *Handler) traced(f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracing.StartPublishSpan(r.Context(), r.Header)
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		f(recorder, r.WithContext(ctx))
+		tracing.EndSpanWithStatus(span, recorder.statusCode)
+	}
+}
+
+// statusRecorder records the status code written to the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
 // publishLegacyEventsAsCE converts an incoming request in legacy event format to a cloudevent and dispatches it using
 // the configured GenericSender.
 func (h *Handler) publishLegacyEventsAsCE(writer http.ResponseWriter, request *http.Request) {
@@ -195,7 +218,8 @@ func (h *Handler) sendEventAndRecordMetrics(ctx context.Context, event *cev2even
 	ctx, cancel := context.WithTimeout(ctx, h.RequestTimeout)
 	defer cancel()
 	h.applyDefaults(ctx, event)
-	tracing.AddTracingContextToCEExtensions(header, event)
+	tracing.SetEventAttributes(trace.SpanFromContext(ctx), event)
+	tracing.AddTracingContextToCEExtensions(ectracing.InjectSpanContext(ctx, header), event)
 	start := time.Now()
 	result, err := h.Sender.Send(ctx, event)
 	duration := time.Since(start)
@@ -17,6 +17,13 @@ import (
 	cev2event "github.com/cloudevents/sdk-go/v2/event"
 	eclogger "github.com/kyma-project/kyma/components/eventing-controller/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/application"
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/application/applicationtest"
@@ -628,6 +635,50 @@ func TestHandler_maxBytes(t *testing.T) {
 	}
 }
 
+func TestHandler_traced(t *testing.T) {
+	tests := []struct {
+		name           string
+		givenStatus    int
+		wantStatusCode codes.Code
+	}{
+		{
+			name:           "span of published event",
+			givenStatus:    http.StatusNoContent,
+			wantStatusCode: codes.Unset,
+		},
+		{
+			name:           "span of failed event",
+			givenStatus:    http.StatusInsufficientStorage,
+			wantStatusCode: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			recorder := tracetest.NewSpanRecorder()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+			defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+			h := &Handler{}
+			writer := httptest.NewRecorder()
+			f := func(writer http.ResponseWriter, r *http.Request) {
+				assert.True(t, trace.SpanFromContext(r.Context()).IsRecording())
+				writer.WriteHeader(tt.givenStatus)
+			}
+
+			// when
+			h.traced(f)(writer, httptest.NewRequest(http.MethodPost, "http://localhost/publish", nil))
+
+			// then
+			assert.Equal(t, tt.givenStatus, writer.Result().StatusCode)
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tt.wantStatusCode, spans[0].Status().Code)
+			assert.Contains(t, spans[0].Attributes(), semconv.HTTPStatusCodeKey.Int(tt.givenStatus))
+		})
+	}
+}
+
 func TestHandler_sendEventAndRecordMetrics(t *testing.T) {
 	type fields struct {
 		Sender    sender.GenericSender
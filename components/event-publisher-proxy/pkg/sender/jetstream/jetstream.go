@@ -18,6 +18,7 @@ import (
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/handler/health"
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/sender"
 	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/sender/beb"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/tracing"
 
 	"github.com/nats-io/nats.go"
 )
@@ -62,7 +63,7 @@ func (s *Sender) ConnectionStatus() nats.Status {
 
 // Send dispatches the event to the NATS backend in JetStream mode.
 // If the NATS connection is not open, it returns an error.
-func (s *Sender) Send(_ context.Context, event *event.Event) (sender.PublishResult, error) {
+func (s *Sender) Send(ctx context.Context, event *event.Event) (sender.PublishResult, error) {
 	if s.ConnectionStatus() != nats.CONNECTED {
 		return nil, ErrNotConnected
 	}
@@ -77,8 +78,10 @@ func (s *Sender) Send(_ context.Context, event *event.Event) (sender.PublishResu
 		return nil, err
 	}
 
-	// send the event
+	// send the event, which is persisted once the stream acknowledged it
+	_, span := tracing.StartPersistSpan(ctx, event, natsBackend, msg.Subject)
 	_, err = jsCtx.PublishMsg(msg)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		s.namedLogger().Errorw("Cannot send event to backend", "error", err)
 		if errors.Is(err, nats.ErrNoStreamResponse) {
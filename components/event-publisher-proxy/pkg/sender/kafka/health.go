@@ -0,0 +1,28 @@
+package kafka
+
+import (
+	"net/http"
+
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/handler/health"
+)
+
+// ReadinessCheck returns an instance of http.HandlerFunc that checks the readiness of the given Kafka Sender.
+// It checks that the Kafka client is open and knows the controller broker of the cluster and reports 2XX if so,
+// otherwise reports 5XX.
+func (s *Sender) ReadinessCheck(w http.ResponseWriter, _ *http.Request) {
+	if s.client.Closed() {
+		s.namedLogger().Error("Readiness check failed: Kafka client is closed")
+		w.WriteHeader(health.StatusCodeNotHealthy)
+		return
+	}
+	if _, err := s.client.Controller(); err != nil {
+		s.namedLogger().Errorw("Readiness check failed: not connected to Kafka cluster", "error", err)
+		w.WriteHeader(health.StatusCodeNotHealthy)
+		return
+	}
+	w.WriteHeader(health.StatusCodeHealthy)
+}
+
+func (s *Sender) LivenessCheck(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(health.StatusCodeHealthy)
+}
@@ -0,0 +1,131 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"go.uber.org/zap"
+
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/internal"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/env"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/handler/health"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/sender"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/sender/beb"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/tracing"
+)
+
+const (
+	kafkaBackend     = "kafka"
+	kafkaHandlerName = "kafka-handler"
+)
+
+// compile time check
+var _ sender.GenericSender = &Sender{}
+var _ health.Checker = &Sender{}
+
+var (
+	ErrCannotSendToTopic = errors.New("cannot send to topic")
+)
+
+// Sender is responsible for sending events to the topics of a Kafka cluster.
+type Sender struct {
+	ctx      context.Context
+	logger   *logger.Logger
+	client   sarama.Client
+	producer sarama.SyncProducer
+	envCfg   *env.KafkaConfig
+}
+
+// NewSaramaConfig returns the config of the Kafka client and producer used by the Sender.
+func NewSaramaConfig(envCfg *env.KafkaConfig) (*sarama.Config, error) {
+	version, err := sarama.ParseKafkaVersion(envCfg.Version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Kafka version %q: %w", envCfg.Version, err)
+	}
+	cfg := sarama.NewConfig()
+	cfg.Version = version
+	cfg.ClientID = envCfg.ClientID
+	// the eventing controller creates the topics of the subscribed event types
+	cfg.Metadata.AllowAutoTopicCreation = false
+	cfg.Producer.RequiredAcks = sarama.WaitForAll
+	// the synchronous producer requires the successes to be returned
+	cfg.Producer.Return.Successes = true
+	return cfg, nil
+}
+
+func (s *Sender) URL() string {
+	return strings.Join(s.envCfg.Brokers, ",")
+}
+
+// NewSender returns a new Sender instance with the given Kafka client and producer.
+func NewSender(ctx context.Context, client sarama.Client, producer sarama.SyncProducer, envCfg *env.KafkaConfig, logger *logger.Logger) *Sender {
+	return &Sender{ctx: ctx, client: client, producer: producer, envCfg: envCfg, logger: logger}
+}
+
+// Send dispatches the event to the Kafka topic of its event type. Events without a topic are dropped,
+// since the topics are created for the event types of the subscriptions only.
+func (s *Sender) Send(ctx context.Context, event *event.Event) (sender.PublishResult, error) {
+	msg, err := s.eventToKafkaMsg(event)
+	if err != nil {
+		return nil, err
+	}
+
+	// send the event
+	_, span := tracing.StartPersistSpan(ctx, event, kafkaBackend, msg.Topic)
+	_, _, err = s.producer.SendMessage(msg)
+	if errors.Is(err, sarama.ErrUnknownTopicOrPartition) {
+		span.AddEvent("Dropped event without subscription")
+		tracing.EndSpan(span, nil)
+		s.namedLogger().Debugw("Dropping event without subscription", "topic", msg.Topic, "id", event.ID())
+		return beb.HTTPPublishResult{Status: http.StatusNoContent}, nil
+	}
+	tracing.EndSpan(span, err)
+	if err != nil {
+		switch {
+		case errors.Is(err, sarama.ErrKafkaStorageError):
+			return nil, fmt.Errorf("%w: %v", sender.ErrInsufficientStorage, err)
+		case errors.Is(err, sarama.ErrOutOfBrokers), errors.Is(err, sarama.ErrNotConnected), errors.Is(err, sarama.ErrClosedClient):
+			return nil, fmt.Errorf("%w: %v", sender.ErrNoConnection, err)
+		}
+		s.namedLogger().Errorw("Cannot send event to backend", "error", err)
+		return nil, fmt.Errorf("%w : %v", sender.ErrInternalBackendError, fmt.Errorf("%w, %v", ErrCannotSendToTopic, err))
+	}
+	return beb.HTTPPublishResult{Status: http.StatusNoContent}, nil
+}
+
+// eventToKafkaMsg translates the CloudEvent into a Kafka message, whose value is the structured CloudEvent.
+func (s *Sender) eventToKafkaMsg(event *event.Event) (*sarama.ProducerMessage, error) {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sarama.ProducerMessage{
+		Topic: s.getTopic(event.Type()),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(internal.HeaderContentType), Value: []byte(event.DataContentType())},
+			{Key: []byte(internal.CeSpecVersionHeader), Value: []byte(event.SpecVersion())},
+			{Key: []byte(internal.CeTypeHeader), Value: []byte(event.Type())},
+			{Key: []byte(internal.CeSourceHeader), Value: []byte(event.Source())},
+			{Key: []byte(internal.CeIDHeader), Value: []byte(event.ID())},
+		},
+		Value: sarama.ByteEncoder(eventJSON),
+	}, nil
+}
+
+// getTopic returns the name of the topic which stores the events of the given event type.
+func (s *Sender) getTopic(eventType string) string {
+	return fmt.Sprintf("%s.%s", s.envCfg.TopicPrefix, eventType)
+}
+
+func (s *Sender) namedLogger() *zap.SugaredLogger {
+	return s.logger.WithContext().Named(kafkaHandlerName).With("backend", kafkaBackend)
+}
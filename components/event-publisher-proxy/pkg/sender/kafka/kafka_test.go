@@ -0,0 +1,147 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/env"
+	"github.com/kyma-project/kyma/components/event-publisher-proxy/pkg/sender"
+	testingutils "github.com/kyma-project/kyma/components/event-publisher-proxy/testing"
+)
+
+func TestKafkaMessageSender(t *testing.T) {
+	testCases := []struct {
+		name           string
+		givenSendErr   error
+		wantErr        error
+		wantStatusCode int
+	}{
+		{
+			name:           "send should succeed if the topic exists",
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "send should drop the event if the topic doesn't exist",
+			givenSendErr:   sarama.ErrUnknownTopicOrPartition,
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:         "send should not succeed if the storage of the broker fails",
+			givenSendErr: sarama.ErrKafkaStorageError,
+			wantErr:      sender.ErrInsufficientStorage,
+		},
+		{
+			name:         "send should not succeed if no broker is reachable",
+			givenSendErr: sarama.ErrOutOfBrokers,
+			wantErr:      sender.ErrNoConnection,
+		},
+		{
+			name:         "send should not succeed if the broker fails",
+			givenSendErr: sarama.ErrRequestTimedOut,
+			wantErr:      sender.ErrInternalBackendError,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			ce := createCloudEvent(t)
+			producer := mocks.NewSyncProducer(t, nil)
+			defer func() { assert.NoError(t, producer.Close()) }()
+			if tc.givenSendErr == nil {
+				producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(value []byte) error {
+					gotEvent := cloudevents.NewEvent()
+					if err := json.Unmarshal(value, &gotEvent); err != nil {
+						return err
+					}
+					if gotEvent.ID() != ce.ID() || gotEvent.Type() != ce.Type() {
+						return fmt.Errorf("unexpected event %v", gotEvent)
+					}
+					return nil
+				})
+			} else {
+				producer.ExpectSendMessageAndFail(tc.givenSendErr)
+			}
+			s := newTestSender(t, producer)
+
+			// act
+			status, err := s.Send(context.Background(), ce)
+
+			// assert
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, status.HTTPStatus())
+		})
+	}
+}
+
+func TestEventToKafkaMsg(t *testing.T) {
+	// arrange
+	ce := createCloudEvent(t)
+	s := newTestSender(t, nil)
+
+	// act
+	msg, err := s.eventToKafkaMsg(ce)
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "kyma."+testingutils.CloudEventTypeWithPrefix, msg.Topic)
+	headers := make(map[string]string, len(msg.Headers))
+	for _, header := range msg.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+	assert.Equal(t, ce.ID(), headers["ce-id"])
+	assert.Equal(t, ce.Type(), headers["ce-type"])
+	assert.Equal(t, ce.Source(), headers["ce-source"])
+}
+
+func TestNewSaramaConfig(t *testing.T) {
+	cfg, err := NewSaramaConfig(&env.KafkaConfig{Version: "2.8.0", ClientID: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, sarama.V2_8_0_0, cfg.Version)
+	assert.True(t, cfg.Producer.Return.Successes)
+	assert.False(t, cfg.Metadata.AllowAutoTopicCreation)
+
+	_, err = NewSaramaConfig(&env.KafkaConfig{Version: "invalid"})
+	assert.Error(t, err)
+}
+
+func newTestSender(t *testing.T, producer sarama.SyncProducer) *Sender {
+	mockedLogger, err := logger.New("json", "info")
+	require.NoError(t, err)
+	return &Sender{
+		producer: producer,
+		envCfg:   &env.KafkaConfig{Brokers: []string{"localhost:9092"}, TopicPrefix: "kyma"},
+		logger:   mockedLogger,
+	}
+}
+
+// createCloudEvent build a cloud event.
+func createCloudEvent(t *testing.T) *event.Event {
+	builder := testingutils.NewCloudEventBuilder(
+		testingutils.WithCloudEventType(testingutils.CloudEventTypeWithPrefix),
+	)
+	payload, _ := builder.BuildStructured()
+	newEvent := cloudevents.NewEvent()
+	newEvent.SetType(testingutils.CloudEventTypeWithPrefix)
+	err := json.Unmarshal([]byte(payload), &newEvent)
+	assert.NoError(t, err)
+
+	return &newEvent
+}
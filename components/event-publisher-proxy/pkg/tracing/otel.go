@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	cev2event "github.com/cloudevents/sdk-go/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/kyma-project/kyma/components/event-publisher-proxy"
+
+	publishSpanName = "eventing.publish"
+	persistSpanName = "eventing.persist"
+
+	// EventTypeKey is the span attribute of the event type.
+	EventTypeKey = attribute.Key("eventing.event.type")
+	// EventSourceKey is the span attribute of the event source.
+	EventSourceKey = attribute.Key("eventing.event.source")
+	// EventIDKey is the span attribute of the event ID.
+	EventIDKey = attribute.Key("eventing.event.id")
+	// BackendKey is the span attribute of the eventing backend which persists the event.
+	BackendKey = attribute.Key("eventing.backend")
+	// DestinationKey is the span attribute of the stream subject or the topic the event is persisted to.
+	DestinationKey = attribute.Key("eventing.destination")
+)
+
+// StartPublishSpan starts the span of publishing an event, which continues the trace of the given request headers.
+func StartPublishSpan(ctx context.Context, header http.Header) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+	return otel.Tracer(tracerName).Start(ctx, publishSpanName, trace.WithSpanKind(trace.SpanKindServer))
+}
+
+// StartPersistSpan starts the span of persisting an event in the given backend.
+func StartPersistSpan(ctx context.Context, event *cev2event.Event, backend, destination string) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, persistSpanName, trace.WithSpanKind(trace.SpanKindProducer))
+	SetEventAttributes(span, event)
+	span.SetAttributes(BackendKey.String(backend), DestinationKey.String(destination))
+	return ctx, span
+}
+
+// SetEventAttributes adds the attributes of the given event to the span.
+func SetEventAttributes(span trace.Span, event *cev2event.Event) {
+	span.SetAttributes(EventTypeKey.String(event.Type()), EventSourceKey.String(event.Source()), EventIDKey.String(event.ID()))
+}
+
+// EndSpan ends the span and marks it as failed if the given error isn't nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// EndSpanWithStatus ends the span with the given HTTP status code and marks it as failed for server errors.
+func EndSpanWithStatus(span trace.Span, statusCode int) {
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(statusCode))
+	if statusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+	span.End()
+}
@@ -0,0 +1,130 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	cev2event "github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	ectracing "github.com/kyma-project/kyma/components/eventing-controller/pkg/tracing"
+)
+
+const (
+	testTraceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentSpanID = "00f067aa0ba902b7"
+	testTraceParent  = "00-" + testTraceID + "-" + testParentSpanID + "-01"
+)
+
+func TestStartPublishSpan(t *testing.T) {
+	testCases := []struct {
+		name        string
+		givenHeader http.Header
+		wantTraceID string
+	}{
+		{
+			name:        "should continue the trace of the w3c tracing headers",
+			givenHeader: newHeader(traceParentKey, testTraceParent),
+			wantTraceID: testTraceID,
+		},
+		{
+			name:        "should continue the trace of the b3 tracing headers",
+			givenHeader: newHeader(b3TraceIDKey, testTraceID, b3SpanIDKey, testParentSpanID, b3SampledKey, "1"),
+			wantTraceID: testTraceID,
+		},
+		{
+			name:        "should start a new trace without tracing headers",
+			givenHeader: http.Header{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			recorder := setupTestTracerProvider(t)
+
+			// when
+			_, span := StartPublishSpan(context.Background(), tc.givenHeader)
+			EndSpanWithStatus(span, http.StatusNoContent)
+
+			// then
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, publishSpanName, spans[0].Name())
+			assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+			assert.Equal(t, codes.Unset, spans[0].Status().Code)
+			if tc.wantTraceID == "" {
+				assert.False(t, spans[0].Parent().IsValid())
+				return
+			}
+			assert.Equal(t, tc.wantTraceID, spans[0].SpanContext().TraceID().String())
+			assert.Equal(t, testParentSpanID, spans[0].Parent().SpanID().String())
+		})
+	}
+}
+
+func TestStartPersistSpan(t *testing.T) {
+	// given
+	recorder := setupTestTracerProvider(t)
+	event := cev2event.New()
+	event.SetID("id")
+	event.SetSource("source")
+	event.SetType("sap.kyma.custom.commerce.order.created.v1")
+	ctx, publishSpan := StartPublishSpan(context.Background(), http.Header{})
+
+	// when
+	_, span := StartPersistSpan(ctx, &event, "nats", "kyma.sap.kyma.custom.commerce.order.created.v1")
+	EndSpan(span, errors.New("no stream response"))
+	publishSpan.End()
+
+	// then
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, persistSpanName, spans[0].Name())
+	assert.Equal(t, publishSpan.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.ElementsMatch(t, []interface{}{
+		EventTypeKey.String("sap.kyma.custom.commerce.order.created.v1"),
+		EventSourceKey.String("source"),
+		EventIDKey.String("id"),
+		BackendKey.String("nats"),
+		DestinationKey.String("kyma.sap.kyma.custom.commerce.order.created.v1"),
+	}, toInterfaces(spans[0].Attributes()))
+}
+
+// setupTestTracerProvider registers a tracer provider which records all spans.
+func setupTestTracerProvider(t *testing.T) *tracetest.SpanRecorder {
+	_, err := ectracing.InitTracerProvider(context.Background(), "test", "")
+	require.NoError(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+	return recorder
+}
+
+// newHeader returns the headers of the given key value pairs.
+func newHeader(keysAndValues ...string) http.Header {
+	header := http.Header{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		header.Set(keysAndValues[i], keysAndValues[i+1])
+	}
+	return header
+}
+
+func toInterfaces[T any](values []T) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
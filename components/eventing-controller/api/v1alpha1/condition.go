@@ -47,6 +47,10 @@ const (
 	ConditionReasonNATSSubscriptionActive    ConditionReason = "NATS Subscription active"
 	ConditionReasonNATSSubscriptionNotActive ConditionReason = "NATS Subscription not active"
 
+	// Kafka Conditions
+	ConditionReasonKafkaSubscriptionActive    ConditionReason = "Kafka Subscription active"
+	ConditionReasonKafkaSubscriptionNotActive ConditionReason = "Kafka Subscription not active"
+
 	// Common backend Conditions
 	ConditionReasonSubscriptionControllerReady    ConditionReason = "Subscription controller started"
 	ConditionReasonSubscriptionControllerNotReady ConditionReason = "Subscription controller not ready"
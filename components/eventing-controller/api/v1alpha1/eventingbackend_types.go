@@ -4,24 +4,28 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// +kubebuilder:validation:Enum=BEB;NATS
+// +kubebuilder:validation:Enum=BEB;NATS;Kafka
 
 type BackendType string
 
 const (
-	BEBBackendType  BackendType = "BEB"
-	NatsBackendType BackendType = "NATS"
+	BEBBackendType   BackendType = "BEB"
+	NatsBackendType  BackendType = "NATS"
+	KafkaBackendType BackendType = "Kafka"
 )
 
 // EventingBackendSpec defines the desired state of EventingBackend.
 type EventingBackendSpec struct {
-	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Specifies the backend type to use. Allowed values are "NATS" and "Kafka".
+	// If it's not set, BEB is used if a secret with the BEB label exists, otherwise NATS.
+	// +optional
+	// +kubebuilder:validation:Enum=NATS;Kafka
+	Backend BackendType `json:"backendType,omitempty"`
 }
 
 // EventingBackendStatus defines the observed state of EventingBackend.
 type EventingBackendStatus struct {
-	// Specifies the backend type used. Allowed values are "BEB" and "NATS"
+	// Specifies the backend type used. Allowed values are "BEB", "NATS" and "Kafka"
 	// +optional
 	Backend BackendType `json:"backendType"`
 
@@ -39,6 +43,30 @@ type EventingBackendStatus struct {
 	// The namespace of the secret containing BEB access tokens, required only for BEB
 	// +optional
 	BEBSecretNamespace string `json:"bebSecretNamespace,omitempty"`
+
+	// Migration reports the progress of switching from the previous backend while it's drained
+	// +optional
+	Migration *BackendMigrationStatus `json:"migration,omitempty"`
+}
+
+// BackendMigrationStatus defines the progress of switching the backend while the previous backend runs in parallel.
+type BackendMigrationStatus struct {
+	// Specifies the previous backend which is drained. Allowed values are "BEB", "NATS" and "Kafka"
+	From BackendType `json:"from"`
+
+	// The time when the migration started
+	StartTime metav1.Time `json:"startTime"`
+
+	// Specifies whether the Event Publisher Proxy publishes only to the new backend
+	PublisherSwitched bool `json:"publisherSwitched"`
+
+	// The time when the Event Publisher Proxy switched to the new backend
+	// +optional
+	PublisherSwitchTime *metav1.Time `json:"publisherSwitchTime,omitempty"`
+
+	// The number of messages of the previous backend not delivered yet, reported only for NATS and Kafka
+	// +optional
+	PendingMessages *int64 `json:"pendingMessages,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -47,6 +75,7 @@ type EventingBackendStatus struct {
 // +kubebuilder:printcolumn:name="EventingReady",type=boolean,JSONPath=`.status.eventingReady`
 // +kubebuilder:printcolumn:name="SubscriptionControllerReady",type=string,JSONPath=`.status.conditions[?(@.type=="Subscription Controller Ready")].status`
 // +kubebuilder:printcolumn:name="PublisherProxyReady",type=string,JSONPath=`.status.conditions[?(@.type=="Publisher Proxy Ready")].status`
+// +kubebuilder:printcolumn:name="MigratingFrom",type=string,JSONPath=`.status.migration.from`,priority=1
 
 // EventingBackend is the Schema for the eventingbackends API.
 type EventingBackend struct {
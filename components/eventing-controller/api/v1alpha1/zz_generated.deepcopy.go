@@ -60,6 +60,31 @@ func (in *BEBFilters) DeepCopy() *BEBFilters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendMigrationStatus) DeepCopyInto(out *BackendMigrationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.PublisherSwitchTime != nil {
+		in, out := &in.PublisherSwitchTime, &out.PublisherSwitchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingMessages != nil {
+		in, out := &in.PendingMessages, &out.PendingMessages
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendMigrationStatus.
+func (in *BackendMigrationStatus) DeepCopy() *BackendMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -180,6 +205,11 @@ func (in *EventingBackendStatus) DeepCopyInto(out *EventingBackendStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(BackendMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventingBackendStatus.
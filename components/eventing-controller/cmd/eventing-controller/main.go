@@ -20,8 +20,12 @@ import (
 	"github.com/kyma-project/kyma/components/eventing-controller/pkg/subscriptionmanager"
 	"github.com/kyma-project/kyma/components/eventing-controller/pkg/subscriptionmanager/beb"
 	"github.com/kyma-project/kyma/components/eventing-controller/pkg/subscriptionmanager/jetstream"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/subscriptionmanager/kafka"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/tracing"
 )
 
+const tracingServiceName = "eventing-controller"
+
 func main() {
 	opts := options.New()
 	if err := opts.Parse(); err != nil {
@@ -44,6 +48,21 @@ func main() {
 	// prepare the setup logger
 	setupLogger := ctrLogger.WithContext().Named("setup")
 
+	// init the tracing of the dispatched events
+	tracingConfig, err := env.GetTracingConfig()
+	if err != nil {
+		setupLogger.Fatalw("Failed to load configuration", "error", err)
+	}
+	shutdownTracing, err := tracing.InitTracerProvider(context.Background(), tracingServiceName, tracingConfig.OTLPEndpoint)
+	if err != nil {
+		setupLogger.Fatalw("Failed to initialize tracing", "error", err)
+	}
+	defer func() {
+		if err = shutdownTracing(context.Background()); err != nil {
+			setupLogger.Warnw("Failed to flush spans", "error", err)
+		}
+	}()
+
 	// Instantiate and initialize all the subscription managers.
 	restCfg := ctrl.GetConfigOrDie()
 	scheme := runtime.NewScheme()
@@ -76,6 +95,19 @@ func main() {
 		}
 	}
 
+	// the Kafka backend is only available if the Kafka brokers are configured
+	var kafkaSubMgr subscriptionmanager.Manager
+	kafkaConfig, err := env.GetKafkaConfig()
+	if err != nil {
+		setupLogger.Fatalw("Failed to load configuration", "backend", v1alpha1.KafkaBackendType, "error", err)
+	}
+	if kafkaConfig.IsEnabled() {
+		kafkaSubMgr = kafka.NewSubscriptionManager(restCfg, kafkaConfig, opts.MetricsAddr, metricsCollector, ctrLogger)
+		if err = kafka.AddToScheme(scheme); err != nil {
+			setupLogger.Fatalw("Failed to start subscription manager", "backend", v1alpha1.KafkaBackendType, "error", err)
+		}
+	}
+
 	// Init the manager.
 	mgr, err := ctrl.NewManager(restCfg, ctrl.Options{
 		Scheme:                 scheme,
@@ -96,6 +128,12 @@ func main() {
 		setupLogger.Fatalw("Failed to initialize subscription manager", "backend", v1alpha1.BEBBackendType, "error", err)
 	}
 
+	if kafkaSubMgr != nil {
+		if err = kafkaSubMgr.Init(mgr); err != nil {
+			setupLogger.Fatalw("Failed to initialize subscription manager", "backend", v1alpha1.KafkaBackendType, "error", err)
+		}
+	}
+
 	if opts.EnableNewCRDVersion {
 		setupLogger.Infow("Starting the webhook server")
 
@@ -118,7 +156,8 @@ func main() {
 	// Start the backend manager.
 	ctx := context.Background()
 	recorder := mgr.GetEventRecorderFor("backend-controller")
-	backendReconciler := backend.NewReconciler(ctx, natsSubMgr, natsConfig, bebSubMgr, mgr.GetClient(), ctrLogger, recorder)
+	backendReconciler := backend.NewReconciler(ctx, natsSubMgr, natsConfig, bebSubMgr, kafkaSubMgr, kafkaConfig,
+		mgr.GetClient(), ctrLogger, recorder)
 	if err = backendReconciler.SetupWithManager(mgr); err != nil {
 		setupLogger.Fatalw("Failed to start backend controller", "error", err)
 	}
@@ -0,0 +1,221 @@
+package backend
+
+import (
+	"time"
+
+	"golang.org/x/xerrors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1alpha1 "github.com/kyma-project/kyma/components/eventing-controller/api/v1alpha1"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/subscriptionmanager"
+)
+
+const (
+	// migrationCheckInterval is how often the progress of the migration is checked if nothing else triggers the reconciliation.
+	migrationCheckInterval = 10 * time.Second
+)
+
+// migration tracks switching the backend while the subscription manager of the previous backend keeps running.
+type migration struct {
+	from                eventingv1alpha1.BackendType
+	startTime           metav1.Time
+	publisherSwitchTime *metav1.Time
+	pendingMessages     *int64
+}
+
+func (m *migration) status() *eventingv1alpha1.BackendMigrationStatus {
+	return &eventingv1alpha1.BackendMigrationStatus{
+		From:                m.from,
+		StartTime:           m.startTime,
+		PublisherSwitched:   m.publisherSwitchTime != nil,
+		PublisherSwitchTime: m.publisherSwitchTime,
+		PendingMessages:     m.pendingMessages,
+	}
+}
+
+// drainOrStopOtherControllers drains or stops the subscription managers of all backends except the active one.
+// Only one backend is drained at a time, so the other backends are stopped immediately while a migration is in progress.
+func (r *Reconciler) drainOrStopOtherControllers(active eventingv1alpha1.BackendType) error {
+	// switching back to the backend which is drained makes the migration obsolete. Its subscription manager
+	// doesn't reconcile the subscriptions anymore, so it's stopped without cleanup and started again.
+	if r.migration != nil && r.migration.from == active {
+		if err := r.stopDrainedController(active, false); err != nil {
+			return err
+		}
+		r.migration = nil
+	}
+	for _, backend := range []eventingv1alpha1.BackendType{
+		eventingv1alpha1.NatsBackendType, eventingv1alpha1.BEBBackendType, eventingv1alpha1.KafkaBackendType,
+	} {
+		if backend == active {
+			continue
+		}
+		if r.migration != nil && r.migration.from != backend {
+			if err := r.stopController(backend); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := r.drainOrStopController(backend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drainOrStopController keeps the subscription manager of the previous backend dispatching if the migration mode is enabled,
+// otherwise it stops the subscription manager immediately. The drained subscription manager doesn't reconcile the subscriptions,
+// so their finalizers and statuses are owned by the subscription manager of the active backend.
+func (r *Reconciler) drainOrStopController(from eventingv1alpha1.BackendType) error {
+	if r.migration != nil && r.migration.from == from {
+		return nil
+	}
+	migratable, ok := r.subscriptionManager(from).(subscriptionmanager.Migratable)
+	if r.cfg.MigrationDrainPeriod <= 0 || !r.isControllerStarted(from) || !ok {
+		return r.stopController(from)
+	}
+	if err := migratable.StopReconciling(); err != nil {
+		r.namedLogger().Warnw("Failed to drain the previous backend, stopping it", "from", from, "error", err)
+		return r.stopController(from)
+	}
+	r.startMigration(from)
+	return nil
+}
+
+// startMigration starts draining the previous backend.
+func (r *Reconciler) startMigration(from eventingv1alpha1.BackendType) {
+	r.migration = &migration{from: from, startTime: metav1.Now()}
+	r.namedLogger().Infow("Started backend migration, the previous backend is drained", "from", from)
+}
+
+// syncMigration stops the previous backend once the Event Publisher Proxy is switched to the new backend
+// and the previous backend is drained or the drain period is over. It returns the time after which
+// the migration should be checked again, or zero if no migration is in progress.
+func (r *Reconciler) syncMigration(publisher *appsv1.Deployment) (time.Duration, error) {
+	m := r.migration
+	if m == nil {
+		return 0, nil
+	}
+
+	// the publisher proxy pods of both backends run during the rolling update, so the previous backend still receives events
+	if m.publisherSwitchTime == nil {
+		if !isPublisherRolledOut(publisher) {
+			return migrationCheckInterval, nil
+		}
+		now := metav1.Now()
+		m.publisherSwitchTime = &now
+		r.namedLogger().Infow("Event Publisher switched to the new backend", "from", m.from)
+	}
+
+	drained := r.isDrained(m)
+	remaining := r.cfg.MigrationDrainPeriod - time.Since(m.publisherSwitchTime.Time)
+	if !drained && remaining > 0 {
+		if remaining < migrationCheckInterval {
+			return remaining, nil
+		}
+		return migrationCheckInterval, nil
+	}
+
+	if !drained {
+		r.namedLogger().Warnw("Drain period is over, stopping the previous backend", "from", m.from, "pendingMessages", m.pendingMessages)
+	}
+	if err := r.stopDrainedController(m.from, true); err != nil {
+		return 0, err
+	}
+	r.migration = nil
+	r.namedLogger().Infow("Finished backend migration", "from", m.from, "duration", time.Since(m.startTime.Time))
+	return 0, nil
+}
+
+// isDrained checks if the previous backend has no pending messages. Backends which can't report
+// the pending messages are never drained, so they keep running for the whole drain period.
+func (r *Reconciler) isDrained(m *migration) bool {
+	drainer, ok := r.subscriptionManager(m.from).(subscriptionmanager.Drainer)
+	if !ok {
+		return false
+	}
+	pending, err := drainer.PendingMessages()
+	if err != nil {
+		r.namedLogger().Warnw("Failed to get pending messages of the previous backend", "from", m.from, "error", err)
+		m.pendingMessages = nil
+		return false
+	}
+	m.pendingMessages = &pending
+	return pending == 0
+}
+
+// stopDrainedController stops the subscription manager of the drained backend. The subscriptions are left unchanged,
+// since they are reconciled by the subscription manager of the active backend. If runCleanup is true, the backend
+// artifacts of the subscriptions are deleted.
+func (r *Reconciler) stopDrainedController(backend eventingv1alpha1.BackendType, runCleanup bool) error {
+	migratable, ok := r.subscriptionManager(backend).(subscriptionmanager.Migratable)
+	if !ok {
+		return r.stopController(backend)
+	}
+	if err := migratable.StopDispatching(runCleanup); err != nil {
+		return xerrors.Errorf("failed to stop drained %s subscription manager: %v", backend, err)
+	}
+	r.setControllerStarted(backend, false)
+	r.namedLogger().Infow("Drained subscription manager was stopped", "from", backend, "cleanup", runCleanup)
+	return nil
+}
+
+func (r *Reconciler) subscriptionManager(backend eventingv1alpha1.BackendType) subscriptionmanager.Manager {
+	switch backend {
+	case eventingv1alpha1.NatsBackendType:
+		return r.natsSubMgr
+	case eventingv1alpha1.BEBBackendType:
+		return r.bebSubMgr
+	case eventingv1alpha1.KafkaBackendType:
+		return r.kafkaSubMgr
+	}
+	return nil
+}
+
+func (r *Reconciler) setControllerStarted(backend eventingv1alpha1.BackendType, started bool) {
+	switch backend {
+	case eventingv1alpha1.NatsBackendType:
+		r.natsSubMgrStarted = started
+	case eventingv1alpha1.BEBBackendType:
+		r.bebSubMgrStarted = started
+	case eventingv1alpha1.KafkaBackendType:
+		r.kafkaSubMgrStarted = started
+	}
+}
+
+func (r *Reconciler) isControllerStarted(backend eventingv1alpha1.BackendType) bool {
+	switch backend {
+	case eventingv1alpha1.NatsBackendType:
+		return r.natsSubMgrStarted
+	case eventingv1alpha1.BEBBackendType:
+		return r.bebSubMgrStarted
+	case eventingv1alpha1.KafkaBackendType:
+		return r.kafkaSubMgrStarted
+	}
+	return false
+}
+
+func (r *Reconciler) stopController(backend eventingv1alpha1.BackendType) error {
+	switch backend {
+	case eventingv1alpha1.NatsBackendType:
+		return r.stopNATSController()
+	case eventingv1alpha1.BEBBackendType:
+		return r.stopBEBController()
+	case eventingv1alpha1.KafkaBackendType:
+		return r.stopKafkaController()
+	}
+	return nil
+}
+
+// isPublisherRolledOut checks if all pods of the publisher deployment are updated and available.
+func isPublisherRolledOut(publisher *appsv1.Deployment) bool {
+	if publisher == nil || publisher.Spec.Replicas == nil {
+		return false
+	}
+	replicas := *publisher.Spec.Replicas
+	return publisher.Status.ObservedGeneration >= publisher.Generation &&
+		publisher.Status.UpdatedReplicas == replicas &&
+		publisher.Status.Replicas == replicas &&
+		publisher.Status.AvailableReplicas == replicas
+}
@@ -0,0 +1,410 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+
+	eventingv1alpha1 "github.com/kyma-project/kyma/components/eventing-controller/api/v1alpha1"
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/deployment"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/env"
+	"github.com/kyma-project/kyma/components/eventing-controller/utils"
+)
+
+// migratableSubMgrMock is the subscription manager which can keep dispatching without reconciling the subscriptions.
+type migratableSubMgrMock struct {
+	SubMgrMock
+	stopReconcilingErr, stopDispatchingErr                                           error
+	StopReconcilingCalled, StopDispatchingWithCleanup, StopDispatchingWithoutCleanup bool
+}
+
+func (t *migratableSubMgrMock) StopReconciling() error {
+	t.StopReconcilingCalled = true
+	return t.stopReconcilingErr
+}
+
+func (t *migratableSubMgrMock) StopDispatching(runCleanup bool) error {
+	if runCleanup {
+		t.StopDispatchingWithCleanup = true
+	} else {
+		t.StopDispatchingWithoutCleanup = true
+	}
+	return t.stopDispatchingErr
+}
+
+// drainerSubMgrMock is the subscription manager which reports its pending messages.
+type drainerSubMgrMock struct {
+	migratableSubMgrMock
+	pending    int64
+	pendingErr error
+}
+
+func (t *drainerSubMgrMock) PendingMessages() (int64, error) {
+	return t.pending, t.pendingErr
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestSyncMigration(t *testing.T) {
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	rolledOutPublisher := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: utils.Int32Ptr(2)},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           2,
+				UpdatedReplicas:    2,
+				AvailableReplicas:  2,
+			},
+		}
+	}
+	rollingOutPublisher := rolledOutPublisher()
+	rollingOutPublisher.Status.Replicas = 3
+
+	switchedAgo := func(d time.Duration) *metav1.Time {
+		switchTime := metav1.NewTime(time.Now().Add(-d))
+		return &switchTime
+	}
+
+	testCases := []struct {
+		name             string
+		givenMigration   *migration
+		givenPublisher   *appsv1.Deployment
+		givenNATSSubMgr  *drainerSubMgrMock
+		givenNATSStopErr error
+		wantFinished     bool
+		wantRequeue      bool
+		wantSwitched     bool
+		wantPending      *int64
+		wantNATSStopped  bool
+		wantErrorOnStop  bool
+	}{
+		{
+			name:            "should wait for publisher rollout",
+			givenMigration:  &migration{from: eventingv1alpha1.NatsBackendType},
+			givenPublisher:  rollingOutPublisher,
+			givenNATSSubMgr: &drainerSubMgrMock{},
+			wantRequeue:     true,
+		},
+		{
+			name:            "should stop drained NATS after publisher rollout",
+			givenMigration:  &migration{from: eventingv1alpha1.NatsBackendType},
+			givenPublisher:  rolledOutPublisher(),
+			givenNATSSubMgr: &drainerSubMgrMock{},
+			wantFinished:    true,
+			wantNATSStopped: true,
+		},
+		{
+			name:            "should wait for NATS with pending messages",
+			givenMigration:  &migration{from: eventingv1alpha1.NatsBackendType},
+			givenPublisher:  rolledOutPublisher(),
+			givenNATSSubMgr: &drainerSubMgrMock{pending: 5},
+			wantRequeue:     true,
+			wantSwitched:    true,
+			wantPending:     int64Ptr(5),
+		},
+		{
+			name:            "should wait for NATS which fails to report pending messages",
+			givenMigration:  &migration{from: eventingv1alpha1.NatsBackendType},
+			givenPublisher:  rolledOutPublisher(),
+			givenNATSSubMgr: &drainerSubMgrMock{pendingErr: errors.New("no connection")},
+			wantRequeue:     true,
+			wantSwitched:    true,
+		},
+		{
+			name:            "should stop NATS with pending messages after drain period",
+			givenMigration:  &migration{from: eventingv1alpha1.NatsBackendType, publisherSwitchTime: switchedAgo(2 * time.Minute)},
+			givenPublisher:  rolledOutPublisher(),
+			givenNATSSubMgr: &drainerSubMgrMock{pending: 5},
+			wantFinished:    true,
+			wantNATSStopped: true,
+		},
+		{
+			name:             "should keep migration when stopping NATS fails",
+			givenMigration:   &migration{from: eventingv1alpha1.NatsBackendType},
+			givenPublisher:   rolledOutPublisher(),
+			givenNATSSubMgr:  &drainerSubMgrMock{},
+			givenNATSStopErr: errors.New("failed to stop"),
+			wantNATSStopped:  true,
+			wantErrorOnStop:  true,
+			wantSwitched:     true,
+			wantPending:      int64Ptr(0),
+		},
+		{
+			name:            "should keep BEB running for drain period",
+			givenMigration:  &migration{from: eventingv1alpha1.BEBBackendType, publisherSwitchTime: switchedAgo(30 * time.Second)},
+			givenPublisher:  rolledOutPublisher(),
+			givenNATSSubMgr: &drainerSubMgrMock{},
+			wantRequeue:     true,
+			wantSwitched:    true,
+		},
+		{
+			name:            "should stop BEB after drain period",
+			givenMigration:  &migration{from: eventingv1alpha1.BEBBackendType, publisherSwitchTime: switchedAgo(2 * time.Minute)},
+			givenPublisher:  rolledOutPublisher(),
+			givenNATSSubMgr: &drainerSubMgrMock{},
+			wantFinished:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			tc.givenNATSSubMgr.stopDispatchingErr = tc.givenNATSStopErr
+			bebSubMgr := &migratableSubMgrMock{}
+			r := &Reconciler{
+				natsSubMgr:        tc.givenNATSSubMgr,
+				natsSubMgrStarted: tc.givenMigration.from == eventingv1alpha1.NatsBackendType,
+				bebSubMgr:         bebSubMgr,
+				bebSubMgrStarted:  tc.givenMigration.from == eventingv1alpha1.BEBBackendType,
+				logger:            defaultLogger,
+				cfg:               env.BackendConfig{MigrationDrainPeriod: time.Minute},
+				migration:         tc.givenMigration,
+			}
+
+			// when
+			requeueAfter, err := r.syncMigration(tc.givenPublisher)
+
+			// then
+			if tc.wantErrorOnStop {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantRequeue, requeueAfter > 0)
+			assert.Equal(t, tc.wantNATSStopped, tc.givenNATSSubMgr.StopDispatchingWithCleanup)
+			// the drained backend is stopped without resetting the statuses of the subscriptions
+			assert.False(t, tc.givenNATSSubMgr.StopCalledWithCleanup)
+			assert.False(t, bebSubMgr.StopCalledWithCleanup)
+			if tc.wantFinished {
+				assert.Nil(t, r.migration)
+				assert.Equal(t, tc.givenMigration.from == eventingv1alpha1.BEBBackendType, bebSubMgr.StopDispatchingWithCleanup)
+				return
+			}
+			require.NotNil(t, r.migration)
+			status := r.migration.status()
+			assert.Equal(t, tc.wantSwitched, status.PublisherSwitched)
+			assert.Equal(t, tc.wantPending, status.PendingMessages)
+		})
+	}
+}
+
+func TestDrainOrStopController(t *testing.T) {
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                   string
+		givenDrainPeriod       time.Duration
+		givenStarted           bool
+		givenStopReconcileErr  error
+		wantMigrationFrom      eventingv1alpha1.BackendType
+		wantStopWithCleanup    bool
+		wantReconcilingStopped bool
+	}{
+		{
+			name:                "should stop controller when migration mode is disabled",
+			givenStarted:        true,
+			wantStopWithCleanup: true,
+		},
+		{
+			name:                   "should drain controller without reconciling when migration mode is enabled",
+			givenDrainPeriod:       time.Minute,
+			givenStarted:           true,
+			wantMigrationFrom:      eventingv1alpha1.NatsBackendType,
+			wantReconcilingStopped: true,
+		},
+		{
+			name:                   "should stop controller which fails to stop reconciling",
+			givenDrainPeriod:       time.Minute,
+			givenStarted:           true,
+			givenStopReconcileErr:  errors.New("v1alpha2 subscriptions"),
+			wantStopWithCleanup:    true,
+			wantReconcilingStopped: true,
+		},
+		{
+			name:             "should not migrate from controller which isn't started",
+			givenDrainPeriod: time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			natsSubMgr := &migratableSubMgrMock{stopReconcilingErr: tc.givenStopReconcileErr}
+			r := &Reconciler{
+				natsSubMgr:        natsSubMgr,
+				natsSubMgrStarted: tc.givenStarted,
+				logger:            defaultLogger,
+				cfg:               env.BackendConfig{MigrationDrainPeriod: tc.givenDrainPeriod},
+			}
+
+			// when
+			err := r.drainOrStopController(eventingv1alpha1.NatsBackendType)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStopWithCleanup, natsSubMgr.StopCalledWithCleanup)
+			assert.Equal(t, tc.wantReconcilingStopped, natsSubMgr.StopReconcilingCalled)
+			if tc.wantMigrationFrom == "" {
+				assert.Nil(t, r.migration)
+				return
+			}
+			require.NotNil(t, r.migration)
+			assert.Equal(t, tc.wantMigrationFrom, r.migration.from)
+		})
+	}
+
+	t.Run("should stop controller which can't be drained", func(t *testing.T) {
+		// given
+		natsSubMgr := &SubMgrMock{}
+		r := &Reconciler{
+			natsSubMgr:        natsSubMgr,
+			natsSubMgrStarted: true,
+			logger:            defaultLogger,
+			cfg:               env.BackendConfig{MigrationDrainPeriod: time.Minute},
+		}
+
+		// when
+		err := r.drainOrStopController(eventingv1alpha1.NatsBackendType)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, natsSubMgr.StopCalledWithCleanup)
+		assert.Nil(t, r.migration)
+	})
+}
+
+func TestDrainOrStopOtherControllers(t *testing.T) {
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                      string
+		givenActive               eventingv1alpha1.BackendType
+		givenMigration            *migration
+		wantMigrationFrom         eventingv1alpha1.BackendType
+		wantNATSStopped           bool
+		wantBEBStopped            bool
+		wantKafkaStopped          bool
+		wantBEBDispatchingStopped bool
+	}{
+		{
+			name:              "should drain the first started controller and stop the others",
+			givenActive:       eventingv1alpha1.KafkaBackendType,
+			wantMigrationFrom: eventingv1alpha1.NatsBackendType,
+			wantBEBStopped:    true,
+		},
+		{
+			name:              "should keep draining the controller of a migration in progress",
+			givenActive:       eventingv1alpha1.NatsBackendType,
+			givenMigration:    &migration{from: eventingv1alpha1.BEBBackendType},
+			wantMigrationFrom: eventingv1alpha1.BEBBackendType,
+			wantKafkaStopped:  true,
+		},
+		{
+			name:                      "should drop the migration when switching back to the drained backend",
+			givenActive:               eventingv1alpha1.BEBBackendType,
+			givenMigration:            &migration{from: eventingv1alpha1.BEBBackendType},
+			wantMigrationFrom:         eventingv1alpha1.NatsBackendType,
+			wantKafkaStopped:          true,
+			wantBEBDispatchingStopped: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			natsSubMgr, bebSubMgr, kafkaSubMgr := &migratableSubMgrMock{}, &migratableSubMgrMock{}, &migratableSubMgrMock{}
+			r := &Reconciler{
+				natsSubMgr:         natsSubMgr,
+				natsSubMgrStarted:  tc.givenActive != eventingv1alpha1.NatsBackendType,
+				bebSubMgr:          bebSubMgr,
+				bebSubMgrStarted:   tc.givenActive != eventingv1alpha1.BEBBackendType,
+				kafkaSubMgr:        kafkaSubMgr,
+				kafkaSubMgrStarted: tc.givenActive != eventingv1alpha1.KafkaBackendType,
+				migration:          tc.givenMigration,
+				logger:             defaultLogger,
+				cfg:                env.BackendConfig{MigrationDrainPeriod: time.Minute},
+			}
+			if tc.givenMigration != nil {
+				// the drained backend is started, even if it's the active one
+				r.setControllerStarted(tc.givenMigration.from, true)
+			}
+
+			// when
+			err := r.drainOrStopOtherControllers(tc.givenActive)
+
+			// then
+			require.NoError(t, err)
+			require.NotNil(t, r.migration)
+			assert.Equal(t, tc.wantMigrationFrom, r.migration.from)
+			assert.Equal(t, tc.wantNATSStopped, natsSubMgr.StopCalledWithCleanup)
+			assert.Equal(t, tc.wantBEBStopped, bebSubMgr.StopCalledWithCleanup)
+			assert.Equal(t, tc.wantKafkaStopped, kafkaSubMgr.StopCalledWithCleanup)
+			// the drained backend which becomes active again is stopped without cleanup, so it's started again
+			assert.Equal(t, tc.wantBEBDispatchingStopped, bebSubMgr.StopDispatchingWithoutCleanup)
+			if tc.wantBEBDispatchingStopped {
+				assert.False(t, r.bebSubMgrStarted)
+			}
+		})
+	}
+}
+
+func TestDeletePublisherProxySecretAfterMigration(t *testing.T) {
+	testCases := []struct {
+		name           string
+		givenMigration *migration
+		wantDeleted    bool
+	}{
+		{
+			name:           "should keep secret while migrating from BEB",
+			givenMigration: &migration{from: eventingv1alpha1.BEBBackendType},
+		},
+		{
+			name:        "should delete secret once migration is finished",
+			wantDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: deployment.PublisherName, Namespace: deployment.PublisherNamespace},
+			}
+			r := setup(secret)
+			r.migration = tc.givenMigration
+
+			// when
+			err := r.deletePublisherProxySecretAfterMigration(ctx)
+
+			// then
+			require.NoError(t, err)
+			err = r.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+			assert.Equal(t, tc.wantDeleted, k8serrors.IsNotFound(err))
+		})
+	}
+}
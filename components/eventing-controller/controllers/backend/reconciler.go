@@ -77,27 +77,36 @@ type Reconciler struct {
 	natsSubMgrStarted bool
 	bebSubMgr         subscriptionmanager.Manager
 	bebSubMgrStarted  bool
-	logger            *logger.Logger
-	record            record.EventRecorder
-	cfg               env.BackendConfig
+	// kafkaSubMgr is nil if no Kafka brokers are configured
+	kafkaSubMgr        subscriptionmanager.Manager
+	kafkaConfig        env.KafkaConfig
+	kafkaSubMgrStarted bool
+	logger             *logger.Logger
+	record             record.EventRecorder
+	cfg                env.BackendConfig
 	// backendType is the type of the backend which the reconciler detects at runtime
 	backendType eventingv1alpha1.BackendType
+	// migration is set while the subscription manager of the previous backend is drained
+	migration *migration
 	// The OAuth2 credentials that are passed to the BEB subscription reconciler
 	oauth2ClientID     []byte
 	oauth2ClientSecret []byte
 }
 
-func NewReconciler(ctx context.Context, natsSubMgr subscriptionmanager.Manager, natsConfig env.NatsConfig, bebSubMgr subscriptionmanager.Manager, client client.Client, logger *logger.Logger, recorder record.EventRecorder) *Reconciler {
+func NewReconciler(ctx context.Context, natsSubMgr subscriptionmanager.Manager, natsConfig env.NatsConfig, bebSubMgr subscriptionmanager.Manager,
+	kafkaSubMgr subscriptionmanager.Manager, kafkaConfig env.KafkaConfig, client client.Client, logger *logger.Logger, recorder record.EventRecorder) *Reconciler {
 	cfg := env.GetBackendConfig()
 	return &Reconciler{
-		ctx:        ctx,
-		natsSubMgr: natsSubMgr,
-		natsConfig: natsConfig,
-		bebSubMgr:  bebSubMgr,
-		Client:     client,
-		logger:     logger,
-		record:     recorder,
-		cfg:        cfg,
+		ctx:         ctx,
+		natsSubMgr:  natsSubMgr,
+		natsConfig:  natsConfig,
+		bebSubMgr:   bebSubMgr,
+		kafkaSubMgr: kafkaSubMgr,
+		kafkaConfig: kafkaConfig,
+		Client:      client,
+		logger:      logger,
+		record:      recorder,
+		cfg:         cfg,
 	}
 }
 
@@ -121,6 +130,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result
 	// if something breaks during reconciliation, the condition and eventingReady is updated to false.
 	defaultStatus := getDefaultBackendStatus()
 
+	// the backend type set in the EventingBackend spec takes precedence over the BEB secret
+	desiredBackendType, err := r.getDesiredBackendType(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	switch desiredBackendType {
+	case eventingv1alpha1.KafkaBackendType:
+		return r.reconcileKafkaBackend(ctx, &defaultStatus)
+	case eventingv1alpha1.NatsBackendType:
+		return r.reconcileNATSBackend(ctx, &defaultStatus)
+	}
+
 	if err := r.List(ctx, &secretList, client.MatchingLabels{
 		BEBBackendSecretLabelKey: BEBBackendSecretLabelValue,
 	}); err != nil {
@@ -160,11 +181,11 @@ func (r *Reconciler) reconcileNATSBackend(ctx context.Context, backendStatus *ev
 		return ctrl.Result{}, errors.Wrapf(err, "create or update EventingBackend failed, type: %s", eventingv1alpha1.NatsBackendType)
 	}
 
-	// Stop the BEB subscription controller
-	if err := r.stopBEBController(); err != nil {
+	// Drain or stop the BEB and Kafka subscription controllers
+	if err := r.drainOrStopOtherControllers(r.backendType); err != nil {
 		backendStatus.SetSubscriptionControllerReadyCondition(false, eventingv1alpha1.ConditionReasonControllerStopFailed, err.Error())
 		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
-			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while stopping BEB controller")
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while stopping BEB or Kafka controller")
 		}
 		return ctrl.Result{}, err
 	}
@@ -178,33 +199,42 @@ func (r *Reconciler) reconcileNATSBackend(ctx context.Context, backendStatus *ev
 		return ctrl.Result{}, err
 	}
 
-	// Delete secret for publisher proxy if it exists
-	err = r.DeletePublisherProxySecret(ctx)
+	// CreateOrUpdate deployment for publisher proxy
+	publisher, err := r.CreateOrUpdatePublisherProxy(ctx, r.backendType)
 	if err != nil {
-		backendStatus.SetPublisherReadyCondition(false, eventingv1alpha1.ConditionReasonPublisherProxySecretError, err.Error())
+		backendStatus.SetPublisherReadyCondition(false, eventingv1alpha1.ConditionReasonPublisherProxySyncFailed, err.Error())
 		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
-			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while deleting Event Publisher secret")
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while creating/updating Event Publisher deployment")
 		}
-		return ctrl.Result{}, errors.Wrapf(err, "delete eventing Event Publisher secret failed")
+		return ctrl.Result{}, err
 	}
 
-	// CreateOrUpdate deployment for publisher proxy
-	publisher, err := r.CreateOrUpdatePublisherProxy(ctx, r.backendType)
+	// Stop the subscription controller of the previous backend once it's drained
+	requeueAfter, err := r.syncMigration(publisher)
 	if err != nil {
-		backendStatus.SetPublisherReadyCondition(false, eventingv1alpha1.ConditionReasonPublisherProxySyncFailed, err.Error())
+		backendStatus.SetSubscriptionControllerReadyCondition(false, eventingv1alpha1.ConditionReasonControllerStopFailed, err.Error())
 		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
-			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while creating/updating Event Publisher deployment")
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while stopping drained controller of the previous backend")
 		}
 		return ctrl.Result{}, err
 	}
 
+	// Delete secret for publisher proxy if it exists, once the migration no longer needs it
+	if err := r.deletePublisherProxySecretAfterMigration(ctx); err != nil {
+		backendStatus.SetPublisherReadyCondition(false, eventingv1alpha1.ConditionReasonPublisherProxySecretError, err.Error())
+		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while deleting Event Publisher secret")
+		}
+		return ctrl.Result{}, errors.Wrapf(err, "delete eventing Event Publisher secret failed")
+	}
+
 	if r.natsSubMgrStarted && !backendStatus.IsSubscriptionControllerStatusReady() {
 		backendStatus.SetSubscriptionControllerReadyCondition(true, eventingv1alpha1.ConditionReasonSubscriptionControllerReady, "")
 	}
 	// CreateOrUpdate status of the CR
 	// Get publisher proxy ready status
 	err = r.syncBackendStatus(ctx, backendStatus, publisher)
-	return ctrl.Result{}, err
+	return ctrl.Result{RequeueAfter: requeueAfter}, err
 }
 
 func (r *Reconciler) reconcileBEBBackend(ctx context.Context, bebSecret *v1.Secret, backendStatus *eventingv1alpha1.EventingBackendStatus) (ctrl.Result, error) {
@@ -221,11 +251,11 @@ func (r *Reconciler) reconcileBEBBackend(ctx context.Context, bebSecret *v1.Secr
 		return ctrl.Result{}, errors.Wrapf(err, "create/update EventingBackend failed, type: %s", eventingv1alpha1.BEBBackendType)
 	}
 
-	// Stop the NATS subscription controller
-	if err := r.stopNATSController(); err != nil {
+	// Drain or stop the NATS and Kafka subscription controllers
+	if err := r.drainOrStopOtherControllers(r.backendType); err != nil {
 		backendStatus.SetSubscriptionControllerReadyCondition(false, eventingv1alpha1.ConditionReasonControllerStopFailed, err.Error())
 		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
-			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while stopping NATS controller")
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while stopping NATS or Kafka controller")
 		}
 		return ctrl.Result{}, err
 	}
@@ -279,6 +309,16 @@ func (r *Reconciler) reconcileBEBBackend(ctx context.Context, bebSecret *v1.Secr
 		return ctrl.Result{}, err
 	}
 
+	// Stop the subscription controller of the previous backend once it's drained
+	requeueAfter, err := r.syncMigration(publisherDeploy)
+	if err != nil {
+		backendStatus.SetSubscriptionControllerReadyCondition(false, eventingv1alpha1.ConditionReasonControllerStopFailed, err.Error())
+		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while stopping drained controller of the previous backend")
+		}
+		return ctrl.Result{}, err
+	}
+
 	if r.bebSubMgrStarted && !backendStatus.IsSubscriptionControllerStatusReady() {
 		backendStatus.SetSubscriptionControllerReadyCondition(true, eventingv1alpha1.ConditionReasonSubscriptionControllerReady, "")
 	}
@@ -289,7 +329,75 @@ func (r *Reconciler) reconcileBEBBackend(ctx context.Context, bebSecret *v1.Secr
 		return ctrl.Result{}, xerrors.Errorf("failed to create/update %s EventingBackend status: %v", r.backendType, err)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *Reconciler) reconcileKafkaBackend(ctx context.Context, backendStatus *eventingv1alpha1.EventingBackendStatus) (ctrl.Result, error) {
+	r.backendType = eventingv1alpha1.KafkaBackendType
+	backendStatus.Backend = r.backendType
+	// CreateOrUpdate CR with Kafka
+	err := r.CreateOrUpdateBackendCR(ctx)
+	if err != nil {
+		backendStatus.SetSubscriptionControllerReadyCondition(false, eventingv1alpha1.ConditionReasonBackendCRSyncFailed, err.Error())
+		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while creating/updating of EventingBackend")
+		}
+		return ctrl.Result{}, errors.Wrapf(err, "create or update EventingBackend failed, type: %s", eventingv1alpha1.KafkaBackendType)
+	}
+
+	// Drain or stop the NATS and BEB subscription controllers
+	if err := r.drainOrStopOtherControllers(r.backendType); err != nil {
+		backendStatus.SetSubscriptionControllerReadyCondition(false, eventingv1alpha1.ConditionReasonControllerStopFailed, err.Error())
+		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while stopping NATS or BEB controller")
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Start the Kafka subscription controller
+	if err := r.startKafkaController(); err != nil {
+		backendStatus.SetSubscriptionControllerReadyCondition(false, eventingv1alpha1.ConditionReasonControllerStartFailed, err.Error())
+		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while starting Kafka controller")
+		}
+		return ctrl.Result{}, err
+	}
+
+	// CreateOrUpdate deployment for publisher proxy
+	publisher, err := r.CreateOrUpdatePublisherProxy(ctx, r.backendType)
+	if err != nil {
+		backendStatus.SetPublisherReadyCondition(false, eventingv1alpha1.ConditionReasonPublisherProxySyncFailed, err.Error())
+		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while creating/updating Event Publisher deployment")
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Stop the subscription controller of the previous backend once it's drained
+	requeueAfter, err := r.syncMigration(publisher)
+	if err != nil {
+		backendStatus.SetSubscriptionControllerReadyCondition(false, eventingv1alpha1.ConditionReasonControllerStopFailed, err.Error())
+		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while stopping drained controller of the previous backend")
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Delete secret for publisher proxy if it exists, once the migration no longer needs it
+	if err := r.deletePublisherProxySecretAfterMigration(ctx); err != nil {
+		backendStatus.SetPublisherReadyCondition(false, eventingv1alpha1.ConditionReasonPublisherProxySecretError, err.Error())
+		if updateErr := r.syncBackendStatus(ctx, backendStatus, nil); updateErr != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update status while deleting Event Publisher secret")
+		}
+		return ctrl.Result{}, errors.Wrapf(err, "delete eventing Event Publisher secret failed")
+	}
+
+	if r.kafkaSubMgrStarted && !backendStatus.IsSubscriptionControllerStatusReady() {
+		backendStatus.SetSubscriptionControllerReadyCondition(true, eventingv1alpha1.ConditionReasonSubscriptionControllerReady, "")
+	}
+	// CreateOrUpdate status of the CR
+	err = r.syncBackendStatus(ctx, backendStatus, publisher)
+	return ctrl.Result{RequeueAfter: requeueAfter}, err
 }
 
 func (r *Reconciler) syncOauth2ClientIDAndSecret(ctx context.Context, backendStatus *eventingv1alpha1.EventingBackendStatus) error {
@@ -366,6 +474,10 @@ func (r *Reconciler) syncBackendStatus(ctx context.Context, backendStatus *event
 		return errors.Wrapf(err, "failed to get current EventingBackend")
 	}
 
+	if r.migration != nil {
+		backendStatus.Migration = r.migration.status()
+	}
+
 	// Once backend changes, the publisher deployment changes are not picked up immediately
 	if hasBackendTypeChanged(currentBackend.Status, *backendStatus) {
 		backendStatus.SetSubscriptionControllerReadyCondition(false,
@@ -453,6 +565,15 @@ func getDefaultBackendStatus() eventingv1alpha1.EventingBackendStatus {
 	return defaultStatus
 }
 
+// deletePublisherProxySecretAfterMigration keeps the secret while the BEB publisher proxy pods may still need it during
+// the migration. It's called after the migration is synced, so the secret is deleted in the reconciliation which finishes the migration.
+func (r *Reconciler) deletePublisherProxySecretAfterMigration(ctx context.Context) error {
+	if r.migration != nil {
+		return nil
+	}
+	return r.DeletePublisherProxySecret(ctx)
+}
+
 func (r *Reconciler) DeletePublisherProxySecret(ctx context.Context) error {
 	secretNamespacedName := types.NamespacedName{
 		Namespace: deployment.PublisherNamespace,
@@ -590,6 +711,8 @@ func (r *Reconciler) CreateOrUpdatePublisherProxy(ctx context.Context, backend e
 		desiredPublisher = deployment.NewNATSPublisherDeployment(r.natsConfig, r.cfg.PublisherConfig)
 	case eventingv1alpha1.BEBBackendType:
 		desiredPublisher = deployment.NewBEBPublisherDeployment(r.cfg.PublisherConfig)
+	case eventingv1alpha1.KafkaBackendType:
+		desiredPublisher = deployment.NewKafkaPublisherDeployment(r.kafkaConfig, r.cfg.PublisherConfig)
 	default:
 		return nil, fmt.Errorf("unknown EventingBackend type %q", backend)
 	}
@@ -603,6 +726,13 @@ func (r *Reconciler) CreateOrUpdatePublisherProxy(ctx context.Context, backend e
 		return nil, errors.Wrapf(err, "failed to get Event Publisher deployment")
 	}
 
+	if currentPublisher == nil && r.migration != nil {
+		// update the publisher proxy of the previous backend in place, so that the rolling update replaces its pods without downtime
+		if currentPublisher, err = r.getPublisherProxy(ctx); err != nil {
+			return nil, errors.Wrapf(err, "failed to get Event Publisher deployment of the previous backend")
+		}
+	}
+
 	if currentPublisher == nil { // no deployment found
 		// delete the publisher proxy with invalid backend type if it still exists
 		if err := r.deletePublisherProxy(ctx); err != nil {
@@ -661,6 +791,8 @@ func (r *Reconciler) CreateOrUpdateBackendCR(ctx context.Context) error {
 	}
 
 	desiredBackend.ResourceVersion = currentBackend.ResourceVersion
+	// the spec is owned by the user
+	desiredBackend.Spec = currentBackend.Spec
 	if object.Semantic.DeepEqual(&currentBackend, &desiredBackend) {
 		return nil
 	}
@@ -672,6 +804,19 @@ func (r *Reconciler) CreateOrUpdateBackendCR(ctx context.Context) error {
 	return nil
 }
 
+// getDesiredBackendType returns the backend type set in the spec of the EventingBackend, or an empty type
+// if the backend type should be detected by the BEB secret.
+func (r *Reconciler) getDesiredBackendType(ctx context.Context) (eventingv1alpha1.BackendType, error) {
+	currentBackend, err := r.getCurrentBackendCR(ctx)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "get EventingBackend failed")
+	}
+	return currentBackend.Spec.Backend, nil
+}
+
 func (r *Reconciler) getCurrentBackendCR(ctx context.Context) (*eventingv1alpha1.EventingBackend, error) {
 	backend := new(eventingv1alpha1.EventingBackend)
 	err := r.Get(ctx, types.NamespacedName{
@@ -776,6 +921,31 @@ func (r *Reconciler) stopBEBController() error {
 	return nil
 }
 
+func (r *Reconciler) startKafkaController() error {
+	if r.kafkaSubMgr == nil {
+		return xerrors.Errorf("failed to start Kafka subscription manager: Kafka backend is not configured")
+	}
+	if !r.kafkaSubMgrStarted {
+		if err := r.kafkaSubMgr.Start(r.cfg.DefaultSubscriptionConfig, subscriptionmanager.Params{}); err != nil {
+			return xerrors.Errorf("failed to start Kafka subscription manager: %v", err)
+		}
+		r.kafkaSubMgrStarted = true
+		r.namedLogger().Info("Kafka subscription manager was started")
+	}
+	return nil
+}
+
+func (r *Reconciler) stopKafkaController() error {
+	if r.kafkaSubMgrStarted {
+		if err := r.kafkaSubMgr.Stop(true); err != nil {
+			return xerrors.Errorf("failed to stop Kafka subscription manager: %v", err)
+		}
+		r.kafkaSubMgrStarted = false
+		r.namedLogger().Info("Kafka subscription manager was stopped")
+	}
+	return nil
+}
+
 // getEPPDeployment fetches the event publisher by the current active backend type.
 func (r *Reconciler) getEPPDeployment(ctx context.Context) (*appsv1.Deployment, error) {
 	var list appsv1.DeploymentList
@@ -794,8 +964,8 @@ func (r *Reconciler) getEPPDeployment(ctx context.Context) (*appsv1.Deployment,
 	return &list.Items[0], nil
 }
 
-// deletePublisherProxy removes the existing publisher proxy.
-func (r *Reconciler) deletePublisherProxy(ctx context.Context) error {
+// getPublisherProxy fetches the existing publisher proxy regardless of its backend type.
+func (r *Reconciler) getPublisherProxy(ctx context.Context) (*appsv1.Deployment, error) {
 	publisherNamespacedName := types.NamespacedName{
 		Namespace: deployment.PublisherNamespace,
 		Name:      deployment.PublisherName,
@@ -804,8 +974,17 @@ func (r *Reconciler) deletePublisherProxy(ctx context.Context) error {
 	err := r.Get(ctx, publisherNamespacedName, publisher)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
-			return nil
+			return nil, nil
 		}
+		return nil, err
+	}
+	return publisher, nil
+}
+
+// deletePublisherProxy removes the existing publisher proxy.
+func (r *Reconciler) deletePublisherProxy(ctx context.Context) error {
+	publisher, err := r.getPublisherProxy(ctx)
+	if err != nil || publisher == nil {
 		return err
 	}
 	r.namedLogger().Debug("Event Publisher with invalid backend type found, deleting it")
@@ -120,6 +120,8 @@ var _ = BeforeSuite(func(done Done) {
 		natsSubMgr,
 		natsConfig,
 		bebSubMgr,
+		nil,
+		env.KafkaConfig{},
 		k8sManager.GetClient(),
 		defaultLogger,
 		k8sManager.GetEventRecorderFor("backend-controller"),
@@ -0,0 +1,267 @@
+package kafka
+
+import (
+	"context"
+	"reflect"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	eventingv1alpha1 "github.com/kyma-project/kyma/components/eventing-controller/api/v1alpha1"
+	"github.com/kyma-project/kyma/components/eventing-controller/controllers/events"
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/eventtype"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/kafka"
+	backendnats "github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/nats"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/sink"
+	backendutils "github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/utils"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/env"
+	"github.com/kyma-project/kyma/components/eventing-controller/utils"
+)
+
+const (
+	reconcilerName = "kafka-subscription-reconciler"
+)
+
+type Reconciler struct {
+	client.Client
+	ctx              context.Context
+	Backend          kafka.Backend
+	recorder         record.EventRecorder
+	logger           *logger.Logger
+	eventTypeCleaner eventtype.Cleaner
+	subsConfig       env.DefaultSubscriptionConfig
+	sinkValidator    sink.Validator
+}
+
+func NewReconciler(ctx context.Context, client client.Client, kafkaHandler kafka.Backend, logger *logger.Logger,
+	recorder record.EventRecorder, cleaner eventtype.Cleaner, subsCfg env.DefaultSubscriptionConfig, defaultSinkValidator sink.Validator) *Reconciler {
+	reconciler := &Reconciler{
+		Client:           client,
+		ctx:              ctx,
+		Backend:          kafkaHandler,
+		recorder:         recorder,
+		logger:           logger,
+		eventTypeCleaner: cleaner,
+		subsConfig:       subsCfg,
+		sinkValidator:    defaultSinkValidator,
+	}
+	if err := kafkaHandler.Initialize(); err != nil {
+		logger.WithContext().Errorw("Failed to start reconciler", "name", reconcilerName, "error", err)
+		panic(err)
+	}
+	return reconciler
+}
+
+// SetupUnmanaged creates a controller under the client control.
+func (r *Reconciler) SetupUnmanaged(mgr ctrl.Manager) error {
+	ctru, err := controller.NewUnmanaged(reconcilerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		r.namedLogger().Errorw("Failed to create unmanaged controller", "error", err)
+		return err
+	}
+
+	if err := ctru.Watch(&source.Kind{Type: &eventingv1alpha1.Subscription{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		r.namedLogger().Errorw("Failed to setup watch for subscriptions", "error", err)
+		return err
+	}
+
+	go func(r *Reconciler, c controller.Controller) {
+		if err := c.Start(r.ctx); err != nil {
+			r.namedLogger().Fatalw("Failed to start controller", "error", err)
+		}
+	}(r, ctru)
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=eventing.kyma-project.io,resources=subscriptions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=eventing.kyma-project.io,resources=subscriptions/status,verbs=get;update;patch
+// Generate required RBAC to emit kubernetes events in the controller.
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// Generated required RBAC to list Applications (required by event type cleaner).
+// +kubebuilder:rbac:groups="applicationconnector.kyma-project.io",resources=applications,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.namedLogger().Debugw("Received subscription reconciliation request", "namespace", req.Namespace, "name", req.Name)
+
+	actualSubscription := &eventingv1alpha1.Subscription{}
+	// Ensure the object was not deleted in the meantime
+	if err := r.Client.Get(ctx, req.NamespacedName, actualSubscription); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	desiredSubscription := actualSubscription.DeepCopy()
+	// Bind fields to logger
+	log := backendutils.LoggerWithSubscription(r.namedLogger(), desiredSubscription)
+
+	if isInDeletion(desiredSubscription) {
+		// The object is being deleted
+		if err := r.handleSubscriptionDeletion(ctx, desiredSubscription, log); err != nil {
+			log.Errorw("Failed to delete the Subscription", "error", err)
+			if syncErr := r.syncSubscriptionStatus(ctx, desiredSubscription, false, err); syncErr != nil {
+				return ctrl.Result{}, syncErr
+			}
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// The object is not being deleted, so if it does not have our finalizer,
+	// then lets add the finalizer and update the object.
+	if !utils.ContainsString(desiredSubscription.ObjectMeta.Finalizers, eventingv1alpha1.Finalizer) {
+		if err := r.addFinalizerToSubscription(ctx, desiredSubscription, log); err != nil {
+			log.Errorw("Failed to add finalizer to Subscription", "error", err)
+			if syncErr := r.syncSubscriptionStatus(ctx, desiredSubscription, false, err); syncErr != nil {
+				return ctrl.Result{}, syncErr
+			}
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// update the cleanEventTypes and config values in the subscription status, if changed
+	statusChanged, err := r.syncInitialStatus(desiredSubscription)
+	if err != nil {
+		if syncErr := r.syncSubscriptionStatus(ctx, desiredSubscription, statusChanged, err); syncErr != nil {
+			return ctrl.Result{}, syncErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check for valid sink
+	if err := r.sinkValidator.Validate(desiredSubscription); err != nil {
+		if syncErr := r.syncSubscriptionStatus(ctx, desiredSubscription, statusChanged, err); syncErr != nil {
+			return ctrl.Result{}, syncErr
+		}
+		// No point in reconciling as the sink is invalid, return latest error to requeue the reconciliation request
+		return ctrl.Result{}, err
+	}
+
+	// Synchronize Kyma subscription to the Kafka backend
+	if err := r.Backend.SyncSubscription(desiredSubscription); err != nil {
+		if syncErr := r.syncSubscriptionStatus(ctx, desiredSubscription, statusChanged, err); syncErr != nil {
+			return ctrl.Result{}, syncErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Update Subscription status
+	if err := r.syncSubscriptionStatus(ctx, desiredSubscription, statusChanged, nil); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// syncSubscriptionStatus syncs Subscription status and keeps the status up to date.
+func (r *Reconciler) syncSubscriptionStatus(ctx context.Context, sub *eventingv1alpha1.Subscription, updateStatus bool, err error) error {
+	readyStatusChanged := setSubReadyStatus(&sub.Status, err == nil)
+
+	desiredConditions := []eventingv1alpha1.Condition{newConditionSubscriptionActive(err)}
+	// check if the conditions are missing or changed
+	if !eventingv1alpha1.ConditionsEquals(sub.Status.Conditions, desiredConditions) {
+		sub.Status.Conditions = desiredConditions
+		updateStatus = true
+	}
+
+	// Update the status only if something needs to be updated
+	if updateStatus || readyStatusChanged {
+		if updateErr := r.Client.Status().Update(ctx, sub, &client.UpdateOptions{}); updateErr != nil {
+			events.Warn(r.recorder, sub, events.ReasonUpdateFailed, "Update Subscription status failed %s", sub.Name)
+			return xerrors.Errorf("failed to update subscription status: %v", updateErr)
+		}
+		events.Normal(r.recorder, sub, events.ReasonUpdate, "Update Subscription status succeeded %s", sub.Name)
+	}
+	return nil
+}
+
+// handleSubscriptionDeletion deletes the Kafka consumer group and removes the finalizer of the subscription if it is set.
+func (r *Reconciler) handleSubscriptionDeletion(ctx context.Context, subscription *eventingv1alpha1.Subscription, log *zap.SugaredLogger) error {
+	if !utils.ContainsString(subscription.ObjectMeta.Finalizers, eventingv1alpha1.Finalizer) {
+		return nil
+	}
+	if err := r.Backend.DeleteSubscription(subscription); err != nil {
+		// if failed to delete the external dependency here, return with error
+		// so that it can be retried
+		return xerrors.Errorf("failed to delete Kafka subscription: %v", err)
+	}
+
+	// remove our finalizer from the list and update it.
+	subscription.ObjectMeta.Finalizers = utils.RemoveString(subscription.ObjectMeta.Finalizers, eventingv1alpha1.Finalizer)
+	if err := r.Client.Update(ctx, subscription); err != nil {
+		events.Warn(r.recorder, subscription, events.ReasonUpdateFailed, "Update Subscription failed %s", subscription.Name)
+		return xerrors.Errorf("failed to remove finalizer from subscription: %v", err)
+	}
+	log.Debug("Removed finalizer from subscription")
+	return nil
+}
+
+// addFinalizerToSubscription appends the eventing finalizer to the subscription.
+func (r *Reconciler) addFinalizerToSubscription(ctx context.Context, sub *eventingv1alpha1.Subscription, log *zap.SugaredLogger) error {
+	sub.ObjectMeta.Finalizers = append(sub.ObjectMeta.Finalizers, eventingv1alpha1.Finalizer)
+	// to avoid a dangling subscription, we update the subscription as soon as the finalizer is added to it
+	if err := r.Update(ctx, sub); err != nil {
+		return xerrors.Errorf("failed to add finalizer to subscription: %v", err)
+	}
+	log.Debug("Added finalizer to subscription")
+	return nil
+}
+
+// syncInitialStatus keeps the latest cleanEventTypes and Config in the subscription.
+func (r *Reconciler) syncInitialStatus(subscription *eventingv1alpha1.Subscription) (bool, error) {
+	statusChanged := false
+	cleanedSubjects, err := backendnats.GetCleanSubjects(subscription, r.eventTypeCleaner)
+	if err != nil {
+		subscription.Status.InitializeCleanEventTypes()
+		return true, xerrors.Errorf("failed to get clean subjects: %v", err)
+	}
+	if !reflect.DeepEqual(subscription.Status.CleanEventTypes, cleanedSubjects) {
+		subscription.Status.CleanEventTypes = cleanedSubjects
+		statusChanged = true
+	}
+	subscriptionConfig := eventingv1alpha1.MergeSubsConfigs(subscription.Spec.Config, &r.subsConfig)
+	if subscription.Status.Config == nil || !reflect.DeepEqual(subscriptionConfig, subscription.Status.Config) {
+		subscription.Status.Config = subscriptionConfig
+		statusChanged = true
+	}
+	if subscription.Status.CleanEventTypes == nil {
+		subscription.Status.InitializeCleanEventTypes()
+		statusChanged = true
+	}
+	return statusChanged, nil
+}
+
+// newConditionSubscriptionActive returns the ConditionSubscriptionActive condition, which is true if the error is nil.
+func newConditionSubscriptionActive(err error) eventingv1alpha1.Condition {
+	if err != nil {
+		return eventingv1alpha1.MakeCondition(eventingv1alpha1.ConditionSubscriptionActive,
+			eventingv1alpha1.ConditionReasonKafkaSubscriptionNotActive, corev1.ConditionFalse, err.Error())
+	}
+	return eventingv1alpha1.MakeCondition(eventingv1alpha1.ConditionSubscriptionActive,
+		eventingv1alpha1.ConditionReasonKafkaSubscriptionActive, corev1.ConditionTrue, "")
+}
+
+// setSubReadyStatus returns true if the subscription ready status has changed.
+func setSubReadyStatus(desiredSubscriptionStatus *eventingv1alpha1.SubscriptionStatus, isReady bool) bool {
+	if desiredSubscriptionStatus.Ready != isReady {
+		desiredSubscriptionStatus.Ready = isReady
+		return true
+	}
+	return false
+}
+
+// isInDeletion checks if the subscription needs to be deleted.
+func isInDeletion(subscription *eventingv1alpha1.Subscription) bool {
+	return !subscription.ObjectMeta.DeletionTimestamp.IsZero()
+}
+
+func (r *Reconciler) namedLogger() *zap.SugaredLogger {
+	return r.logger.WithContext().Named(reconcilerName)
+}
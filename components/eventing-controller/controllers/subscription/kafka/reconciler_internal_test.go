@@ -0,0 +1,216 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	eventingv1alpha1 "github.com/kyma-project/kyma/components/eventing-controller/api/v1alpha1"
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/eventtype"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/kafka"
+	backendmetrics "github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/metrics"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/sink"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/env"
+	controllertesting "github.com/kyma-project/kyma/components/eventing-controller/testing"
+)
+
+const (
+	waitTimeout  = 5 * time.Second
+	waitInterval = 10 * time.Millisecond
+)
+
+// TestReconciler_Reconcile tests the Reconcile() method of the reconciler against an in-memory Kafka cluster.
+func TestReconciler_Reconcile(t *testing.T) {
+	// A subscription with the correct Finalizer, ready for reconciliation with the backend.
+	testSub := controllertesting.NewSubscription("sub1", "test",
+		controllertesting.WithFinalizers([]string{eventingv1alpha1.Finalizer}),
+		controllertesting.WithFilter(controllertesting.EventSource, controllertesting.OrderCreatedEventType),
+	)
+	// A subscription marked for deletion.
+	testSubUnderDeletion := controllertesting.NewSubscription("sub2", "test",
+		controllertesting.WithNonZeroDeletionTimestamp(),
+		controllertesting.WithFinalizers([]string{eventingv1alpha1.Finalizer}),
+		controllertesting.WithFilter(controllertesting.EventSource, controllertesting.OrderCreatedEventType),
+	)
+
+	validatorErr := errors.New("invalid sink")
+	cleanerErr := errors.New("invalid event type format")
+	happyCleaner := eventtype.CleanerFunc(func(et string) (string, error) { return et, nil })
+	unhappyCleaner := eventtype.CleanerFunc(func(et string) (string, error) { return et, cleanerErr })
+	happyValidator := sink.ValidatorFunc(func(s *eventingv1alpha1.Subscription) error { return nil })
+	unhappyValidator := sink.ValidatorFunc(func(s *eventingv1alpha1.Subscription) error { return validatorErr })
+
+	var testCases = []struct {
+		name               string
+		givenSubscription  *eventingv1alpha1.Subscription
+		givenCleaner       eventtype.Cleaner
+		givenValidator     sink.Validator
+		wantReconcileError error
+		wantCondition      eventingv1alpha1.Condition
+		wantReady          bool
+		wantConsumerGroups []string
+		wantDeleted        bool
+	}{
+		{
+			name:              "Consumer group is created and subscription is ready when there is no error",
+			givenSubscription: testSub,
+			givenCleaner:      happyCleaner,
+			givenValidator:    happyValidator,
+			wantCondition:     newConditionSubscriptionActive(nil),
+			wantReady:         true,
+			// the consumer group is named after the namespace and the name of the subscription
+			wantConsumerGroups: []string{"kyma.test.sub1"},
+		},
+		{
+			name:               "Return error and subscription is not ready when validator returns error",
+			givenSubscription:  testSub,
+			givenCleaner:       happyCleaner,
+			givenValidator:     unhappyValidator,
+			wantReconcileError: validatorErr,
+			wantCondition:      newConditionSubscriptionActive(validatorErr),
+			wantConsumerGroups: []string{},
+		},
+		{
+			name:               "Return error and subscription is not ready when event type cleaner returns error",
+			givenSubscription:  testSub,
+			givenCleaner:       unhappyCleaner,
+			givenValidator:     happyValidator,
+			wantReconcileError: xerrors.Errorf("failed to get clean subjects: %v", cleanerErr),
+			wantCondition:      newConditionSubscriptionActive(xerrors.Errorf("failed to get clean subjects: %v", cleanerErr)),
+			wantConsumerGroups: []string{},
+		},
+		{
+			name:               "Consumer group and finalizer are removed when the subscription is deleted",
+			givenSubscription:  testSubUnderDeletion,
+			givenCleaner:       happyCleaner,
+			givenValidator:     happyValidator,
+			wantConsumerGroups: []string{},
+			wantDeleted:        true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			fakeClient := createFakeClientBuilder(t).WithObjects(tc.givenSubscription.DeepCopy()).Build()
+			kafkaClient := kafka.NewMemoryClient()
+			reconciler := NewReconciler(ctx, fakeClient, newTestKafka(t, kafkaClient), newTestLogger(t),
+				&record.FakeRecorder{}, tc.givenCleaner, env.DefaultSubscriptionConfig{}, tc.givenValidator)
+			// the consumer group of the subscription under deletion exists already
+			if tc.wantDeleted {
+				joinConsumerGroup(t, kafkaClient, "kyma.test.sub2")
+			}
+			key := types.NamespacedName{Namespace: tc.givenSubscription.Namespace, Name: tc.givenSubscription.Name}
+
+			// when
+			res, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+			// then
+			require.Equal(t, ctrl.Result{}, res)
+			if tc.wantReconcileError == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantReconcileError.Error())
+			}
+
+			var fetchedSub eventingv1alpha1.Subscription
+			err = fakeClient.Get(ctx, key, &fetchedSub)
+			if tc.wantDeleted {
+				// the subscription is gone once its finalizer is removed
+				require.True(t, k8serrors.IsNotFound(err))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, []string{eventingv1alpha1.Finalizer}, fetchedSub.Finalizers)
+				require.Equal(t, tc.wantReady, fetchedSub.Status.Ready)
+				require.True(t, eventingv1alpha1.ConditionsEquals(
+					[]eventingv1alpha1.Condition{tc.wantCondition}, fetchedSub.Status.Conditions))
+			}
+
+			// the consumer joins the consumer group in the background
+			require.Eventually(t, func() bool {
+				groupIDs, err := kafkaClient.ListConsumerGroups()
+				return err == nil && len(groupIDs) == len(tc.wantConsumerGroups)
+			}, waitTimeout, waitInterval)
+			groupIDs, err := kafkaClient.ListConsumerGroups()
+			require.NoError(t, err)
+			require.ElementsMatch(t, tc.wantConsumerGroups, groupIDs)
+		})
+	}
+}
+
+func Test_newConditionSubscriptionActive(t *testing.T) {
+	testCases := []struct {
+		name       string
+		givenErr   error
+		wantStatus corev1.ConditionStatus
+		wantReason eventingv1alpha1.ConditionReason
+	}{
+		{
+			name:       "Condition is true without error",
+			wantStatus: corev1.ConditionTrue,
+			wantReason: eventingv1alpha1.ConditionReasonKafkaSubscriptionActive,
+		},
+		{
+			name:       "Condition is false with error",
+			givenErr:   errors.New("some error"),
+			wantStatus: corev1.ConditionFalse,
+			wantReason: eventingv1alpha1.ConditionReasonKafkaSubscriptionNotActive,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			condition := newConditionSubscriptionActive(tc.givenErr)
+			require.Equal(t, eventingv1alpha1.ConditionSubscriptionActive, condition.Type)
+			require.Equal(t, tc.wantStatus, condition.Status)
+			require.Equal(t, tc.wantReason, condition.Reason)
+		})
+	}
+}
+
+func newTestLogger(t *testing.T) *logger.Logger {
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	return defaultLogger
+}
+
+func newTestKafka(t *testing.T, client kafka.Client) *kafka.Kafka {
+	config := env.KafkaConfig{TopicPrefix: "kyma", ConsumerGroupPrefix: "kyma"}
+	backend := kafka.NewKafka(config, client, env.DefaultSubscriptionConfig{}, backendmetrics.NewCollector(), newTestLogger(t))
+	t.Cleanup(func() {
+		require.NoError(t, backend.Shutdown())
+	})
+	return backend
+}
+
+// joinConsumerGroup creates the consumer group by consuming a topic until it's empty.
+func joinConsumerGroup(t *testing.T, client *kafka.MemoryClient, groupID string) {
+	require.NoError(t, client.CreateTopic("empty"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, client.Consume(ctx, groupID, []string{"empty"}, func(context.Context, *kafka.Message) error {
+		return nil
+	}))
+}
+
+func createFakeClientBuilder(t *testing.T) *fake.ClientBuilder {
+	err := eventingv1alpha1.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme)
+}
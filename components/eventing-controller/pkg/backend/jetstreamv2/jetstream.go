@@ -436,7 +436,7 @@ func (js *JetStream) getCallback(subKeyPrefix, subscriptionName string) nats.Msg
 		ctxWithCancel, cancel := context.WithCancel(context.Background())
 		defer cancel()
 		ctxWithCE := cev2.ContextWithTarget(ctxWithCancel, sink)
-		traceCtxWithCE := tracing.AddTracingHeadersToContext(ctxWithCE, ce)
+		traceCtxWithCE, span := tracing.StartDispatchSpan(ctxWithCE, ce, subKeyPrefix, sink, backendutils.GetRedeliveryCount(msg))
 
 		// decorate the logger with CloudEvent context
 		ceLogger := js.namedLogger().With("id", ce.ID(), "source", ce.Source(), "type", ce.Type(), "sink", sink)
@@ -445,6 +445,7 @@ func (js *JetStream) getCallback(subKeyPrefix, subscriptionName string) nats.Msg
 
 		// dispatch the event to sink
 		result := js.client.Send(traceCtxWithCE, *ce)
+		tracing.EndDispatchSpan(span, result)
 		if !cev2protocol.IsACK(result) {
 			js.metricsCollector.RecordDeliveryPerSubscription(subscriptionName, ce.Type(), sink, http.StatusInternalServerError)
 			ceLogger.Errorw("Failed to dispatch the CloudEvent")
@@ -0,0 +1,48 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrUnknownTopic = errors.New("unknown Kafka topic")
+	ErrClosed       = errors.New("client of the Kafka cluster is closed")
+)
+
+// Client defines the operations on the Kafka cluster which the Kafka backend needs.
+// It is implemented by the Sarama client for real clusters and by MemoryClient for tests.
+type Client interface {
+	// CreateTopic creates the topic if it doesn't exist yet.
+	CreateTopic(topic string) error
+
+	// Consume joins the consumer group and passes the messages of the topics to the handler until the context is done.
+	// The offset of a message is committed once the handler returns without error.
+	Consume(ctx context.Context, groupID string, topics []string, handler MessageHandler) error
+
+	// ListConsumerGroups returns the IDs of all consumer groups of the cluster.
+	ListConsumerGroups() ([]string, error)
+
+	// DeleteConsumerGroup deletes the consumer group and its committed offsets. Unknown consumer groups are ignored.
+	DeleteConsumerGroup(groupID string) error
+
+	// Lag returns the number of messages of the consumer group which are not committed yet. It counts all partitions
+	// of the given topics and of the topics the consumer group committed offsets for or its members subscribed to.
+	// Partitions without a committed offset count from their oldest message.
+	Lag(groupID string, topics []string) (int64, error)
+
+	// Close closes the connections to the cluster.
+	Close() error
+}
+
+// Message is a message consumed from a Kafka topic.
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Value     []byte
+}
+
+// MessageHandler processes the consumed message. It returns an error only if the message couldn't be processed
+// because the context is done, so the message is consumed again later.
+type MessageHandler func(ctx context.Context, msg *Message) error
@@ -0,0 +1,342 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	cev2 "github.com/cloudevents/sdk-go/v2"
+	cev2event "github.com/cloudevents/sdk-go/v2/event"
+	cev2protocol "github.com/cloudevents/sdk-go/v2/protocol"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+
+	eventingv1alpha1 "github.com/kyma-project/kyma/components/eventing-controller/api/v1alpha1"
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+	backendmetrics "github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/metrics"
+	backendutils "github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/utils"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/env"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/tracing"
+)
+
+var _ Backend = &Kafka{}
+
+const (
+	kafkaHandlerName = "kafka-handler"
+	separator        = "."
+	// consumerRetryPeriod is the time to wait before joining the consumer group again after consuming failed.
+	consumerRetryPeriod = 10 * time.Second
+)
+
+type Backend interface {
+	// Initialize should initialize the communication layer with the messaging backend system
+	Initialize() error
+
+	// SyncSubscription should synchronize the Kyma eventing subscription with the consumer group of its topics.
+	SyncSubscription(subscription *eventingv1alpha1.Subscription) error
+
+	// DeleteSubscription should delete the consumer group of the subscription.
+	DeleteSubscription(subscription *eventingv1alpha1.Subscription) error
+
+	// DeleteInvalidConsumerGroups deletes all consumer groups of the backend belonging to none of the subscriptions.
+	DeleteInvalidConsumerGroups(subscriptions []eventingv1alpha1.Subscription) error
+
+	// PendingMessages returns the number of messages the consumer groups of the backend didn't commit yet,
+	// including the consumer groups of the subscriptions which aren't consumed by this instance.
+	PendingMessages() (int64, error)
+
+	// Shutdown stops all consumers and closes the connection to the Kafka cluster.
+	Shutdown() error
+}
+
+// Kafka maps each subscription to a consumer group, which consumes one topic per event type of the subscription
+// and dispatches the events to the sink. The events of a partition are dispatched one at a time in order.
+type Kafka struct {
+	Config     env.KafkaConfig
+	client     Client
+	ceClient   cev2.Client
+	subsConfig env.DefaultSubscriptionConfig
+	// consumers are the running consumers per consumer group ID
+	consumers        map[string]*consumer
+	mutex            sync.Mutex
+	sinks            sync.Map
+	logger           *logger.Logger
+	metricsCollector *backendmetrics.Collector
+}
+
+// consumer consumes the topics of a subscription as the member of its consumer group.
+type consumer struct {
+	topics []string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// stop stops consuming and waits until the message in progress is processed.
+func (c *consumer) stop() {
+	c.cancel()
+	<-c.done
+}
+
+func NewKafka(config env.KafkaConfig, client Client, subsConfig env.DefaultSubscriptionConfig,
+	metricsCollector *backendmetrics.Collector, logger *logger.Logger) *Kafka {
+	return &Kafka{
+		Config:           config,
+		client:           client,
+		subsConfig:       subsConfig,
+		consumers:        make(map[string]*consumer),
+		logger:           logger,
+		metricsCollector: metricsCollector,
+	}
+}
+
+func (k *Kafka) Initialize() error {
+	if k.ceClient != nil {
+		return nil
+	}
+	transport := &http.Transport{
+		MaxIdleConns:        k.Config.MaxIdleConns,
+		MaxConnsPerHost:     k.Config.MaxConnsPerHost,
+		MaxIdleConnsPerHost: k.Config.MaxIdleConnsPerHost,
+		IdleConnTimeout:     k.Config.IdleConnTimeout,
+	}
+	client, err := cev2.NewClientHTTP(cev2.WithRoundTripper(transport))
+	if err != nil {
+		return xerrors.Errorf("failed to create CloudEvents client: %v", err)
+	}
+	k.ceClient = client
+	return nil
+}
+
+func (k *Kafka) SyncSubscription(subscription *eventingv1alpha1.Subscription) error {
+	log := backendutils.LoggerWithSubscription(k.namedLogger(), subscription)
+	groupID := k.consumerGroupID(subscription)
+
+	topics := make([]string, 0, len(subscription.Status.CleanEventTypes))
+	for _, eventType := range subscription.Status.CleanEventTypes {
+		topic := k.topicName(eventType)
+		if err := k.client.CreateTopic(topic); err != nil {
+			return xerrors.Errorf("failed to create Kafka topic %s: %v", topic, err)
+		}
+		topics = append(topics, topic)
+		k.metricsCollector.RecordEventTypes(subscription.Name, subscription.Namespace, eventType, groupID)
+	}
+
+	// add/update sink info in map for the message handler
+	k.sinks.Store(groupID, subscription.Spec.Sink)
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if c, ok := k.consumers[groupID]; ok {
+		if reflect.DeepEqual(c.topics, topics) {
+			return nil
+		}
+		// the consumer has to join the consumer group again to consume the changed topics
+		c.stop()
+		delete(k.consumers, groupID)
+	}
+	if len(topics) == 0 {
+		log.Debugw("No topics to consume", "consumerGroup", groupID)
+		return nil
+	}
+	k.consumers[groupID] = k.startConsumer(groupID, topics, types.NamespacedName{Namespace: subscription.Namespace, Name: subscription.Name})
+	log.Debugw("Started Kafka consumer", "consumerGroup", groupID, "topics", topics)
+	return nil
+}
+
+func (k *Kafka) DeleteSubscription(subscription *eventingv1alpha1.Subscription) error {
+	log := backendutils.LoggerWithSubscription(k.namedLogger(), subscription)
+	groupID := k.consumerGroupID(subscription)
+
+	k.mutex.Lock()
+	if c, ok := k.consumers[groupID]; ok {
+		c.stop()
+		delete(k.consumers, groupID)
+	}
+	k.mutex.Unlock()
+	k.sinks.Delete(groupID)
+
+	if err := k.client.DeleteConsumerGroup(groupID); err != nil {
+		return xerrors.Errorf("failed to delete Kafka consumer group %s: %v", groupID, err)
+	}
+	log.Debugw("Deleted Kafka consumer group", "consumerGroup", groupID)
+	return nil
+}
+
+func (k *Kafka) DeleteInvalidConsumerGroups(subscriptions []eventingv1alpha1.Subscription) error {
+	groupIDs, err := k.client.ListConsumerGroups()
+	if err != nil {
+		return xerrors.Errorf("failed to list Kafka consumer groups: %v", err)
+	}
+	validGroupIDs := make(map[string]bool, len(subscriptions))
+	for i := range subscriptions {
+		validGroupIDs[k.consumerGroupID(&subscriptions[i])] = true
+	}
+	for _, groupID := range groupIDs {
+		// consumer groups of other applications using the same Kafka cluster are left untouched
+		if !strings.HasPrefix(groupID, k.Config.ConsumerGroupPrefix+separator) || validGroupIDs[groupID] {
+			continue
+		}
+		if err := k.client.DeleteConsumerGroup(groupID); err != nil {
+			return xerrors.Errorf("failed to delete Kafka consumer group %s: %v", groupID, err)
+		}
+		k.namedLogger().Debugw("Deleted invalid Kafka consumer group", "consumerGroup", groupID)
+	}
+	return nil
+}
+
+func (k *Kafka) PendingMessages() (int64, error) {
+	groupIDs, err := k.client.ListConsumerGroups()
+	if err != nil {
+		return 0, xerrors.Errorf("failed to list Kafka consumer groups: %v", err)
+	}
+	var pending int64
+	for _, groupID := range groupIDs {
+		// consumer groups of other applications using the same Kafka cluster are left out
+		if !strings.HasPrefix(groupID, k.Config.ConsumerGroupPrefix+separator) {
+			continue
+		}
+		lag, err := k.client.Lag(groupID, k.consumerTopics(groupID))
+		if err != nil {
+			return 0, xerrors.Errorf("failed to get lag of Kafka consumer group %s: %v", groupID, err)
+		}
+		pending += lag
+	}
+	return pending, nil
+}
+
+// consumerTopics returns the topics of the running consumer of the consumer group, if any.
+func (k *Kafka) consumerTopics(groupID string) []string {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if c, ok := k.consumers[groupID]; ok {
+		return c.topics
+	}
+	return nil
+}
+
+func (k *Kafka) Shutdown() error {
+	k.mutex.Lock()
+	for groupID, c := range k.consumers {
+		c.stop()
+		delete(k.consumers, groupID)
+	}
+	k.mutex.Unlock()
+	return k.client.Close()
+}
+
+// startConsumer consumes the topics in the background until the consumer is stopped.
+func (k *Kafka) startConsumer(groupID string, topics []string, subscription types.NamespacedName) *consumer {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &consumer{topics: topics, cancel: cancel, done: make(chan struct{})}
+	handler := k.getHandler(groupID, subscription)
+	log := k.namedLogger().With("consumerGroup", groupID, "topics", topics)
+
+	go func() {
+		defer close(c.done)
+		for {
+			err := k.client.Consume(ctx, groupID, topics, handler)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Errorw("Failed to consume Kafka topics, retrying", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(consumerRetryPeriod):
+			}
+		}
+	}()
+	return c
+}
+
+func (k *Kafka) getHandler(groupID string, subscription types.NamespacedName) MessageHandler {
+	return func(ctx context.Context, msg *Message) error {
+		// fetch sink info from storage
+		sinkValue, ok := k.sinks.Load(groupID)
+		if !ok {
+			k.namedLogger().Errorw("Failed to find sink URL in storage", "consumerGroup", groupID)
+			return nil
+		}
+		// convert interface type to string
+		sink, ok := sinkValue.(string)
+		if !ok {
+			k.namedLogger().Errorw("Failed to convert sink value to string", "sinkValue", sinkValue)
+			return nil
+		}
+		ce, err := convertMessageToCE(msg)
+		if err != nil {
+			// the message can never be dispatched, so it's skipped
+			k.namedLogger().Errorw("Failed to convert Kafka message to CloudEvent", "topic", msg.Topic,
+				"partition", msg.Partition, "offset", msg.Offset, "error", err)
+			return nil
+		}
+
+		// decorate the logger with CloudEvent context
+		ceLogger := k.namedLogger().With("id", ce.ID(), "source", ce.Source(), "type", ce.Type(), "sink", sink)
+
+		for retries := 0; ; retries++ {
+			// the tracing extensions are removed from the dispatched event, so each retry dispatches a copy
+			dispatchedCE := ce.Clone()
+			ctxWithCE := cev2.ContextWithTarget(ctx, sink)
+			traceCtxWithCE, span := tracing.StartDispatchSpan(ctxWithCE, &dispatchedCE, subscription.String(), sink, int64(retries))
+
+			ceLogger.Debugw("Sending the CloudEvent")
+			result := k.ceClient.Send(traceCtxWithCE, dispatchedCE)
+			tracing.EndDispatchSpan(span, result)
+			if cev2protocol.IsACK(result) {
+				k.metricsCollector.RecordDeliveryPerSubscription(subscription.Name, ce.Type(), sink, http.StatusOK)
+				ceLogger.Infow("CloudEvent was dispatched")
+				return nil
+			}
+			// the consumer is stopped, so the message is not committed and consumed again later
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			k.metricsCollector.RecordDeliveryPerSubscription(subscription.Name, ce.Type(), sink, http.StatusInternalServerError)
+			if retries >= k.subsConfig.DispatcherMaxRetries {
+				// the message is committed anyway, otherwise it would block all further messages of the partition
+				ceLogger.Errorw("Failed to dispatch the CloudEvent, dropping it", "retries", retries, "error", result)
+				return nil
+			}
+			ceLogger.Warnw("Failed to dispatch the CloudEvent, retrying", "retries", retries, "error", result)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(k.subsConfig.DispatcherRetryPeriod):
+			}
+		}
+	}
+}
+
+// consumerGroupID returns the ID of the consumer group of the given subscription. It can't be ambiguous,
+// since namespace names don't contain dots.
+func (k *Kafka) consumerGroupID(subscription *eventingv1alpha1.Subscription) string {
+	return k.Config.ConsumerGroupPrefix + separator + subscription.Namespace + separator + subscription.Name
+}
+
+// topicName returns the name of the topic storing the events of the given clean event type.
+func (k *Kafka) topicName(eventType string) string {
+	return k.Config.TopicPrefix + separator + eventType
+}
+
+// convertMessageToCE converts the Kafka message published by the Event Publisher Proxy into a CloudEvent.
+func convertMessageToCE(msg *Message) (*cev2event.Event, error) {
+	event := cev2event.New(cev2event.CloudEventsVersionV1)
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		return nil, err
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (k *Kafka) namedLogger() *zap.SugaredLogger {
+	return k.logger.WithContext().Named(kafkaHandlerName)
+}
@@ -0,0 +1,245 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cev2event "github.com/cloudevents/sdk-go/v2/event"
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1alpha1 "github.com/kyma-project/kyma/components/eventing-controller/api/v1alpha1"
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+	backendmetrics "github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/metrics"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/env"
+)
+
+const (
+	testEventType  = "sap.kyma.custom.commerce.order.created.v1"
+	testTopic      = "kyma." + testEventType
+	waitTimeout    = 5 * time.Second
+	waitInterval   = 10 * time.Millisecond
+	testRetryDelay = 10 * time.Millisecond
+)
+
+func TestKafka_SyncSubscription(t *testing.T) {
+	testCases := []struct {
+		name                string
+		givenFailedRequests int32
+		givenMaxRetries     int
+		wantRequests        int32
+	}{
+		{
+			name:         "event is dispatched to the sink",
+			wantRequests: 1,
+		},
+		{
+			name:                "event is dispatched again if the sink fails",
+			givenFailedRequests: 2,
+			givenMaxRetries:     3,
+			wantRequests:        3,
+		},
+		{
+			name:                "event is dropped once the retries are exhausted",
+			givenFailedRequests: 5,
+			givenMaxRetries:     1,
+			wantRequests:        2,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			var requests int32
+			sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= tc.givenFailedRequests {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer sink.Close()
+
+			client := NewMemoryClient()
+			kafka := newTestKafka(t, client, env.DefaultSubscriptionConfig{
+				DispatcherMaxRetries:  tc.givenMaxRetries,
+				DispatcherRetryPeriod: testRetryDelay,
+			})
+			sub := newTestSubscription("test", "sub", sink.URL, testEventType)
+
+			// when
+			require.NoError(t, kafka.SyncSubscription(sub))
+			require.NoError(t, client.Publish(testTopic, newTestEvent(t)))
+
+			// then
+			assert.Eventually(t, func() bool {
+				return atomic.LoadInt32(&requests) == tc.wantRequests
+			}, waitTimeout, waitInterval)
+			// the event is committed even if it's dropped
+			assert.Eventually(t, func() bool {
+				pending, err := kafka.PendingMessages()
+				return err == nil && pending == 0
+			}, waitTimeout, waitInterval)
+			assert.Equal(t, tc.wantRequests, atomic.LoadInt32(&requests))
+			assert.ElementsMatch(t, []string{testTopic}, client.Topics())
+		})
+	}
+}
+
+func TestKafka_SyncSubscriptionChangesTopics(t *testing.T) {
+	// given
+	var requests int32
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer sink.Close()
+
+	client := NewMemoryClient()
+	kafka := newTestKafka(t, client, env.DefaultSubscriptionConfig{})
+	sub := newTestSubscription("test", "sub", sink.URL, testEventType)
+	require.NoError(t, kafka.SyncSubscription(sub))
+
+	// when
+	otherEventType := "sap.kyma.custom.commerce.order.deleted.v1"
+	sub.Status.CleanEventTypes = []string{otherEventType}
+	require.NoError(t, kafka.SyncSubscription(sub))
+	require.NoError(t, client.Publish("kyma."+otherEventType, newTestEvent(t)))
+
+	// then
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&requests) == 1
+	}, waitTimeout, waitInterval)
+	assert.ElementsMatch(t, []string{testTopic, "kyma." + otherEventType}, client.Topics())
+}
+
+func TestKafka_DeleteSubscription(t *testing.T) {
+	// given
+	client := NewMemoryClient()
+	kafka := newTestKafka(t, client, env.DefaultSubscriptionConfig{})
+	sub := newTestSubscription("test", "sub", "http://sink.test.svc.cluster.local", testEventType)
+	require.NoError(t, kafka.SyncSubscription(sub))
+	require.Eventually(t, func() bool {
+		groupIDs, err := client.ListConsumerGroups()
+		return err == nil && len(groupIDs) == 1
+	}, waitTimeout, waitInterval)
+
+	// when
+	err := kafka.DeleteSubscription(sub)
+
+	// then
+	require.NoError(t, err)
+	groupIDs, err := client.ListConsumerGroups()
+	require.NoError(t, err)
+	assert.Empty(t, groupIDs)
+	pending, err := kafka.PendingMessages()
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+}
+
+func TestKafka_DeleteInvalidConsumerGroups(t *testing.T) {
+	// given
+	client := NewMemoryClient()
+	kafka := newTestKafka(t, client, env.DefaultSubscriptionConfig{})
+	validSub := newTestSubscription("test", "valid", "http://sink.test.svc.cluster.local", testEventType)
+	for _, groupID := range []string{"kyma.test.valid", "kyma.test.deleted", "other-application"} {
+		joinConsumerGroup(t, client, groupID)
+	}
+
+	// when
+	err := kafka.DeleteInvalidConsumerGroups([]eventingv1alpha1.Subscription{*validSub})
+
+	// then
+	require.NoError(t, err)
+	groupIDs, err := client.ListConsumerGroups()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"kyma.test.valid", "other-application"}, groupIDs)
+}
+
+func TestKafka_PendingMessages(t *testing.T) {
+	// given
+	client := NewMemoryClient()
+	kafka := newTestKafka(t, client, env.DefaultSubscriptionConfig{})
+	// the consumer groups aren't consumed by this instance, e.g. after a restart
+	for _, groupID := range []string{"kyma.test.sub", "other-application"} {
+		joinConsumerGroup(t, client, groupID)
+	}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, client.Publish("empty", newTestEvent(t)))
+	}
+
+	// when
+	pending, err := kafka.PendingMessages()
+
+	// then
+	require.NoError(t, err)
+	// consumer groups of other applications aren't counted
+	assert.Equal(t, int64(2), pending)
+}
+
+func TestMemoryClient_Lag(t *testing.T) {
+	// given
+	client := NewMemoryClient()
+	joinConsumerGroup(t, client, "group")
+	require.NoError(t, client.CreateTopic("uncommitted"))
+	require.NoError(t, client.Publish("empty", newTestEvent(t)))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.Publish("uncommitted", newTestEvent(t)))
+	}
+
+	// when
+	lag, err := client.Lag("group", []string{"uncommitted"})
+
+	// then
+	require.NoError(t, err)
+	// the topic without a committed offset counts from the oldest message
+	assert.Equal(t, int64(4), lag)
+}
+
+func newTestKafka(t *testing.T, client Client, subsConfig env.DefaultSubscriptionConfig) *Kafka {
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	config := env.KafkaConfig{TopicPrefix: "kyma", ConsumerGroupPrefix: "kyma"}
+	kafka := NewKafka(config, client, subsConfig, backendmetrics.NewCollector(), defaultLogger)
+	require.NoError(t, kafka.Initialize())
+	t.Cleanup(func() {
+		require.NoError(t, kafka.Shutdown())
+	})
+	return kafka
+}
+
+func newTestSubscription(namespace, name, sink string, eventTypes ...string) *eventingv1alpha1.Subscription {
+	return &eventingv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       eventingv1alpha1.SubscriptionSpec{Sink: sink},
+		Status:     eventingv1alpha1.SubscriptionStatus{CleanEventTypes: eventTypes},
+	}
+}
+
+func newTestEvent(t *testing.T) []byte {
+	event := cev2event.New(cev2event.CloudEventsVersionV1)
+	event.SetID("00000000-0000-0000-0000-000000000000")
+	event.SetSource("commerce")
+	event.SetType(testEventType)
+	require.NoError(t, event.SetData(cev2event.ApplicationJSON, map[string]string{"orderId": "42"}))
+	value, err := json.Marshal(event)
+	require.NoError(t, err)
+	return value
+}
+
+// joinConsumerGroup creates the consumer group by consuming a topic until it's empty.
+func joinConsumerGroup(t *testing.T, client *MemoryClient, groupID string) {
+	require.NoError(t, client.CreateTopic("empty"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, client.Consume(ctx, groupID, []string{"empty"}, func(context.Context, *Message) error {
+		return nil
+	}))
+}
@@ -0,0 +1,173 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+)
+
+// compile time check
+var _ Client = &MemoryClient{}
+
+// MemoryClient is an in-memory Kafka cluster, which allows running the Kafka backend without Kafka brokers,
+// e.g. in tests. Each topic has a single partition, new consumer groups start with the oldest message and
+// a consumer group supports a single member only.
+type MemoryClient struct {
+	mutex   sync.Mutex
+	topics  map[string][][]byte
+	offsets map[string]map[string]int64
+	// published is closed and replaced whenever a message is published to wake up the waiting consumers
+	published chan struct{}
+	closed    bool
+}
+
+// NewMemoryClient returns an empty in-memory Kafka cluster.
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{
+		topics:    make(map[string][][]byte),
+		offsets:   make(map[string]map[string]int64),
+		published: make(chan struct{}),
+	}
+}
+
+// Publish appends the message to the topic, which must exist.
+func (c *MemoryClient) Publish(topic string, value []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	messages, ok := c.topics[topic]
+	if !ok {
+		return ErrUnknownTopic
+	}
+	c.topics[topic] = append(messages, value)
+	close(c.published)
+	c.published = make(chan struct{})
+	return nil
+}
+
+// Topics returns the names of all topics.
+func (c *MemoryClient) Topics() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	topics := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+func (c *MemoryClient) CreateTopic(topic string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	if _, ok := c.topics[topic]; !ok {
+		c.topics[topic] = nil
+	}
+	return nil
+}
+
+func (c *MemoryClient) Consume(ctx context.Context, groupID string, topics []string, handler MessageHandler) error {
+	for {
+		msg, published, err := c.next(groupID, topics)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			// wait until a message is published
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-published:
+				continue
+			}
+		}
+		if err := handler(ctx, msg); err != nil {
+			return nil
+		}
+		c.commit(groupID, msg)
+	}
+}
+
+// next returns the next message of the consumer group. If there is none, it returns the channel
+// which is closed once a message is published.
+func (c *MemoryClient) next(groupID string, topics []string) (*Message, <-chan struct{}, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return nil, nil, ErrClosed
+	}
+	offsets, ok := c.offsets[groupID]
+	if !ok {
+		offsets = make(map[string]int64)
+		c.offsets[groupID] = offsets
+	}
+	for _, topic := range topics {
+		messages, ok := c.topics[topic]
+		if !ok {
+			return nil, nil, ErrUnknownTopic
+		}
+		offset, ok := offsets[topic]
+		if !ok {
+			offsets[topic] = 0
+		}
+		if offset < int64(len(messages)) {
+			return &Message{Topic: topic, Offset: offset, Value: messages[offset]}, nil, nil
+		}
+	}
+	return nil, c.published, nil
+}
+
+func (c *MemoryClient) commit(groupID string, msg *Message) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// the consumer group might have been deleted in the meantime
+	if offsets, ok := c.offsets[groupID]; ok {
+		offsets[msg.Topic] = msg.Offset + 1
+	}
+}
+
+func (c *MemoryClient) ListConsumerGroups() ([]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	groupIDs := make([]string, 0, len(c.offsets))
+	for groupID := range c.offsets {
+		groupIDs = append(groupIDs, groupID)
+	}
+	return groupIDs, nil
+}
+
+func (c *MemoryClient) DeleteConsumerGroup(groupID string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.offsets, groupID)
+	return nil
+}
+
+func (c *MemoryClient) Lag(groupID string, topics []string) (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	offsets := c.offsets[groupID]
+	subscribedTopics := make(map[string]bool, len(topics)+len(offsets))
+	for _, topic := range topics {
+		subscribedTopics[topic] = true
+	}
+	for topic := range offsets {
+		subscribedTopics[topic] = true
+	}
+	var lag int64
+	for topic := range subscribedTopics {
+		// topics without a committed offset count from the oldest message
+		lag += int64(len(c.topics[topic])) - offsets[topic]
+	}
+	return lag, nil
+}
+
+func (c *MemoryClient) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.closed = true
+	return nil
+}
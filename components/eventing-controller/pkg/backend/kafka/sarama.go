@@ -0,0 +1,233 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Shopify/sarama"
+	"golang.org/x/xerrors"
+
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/env"
+)
+
+const (
+	InitialOffsetNewest = "newest"
+	InitialOffsetOldest = "oldest"
+)
+
+// compile time check
+var _ Client = &saramaClient{}
+
+// saramaClient implements the Client interface using the Sarama Kafka client.
+type saramaClient struct {
+	brokers      []string
+	saramaConfig *sarama.Config
+	config       env.KafkaConfig
+	client       sarama.Client
+	admin        sarama.ClusterAdmin
+}
+
+// NewSaramaClient connects to the Kafka brokers of the given config.
+func NewSaramaClient(config env.KafkaConfig) (Client, error) {
+	saramaConfig, err := newSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	client, err := sarama.NewClient(config.Brokers, saramaConfig)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to connect to Kafka brokers %v: %v", config.Brokers, err)
+	}
+	// closing the cluster admin closes the client as well
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, xerrors.Errorf("failed to create Kafka cluster admin: %v", err)
+	}
+	return &saramaClient{
+		brokers:      config.Brokers,
+		saramaConfig: saramaConfig,
+		config:       config,
+		client:       client,
+		admin:        admin,
+	}, nil
+}
+
+func newSaramaConfig(config env.KafkaConfig) (*sarama.Config, error) {
+	version, err := sarama.ParseKafkaVersion(config.Version)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid Kafka version %q: %v", config.Version, err)
+	}
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Version = version
+	saramaConfig.ClientID = config.ClientID
+	switch config.InitialOffset {
+	case InitialOffsetNewest:
+		saramaConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
+	case InitialOffsetOldest:
+		saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+	default:
+		return nil, xerrors.Errorf("invalid Kafka initial offset %q, allowed values are %q and %q",
+			config.InitialOffset, InitialOffsetNewest, InitialOffsetOldest)
+	}
+	return saramaConfig, nil
+}
+
+func (c *saramaClient) CreateTopic(topic string) error {
+	err := c.admin.CreateTopic(topic, &sarama.TopicDetail{
+		NumPartitions:     c.config.TopicPartitions,
+		ReplicationFactor: c.config.TopicReplicationFactor,
+	}, false)
+	var topicErr *sarama.TopicError
+	if errors.As(err, &topicErr) && topicErr.Err == sarama.ErrTopicAlreadyExists {
+		return nil
+	}
+	return err
+}
+
+func (c *saramaClient) Consume(ctx context.Context, groupID string, topics []string, handler MessageHandler) error {
+	// a consumer group can't share the client with other consumer groups, so it uses its own
+	group, err := sarama.NewConsumerGroup(c.brokers, groupID, c.saramaConfig)
+	if err != nil {
+		return xerrors.Errorf("failed to create Kafka consumer group %s: %v", groupID, err)
+	}
+	defer func() { _ = group.Close() }()
+
+	groupHandler := &consumerGroupHandler{handler: handler}
+	for ctx.Err() == nil {
+		// Consume returns whenever the consumer group rebalances, so it's called again to rejoin the group
+		if err := group.Consume(ctx, topics, groupHandler); err != nil {
+			return xerrors.Errorf("failed to consume Kafka topics %v: %v", topics, err)
+		}
+	}
+	return nil
+}
+
+func (c *saramaClient) ListConsumerGroups() ([]string, error) {
+	groups, err := c.admin.ListConsumerGroups()
+	if err != nil {
+		return nil, err
+	}
+	groupIDs := make([]string, 0, len(groups))
+	for groupID := range groups {
+		groupIDs = append(groupIDs, groupID)
+	}
+	return groupIDs, nil
+}
+
+func (c *saramaClient) DeleteConsumerGroup(groupID string) error {
+	if err := c.admin.DeleteConsumerGroup(groupID); err != nil && !errors.Is(err, sarama.ErrGroupIDNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (c *saramaClient) Lag(groupID string, topics []string) (int64, error) {
+	offsets, err := c.admin.ListConsumerGroupOffsets(groupID, nil)
+	if err != nil {
+		return 0, err
+	}
+	if offsets.Err != sarama.ErrNoError {
+		return 0, offsets.Err
+	}
+	subscribedTopics, err := c.subscribedTopics(groupID, topics, offsets)
+	if err != nil {
+		return 0, err
+	}
+	var lag int64
+	for topic := range subscribedTopics {
+		partitions, err := c.client.Partitions(topic)
+		if err != nil {
+			return 0, err
+		}
+		for _, partition := range partitions {
+			newest, err := c.client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return 0, err
+			}
+			committed, err := c.committedOffset(topic, partition, offsets)
+			if err != nil {
+				return 0, err
+			}
+			lag += newest - committed
+		}
+	}
+	return lag, nil
+}
+
+// subscribedTopics returns the given topics, the topics the consumer group committed offsets for,
+// and the topics its members subscribed to.
+func (c *saramaClient) subscribedTopics(groupID string, topics []string, offsets *sarama.OffsetFetchResponse) (map[string]bool, error) {
+	subscribedTopics := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		subscribedTopics[topic] = true
+	}
+	for topic := range offsets.Blocks {
+		subscribedTopics[topic] = true
+	}
+	groups, err := c.admin.DescribeConsumerGroups([]string{groupID})
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		for _, member := range group.Members {
+			metadata, err := member.GetMemberMetadata()
+			if err != nil {
+				return nil, err
+			}
+			for _, topic := range metadata.Topics {
+				subscribedTopics[topic] = true
+			}
+		}
+	}
+	return subscribedTopics, nil
+}
+
+// committedOffset returns the offset the consumer group committed for the partition. If the consumer group
+// didn't commit an offset yet, it returns the oldest offset of the partition, where new consumer groups start.
+func (c *saramaClient) committedOffset(topic string, partition int32, offsets *sarama.OffsetFetchResponse) (int64, error) {
+	if block := offsets.GetBlock(topic, partition); block != nil {
+		if block.Err != sarama.ErrNoError {
+			return 0, block.Err
+		}
+		if block.Offset >= 0 {
+			return block.Offset, nil
+		}
+	}
+	return c.client.GetOffset(topic, partition, sarama.OffsetOldest)
+}
+
+func (c *saramaClient) Close() error {
+	return c.admin.Close()
+}
+
+// consumerGroupHandler passes the messages of the claimed partitions to the MessageHandler.
+type consumerGroupHandler struct {
+	handler MessageHandler
+}
+
+func (h *consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *consumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			message := &Message{Topic: msg.Topic, Partition: msg.Partition, Offset: msg.Offset, Value: msg.Value}
+			if err := h.handler(session.Context(), message); err != nil {
+				// the message is not marked, so it's consumed again once the consumer group rejoins
+				return nil
+			}
+			session.MarkMessage(msg, "")
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
@@ -328,6 +328,17 @@ func (js *JetStream) GetJetStreamContext() nats.JetStreamContext {
 	return js.jsCtx
 }
 
+// Shutdown closes the connection to the NATS server, so the messages are no longer dispatched.
+// Unlike DeleteSubscription, it keeps the consumers on the NATS server.
+func (js *JetStream) Shutdown() {
+	if js.conn == nil {
+		return
+	}
+	// the connection is closed on purpose, so the subscriptions mustn't be reconciled
+	js.conn.SetClosedHandler(nil)
+	js.conn.Close()
+}
+
 func (js *JetStream) validateConfig() error {
 	if js.Config.JSStreamName == "" {
 		return errors.New("Stream name cannot be empty")
@@ -668,7 +679,7 @@ func (js *JetStream) getCallback(subKeyPrefix, subscriptionName string) nats.Msg
 		ctxWithCancel, cancel := context.WithCancel(context.Background())
 		defer cancel()
 		ctxWithCE := cev2.ContextWithTarget(ctxWithCancel, sink)
-		traceCtxWithCE := tracing.AddTracingHeadersToContext(ctxWithCE, ce)
+		traceCtxWithCE, span := tracing.StartDispatchSpan(ctxWithCE, ce, subKeyPrefix, sink, backendutils.GetRedeliveryCount(msg))
 
 		// decorate the logger with CloudEvent context
 		ceLogger := js.namedLogger().With("id", ce.ID(), "source", ce.Source(), "type", ce.Type(), "sink", sink)
@@ -677,6 +688,7 @@ func (js *JetStream) getCallback(subKeyPrefix, subscriptionName string) nats.Msg
 
 		// dispatch the event to sink
 		result := js.client.Send(traceCtxWithCE, *ce)
+		tracing.EndDispatchSpan(span, result)
 		if !cev2protocol.IsACK(result) {
 			js.metricsCollector.RecordDeliveryPerSubscription(subscriptionName, ce.Type(), sink, http.StatusInternalServerError)
 			ceLogger.Errorw("Failed to dispatch the CloudEvent")
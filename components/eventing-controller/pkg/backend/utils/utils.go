@@ -199,6 +199,16 @@ func ConvertMsgToCE(msg *nats.Msg) (*cev2event.Event, error) {
 	return &event, nil
 }
 
+// GetRedeliveryCount returns how often the JetStream message was delivered before, or zero if the message doesn't
+// have JetStream metadata.
+func GetRedeliveryCount(msg *nats.Msg) int64 {
+	metadata, err := msg.Metadata()
+	if err != nil || metadata.NumDelivered == 0 {
+		return 0
+	}
+	return int64(metadata.NumDelivered - 1)
+}
+
 // LoggerWithSubscription returns a logger with the given subscription details.
 func LoggerWithSubscription(log *zap.SugaredLogger, subscription *eventingv1alpha1.Subscription) *zap.SugaredLogger {
 	return log.With(
@@ -9,6 +9,7 @@ import (
 	"github.com/kyma-project/kyma/components/eventing-controller/pkg/ems/api/events/types"
 	eventingtesting "github.com/kyma-project/kyma/components/eventing-controller/testing"
 	"github.com/kyma-project/kyma/components/eventing-controller/utils"
+	"github.com/nats-io/nats.go"
 	. "github.com/onsi/gomega"
 )
 
@@ -188,3 +189,31 @@ func TestGetInternalView4Ev2(t *testing.T) {
 
 	})
 }
+
+func TestGetRedeliveryCount(t *testing.T) {
+	g := NewGomegaWithT(t)
+	testCases := []struct {
+		name  string
+		msg   *nats.Msg
+		count int64
+	}{
+		{
+			name:  "first delivery of a JetStream message",
+			msg:   &nats.Msg{Sub: &nats.Subscription{}, Reply: "$JS.ACK.kyma.consumer.1.10.20.1671100000000000000.0"},
+			count: 0,
+		},
+		{
+			name:  "redelivered JetStream message",
+			msg:   &nats.Msg{Sub: &nats.Subscription{}, Reply: "$JS.ACK.kyma.consumer.3.10.20.1671100000000000000.0"},
+			count: 2,
+		},
+		{
+			name:  "message without JetStream metadata",
+			msg:   &nats.Msg{},
+			count: 0,
+		},
+	}
+	for _, tc := range testCases {
+		g.Expect(GetRedeliveryCount(tc.msg)).To(Equal(tc.count), tc.name)
+	}
+}
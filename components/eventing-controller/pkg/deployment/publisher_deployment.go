@@ -58,6 +58,7 @@ func NewBEBPublisherDeployment(publisherConfig env.PublisherConfig) *appsv1.Depl
 		WithContainers(publisherConfig),
 		WithBEBEnvVars(publisherConfig),
 		WithLogEnvVars(publisherConfig),
+		WithTracingEnvVars(publisherConfig),
 	)
 }
 func NewNATSPublisherDeployment(natsConfig env.NatsConfig, publisherConfig env.PublisherConfig) *appsv1.Deployment {
@@ -67,6 +68,19 @@ func NewNATSPublisherDeployment(natsConfig env.NatsConfig, publisherConfig env.P
 		WithContainers(publisherConfig),
 		WithNATSEnvVars(natsConfig, publisherConfig),
 		WithLogEnvVars(publisherConfig),
+		WithTracingEnvVars(publisherConfig),
+		WithAffinity(),
+	)
+}
+
+func NewKafkaPublisherDeployment(kafkaConfig env.KafkaConfig, publisherConfig env.PublisherConfig) *appsv1.Deployment {
+	return NewDeployment(
+		publisherConfig,
+		WithLabels(v1alpha1.KafkaBackendType),
+		WithContainers(publisherConfig),
+		WithKafkaEnvVars(kafkaConfig, publisherConfig),
+		WithLogEnvVars(publisherConfig),
+		WithTracingEnvVars(publisherConfig),
 		WithAffinity(),
 	)
 }
@@ -166,6 +180,16 @@ func WithLogEnvVars(publisherConfig env.PublisherConfig) DeployOpt {
 	}
 }
 
+func WithTracingEnvVars(publisherConfig env.PublisherConfig) DeployOpt {
+	return func(d *appsv1.Deployment) {
+		for i, container := range d.Spec.Template.Spec.Containers {
+			if strings.EqualFold(container.Name, PublisherName) {
+				d.Spec.Template.Spec.Containers[i].Env = append(d.Spec.Template.Spec.Containers[i].Env, getTracingEnvVars(publisherConfig)...)
+			}
+		}
+	}
+}
+
 func WithNATSEnvVars(natsConfig env.NatsConfig, publisherConfig env.PublisherConfig) DeployOpt {
 	return func(d *appsv1.Deployment) {
 		for i, container := range d.Spec.Template.Spec.Containers {
@@ -176,6 +200,16 @@ func WithNATSEnvVars(natsConfig env.NatsConfig, publisherConfig env.PublisherCon
 	}
 }
 
+func WithKafkaEnvVars(kafkaConfig env.KafkaConfig, publisherConfig env.PublisherConfig) DeployOpt {
+	return func(d *appsv1.Deployment) {
+		for i, container := range d.Spec.Template.Spec.Containers {
+			if strings.EqualFold(container.Name, PublisherName) {
+				d.Spec.Template.Spec.Containers[i].Env = getKafkaEnvVars(kafkaConfig, publisherConfig)
+			}
+		}
+	}
+}
+
 func WithBEBEnvVars(publisherConfig env.PublisherConfig) DeployOpt {
 	return func(d *appsv1.Deployment) {
 		for i, container := range d.Spec.Template.Spec.Containers {
@@ -256,6 +290,12 @@ func getLogEnvVars(publisherConfig env.PublisherConfig) []v1.EnvVar {
 	}
 }
 
+func getTracingEnvVars(publisherConfig env.PublisherConfig) []v1.EnvVar {
+	return []v1.EnvVar{
+		{Name: "TRACING_OTLP_ENDPOINT", Value: publisherConfig.TracingOTLPEndpoint},
+	}
+}
+
 func getBEBEnvVars(publisherConfig env.PublisherConfig) []v1.EnvVar {
 	return []v1.EnvVar{
 		{Name: "BACKEND", Value: "beb"},
@@ -342,6 +382,29 @@ func getNATSEnvVars(natsConfig env.NatsConfig, publisherConfig env.PublisherConf
 	}
 }
 
+func getKafkaEnvVars(kafkaConfig env.KafkaConfig, publisherConfig env.PublisherConfig) []v1.EnvVar {
+	return []v1.EnvVar{
+		{Name: "BACKEND", Value: "kafka"},
+		{Name: "PORT", Value: strconv.Itoa(int(publisherPortNum))},
+		{Name: "KAFKA_BROKERS", Value: strings.Join(kafkaConfig.Brokers, ",")},
+		{Name: "KAFKA_VERSION", Value: kafkaConfig.Version},
+		{Name: "KAFKA_TOPIC_PREFIX", Value: kafkaConfig.TopicPrefix},
+		{Name: "REQUEST_TIMEOUT", Value: publisherConfig.RequestTimeout},
+		{Name: "LEGACY_NAMESPACE", Value: "kyma"},
+		{
+			Name: "EVENT_TYPE_PREFIX",
+			ValueFrom: &v1.EnvVarSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: configMapName,
+					},
+					Key: configMapKeyEventTypePrefix,
+				},
+			},
+		},
+	}
+}
+
 func getResources(requestsCPU, requestsMemory, limitsCPU, limitsMemory string) v1.ResourceRequirements {
 	return v1.ResourceRequirements{
 		Requests: v1.ResourceList{
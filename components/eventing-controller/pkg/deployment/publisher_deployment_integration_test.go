@@ -14,7 +14,8 @@ import (
 )
 
 const (
-	natsURL = "eventing-nats.kyma-system.svc.cluster.local"
+	natsURL      = "eventing-nats.kyma-system.svc.cluster.local"
+	kafkaBrokers = "kafka-0.kafka:9092,kafka-1.kafka:9092"
 )
 
 func TestNewDeployment(t *testing.T) {
@@ -43,6 +44,11 @@ func TestNewDeployment(t *testing.T) {
 			givenBackend:          "BEB",
 			wantBackendAssertions: bebBackendAssertions,
 		},
+		{
+			name:                  "Kafka should be set properly after calling the constructor",
+			givenBackend:          "Kafka",
+			wantBackendAssertions: kafkaBackendAssertions,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -60,6 +66,13 @@ func TestNewDeployment(t *testing.T) {
 				deployment = NewNATSPublisherDeployment(natsConfig, publisherConfig)
 			case "BEB":
 				deployment = NewBEBPublisherDeployment(publisherConfig)
+			case "Kafka":
+				kafkaConfig := env.KafkaConfig{
+					Brokers:     strings.Split(kafkaBrokers, ","),
+					Version:     "2.8.0",
+					TopicPrefix: "kyma",
+				}
+				deployment = NewKafkaPublisherDeployment(kafkaConfig, publisherConfig)
 			default:
 				t.Errorf("Invalid backend!")
 			}
@@ -196,6 +209,47 @@ func Test_GetLogEnvVars(t *testing.T) {
 	}
 }
 
+func Test_GetTracingEnvVars(t *testing.T) {
+	testCases := []struct {
+		name      string
+		givenEnvs map[string]string
+		wantEnvs  map[string]string
+	}{
+		{
+			name:      "TRACING_OTLP_ENDPOINT should stay empty",
+			givenEnvs: map[string]string{},
+			wantEnvs: map[string]string{
+				"TRACING_OTLP_ENDPOINT": "",
+			},
+		},
+		{
+			name: "TRACING_OTLP_ENDPOINT should take the controller value",
+			givenEnvs: map[string]string{
+				"TRACING_OTLP_ENDPOINT": "http://collector.kyma-system:4318",
+			},
+			wantEnvs: map[string]string{
+				"TRACING_OTLP_ENDPOINT": "http://collector.kyma-system:4318",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.givenEnvs {
+				t.Setenv(k, v)
+			}
+			backendConfig := env.GetBackendConfig()
+			envVars := getTracingEnvVars(backendConfig.PublisherConfig)
+
+			// ensure the right envs were set
+			for index, val := range tc.wantEnvs {
+				gotEnv := findEnvVar(envVars, index)
+				assert.NotNil(t, gotEnv)
+				assert.Equal(t, val, gotEnv.Value)
+			}
+		})
+	}
+}
+
 func Test_GetBEBEnvVars(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -256,6 +310,24 @@ func natsBackendAssertions(t *testing.T, deployment appsv1.Deployment) {
 	}
 }
 
+// kafkaBackendAssertions checks that the Kafka-specific data was set in the NewKafkaPublisherDeployment.
+func kafkaBackendAssertions(t *testing.T, deployment appsv1.Deployment) {
+	container := findPublisherContainer(deployment)
+	assert.NotNil(t, container)
+
+	backend := findEnvVar(container.Env, "BACKEND")
+	assert.Equal(t, backend.Value, "kafka")
+	brokers := findEnvVar(container.Env, "KAFKA_BROKERS")
+	assert.Equal(t, brokers.Value, kafkaBrokers)
+	version := findEnvVar(container.Env, "KAFKA_VERSION")
+	assert.Equal(t, version.Value, "2.8.0")
+	topicPrefix := findEnvVar(container.Env, "KAFKA_TOPIC_PREFIX")
+	assert.Equal(t, topicPrefix.Value, "kyma")
+
+	// check the affinity was set
+	assert.NotEmpty(t, deployment.Spec.Template.Spec.Affinity)
+}
+
 // bebBackendAssertions checks that the beb-specific data was set in the NewBEBPublisherDeployment.
 func bebBackendAssertions(t *testing.T, deployment appsv1.Deployment) {
 	container := findPublisherContainer(deployment)
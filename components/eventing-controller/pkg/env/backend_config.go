@@ -19,6 +19,10 @@ type BackendConfig struct {
 	//nolint:lll
 	ValidatingWebhookName string `envconfig:"VALIDATING_WEBHOOK_NAME" default:"subscription-validating-webhook-configuration"`
 
+	// MigrationDrainPeriod is the longest time the previous backend keeps running after the Event Publisher Proxy
+	// switched to the new backend. Zero stops the previous backend immediately.
+	MigrationDrainPeriod time.Duration `envconfig:"BACKEND_MIGRATION_DRAIN_PERIOD" default:"0s"`
+
 	DefaultSubscriptionConfig DefaultSubscriptionConfig
 }
 
@@ -38,6 +42,8 @@ type PublisherConfig struct {
 	// publisher takes the controller values
 	AppLogFormat string `envconfig:"APP_LOG_FORMAT" default:"json"`
 	AppLogLevel  string `envconfig:"APP_LOG_LEVEL" default:"info"`
+	// TracingOTLPEndpoint is the OTLP/HTTP endpoint the spans are exported to. Tracing is disabled if it's empty.
+	TracingOTLPEndpoint string `envconfig:"TRACING_OTLP_ENDPOINT" default:""`
 }
 
 type DefaultSubscriptionConfig struct {
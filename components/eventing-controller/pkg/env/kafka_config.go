@@ -0,0 +1,56 @@
+package env
+
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// KafkaConfig represents the environment config for the Eventing Controller with Kafka.
+type KafkaConfig struct {
+	// Brokers are the addresses of the Kafka brokers. The Kafka backend is disabled if no broker is set.
+	Brokers []string `envconfig:"KAFKA_BROKERS"`
+	// Version is the Kafka version the brokers run, e.g. 2.8.0.
+	Version string `envconfig:"KAFKA_VERSION" default:"2.8.0"`
+	// ClientID identifies the Eventing Controller in the logs and metrics of the brokers.
+	ClientID string `envconfig:"KAFKA_CLIENT_ID" default:"eventing-controller"`
+
+	// TopicPrefix is prepended to the clean event type to build the name of the topic storing the events.
+	// The Event Publisher Proxy must use the same prefix.
+	TopicPrefix string `envconfig:"KAFKA_TOPIC_PREFIX" default:"kyma"`
+	// Number of partitions and replicas of the topics created for the subscriptions.
+	TopicPartitions        int32 `envconfig:"KAFKA_TOPIC_PARTITIONS" default:"1"`
+	TopicReplicationFactor int16 `envconfig:"KAFKA_TOPIC_REPLICATION_FACTOR" default:"1"`
+	// ConsumerGroupPrefix is prepended to the namespace and name of the subscription to build the consumer group ID.
+	ConsumerGroupPrefix string `envconfig:"KAFKA_CONSUMER_GROUP_PREFIX" default:"kyma"`
+	// InitialOffset determines where a new consumer group starts consuming the topics:
+	// - newest: The consumer group receives only the events published after it joined.
+	// - oldest: The consumer group receives all events still stored in the topics.
+	InitialOffset string `envconfig:"KAFKA_INITIAL_OFFSET" default:"newest"`
+
+	// EventTypePrefix prefix for the EventType
+	// note: eventType format is <prefix>.<application>.<event>.<version>
+	EventTypePrefix string `envconfig:"EVENT_TYPE_PREFIX"`
+
+	// HTTP Transport config for the message dispatcher
+	MaxIdleConns        int           `envconfig:"MAX_IDLE_CONNS" default:"50"`
+	MaxConnsPerHost     int           `envconfig:"MAX_CONNS_PER_HOST" default:"50"`
+	MaxIdleConnsPerHost int           `envconfig:"MAX_IDLE_CONNS_PER_HOST" default:"50"`
+	IdleConnTimeout     time.Duration `envconfig:"IDLE_CONN_TIMEOUT" default:"10s"`
+
+	// EnableNewCRDVersion changes the Subscription CRD to v1alpha2, which the Kafka backend doesn't support yet.
+	EnableNewCRDVersion bool `envconfig:"ENABLE_NEW_CRD_VERSION" default:"false"`
+}
+
+// IsEnabled returns true if the Kafka backend is configured.
+func (c KafkaConfig) IsEnabled() bool {
+	return len(c.Brokers) > 0
+}
+
+func GetKafkaConfig() (KafkaConfig, error) {
+	cfg := KafkaConfig{}
+	if err := envconfig.Process("", &cfg); err != nil {
+		return KafkaConfig{}, err
+	}
+	return cfg, nil
+}
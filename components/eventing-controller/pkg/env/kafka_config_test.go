@@ -0,0 +1,111 @@
+package env
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetKafkaConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		envs        map[string]string
+		want        KafkaConfig
+		wantEnabled bool
+		wantErr     bool
+	}{
+		{name: "Empty env gives disabled default config",
+			want: KafkaConfig{
+				Version:                "2.8.0",
+				ClientID:               "eventing-controller",
+				TopicPrefix:            "kyma",
+				TopicPartitions:        1,
+				TopicReplicationFactor: 1,
+				ConsumerGroupPrefix:    "kyma",
+				InitialOffset:          "newest",
+				MaxIdleConns:           50,
+				MaxConnsPerHost:        50,
+				MaxIdleConnsPerHost:    50,
+				IdleConnTimeout:        10 * time.Second,
+			},
+			wantEnabled: false,
+		},
+		{name: "Envs are mapped correctly",
+			envs: map[string]string{
+				"KAFKA_BROKERS":                  "kafka-0:9092,kafka-1:9092",
+				"KAFKA_VERSION":                  "3.3.1",
+				"KAFKA_CLIENT_ID":                "kcid",
+				"KAFKA_TOPIC_PREFIX":             "ktp",
+				"KAFKA_TOPIC_PARTITIONS":         "2",
+				"KAFKA_TOPIC_REPLICATION_FACTOR": "3",
+				"KAFKA_CONSUMER_GROUP_PREFIX":    "kcgp",
+				"KAFKA_INITIAL_OFFSET":           "oldest",
+				"EVENT_TYPE_PREFIX":              "etp",
+				"MAX_IDLE_CONNS":                 "4",
+				"MAX_CONNS_PER_HOST":             "5",
+				"MAX_IDLE_CONNS_PER_HOST":        "6",
+				"IDLE_CONN_TIMEOUT":              "1s",
+				"ENABLE_NEW_CRD_VERSION":         "true",
+			},
+			want: KafkaConfig{
+				Brokers:                []string{"kafka-0:9092", "kafka-1:9092"},
+				Version:                "3.3.1",
+				ClientID:               "kcid",
+				TopicPrefix:            "ktp",
+				TopicPartitions:        2,
+				TopicReplicationFactor: 3,
+				ConsumerGroupPrefix:    "kcgp",
+				InitialOffset:          "oldest",
+				EventTypePrefix:        "etp",
+				MaxIdleConns:           4,
+				MaxConnsPerHost:        5,
+				MaxIdleConnsPerHost:    6,
+				IdleConnTimeout:        1 * time.Second,
+				EnableNewCRDVersion:    true,
+			},
+			wantEnabled: true,
+		},
+		{name: "Invalid number of partitions triggers error",
+			envs: map[string]string{
+				"KAFKA_TOPIC_PARTITIONS": "many",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Store the current environ and restore it after this test.
+			// A wrongly set up environment would break this test otherwise.
+			env := os.Environ()
+			t.Cleanup(func() {
+				for _, e := range env {
+					s := strings.Split(e, "=")
+					os.Setenv(s[0], s[1])
+				}
+			})
+
+			// Clean the environment to make this test reliable.
+			os.Clearenv()
+			for k, v := range tt.envs {
+				t.Setenv(k, v)
+			}
+
+			got, err := GetKafkaConfig()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetKafkaConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetKafkaConfig() got = %v, want %v", got, tt.want)
+			}
+			if got.IsEnabled() != tt.wantEnabled {
+				t.Errorf("IsEnabled() got = %v, want %v", got.IsEnabled(), tt.wantEnabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package env
+
+import (
+	"github.com/kelseyhightower/envconfig"
+)
+
+// TracingConfig represents the environment config for tracing the dispatched events.
+type TracingConfig struct {
+	// OTLPEndpoint is the OTLP/HTTP endpoint the spans are exported to. Tracing is disabled if it's empty.
+	OTLPEndpoint string `envconfig:"TRACING_OTLP_ENDPOINT" default:""`
+}
+
+func GetTracingConfig() (TracingConfig, error) {
+	cfg := TracingConfig{}
+	if err := envconfig.Process("", &cfg); err != nil {
+		return TracingConfig{}, err
+	}
+	return cfg, nil
+}
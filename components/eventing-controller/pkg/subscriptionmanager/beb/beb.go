@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
@@ -43,6 +44,9 @@ const (
 	subscriptionManagerName = "beb-subscription-manager"
 )
 
+// compile time check
+var _ subscriptionmanager.Migratable = &SubscriptionManager{}
+
 // AddToScheme adds the own schemes to the runtime scheme.
 func AddToScheme(scheme *runtime.Scheme) error {
 	if err := clientgoscheme.AddToScheme(scheme); err != nil {
@@ -76,6 +80,8 @@ type SubscriptionManager struct {
 	bebBackend       backendbeb.Backend
 	eventMeshBackend backendeventmesh.Backend
 	logger           *logger.Logger
+	// drainedSubscriptions are the subscriptions which existed when reconciling was stopped
+	drainedSubscriptions []eventingv1alpha1.Subscription
 }
 
 // NewSubscriptionManager creates the SubscriptionManager for BEB and initializes it as far as it
@@ -179,6 +185,56 @@ func (c *SubscriptionManager) Stop(runCleanup bool) error {
 	return c.stopBebBackend(runCleanup)
 }
 
+// StopReconciling stops the BEB subscription controller, but keeps the BEB subscriptions, so BEB keeps dispatching the messages.
+// Only the v1alpha1 subscriptions can be drained.
+func (c *SubscriptionManager) StopReconciling() error {
+	if c.envCfg.EnableNewCRDVersion {
+		return errors.New("EventMesh subscription manager can't be drained with v1alpha2 subscriptions")
+	}
+
+	var subs eventingv1alpha1.SubscriptionList
+	if err := c.mgr.GetClient().List(context.Background(), &subs); err != nil {
+		return fmt.Errorf("failed to get all subscription resources: %w", err)
+	}
+	c.drainedSubscriptions = subs.Items
+	c.cancel()
+	c.namedLogger().Info("Stopped reconciling BEB subscriptions")
+	return nil
+}
+
+// StopDispatching deletes the BEB subscriptions and the APIRules of the subscriptions which existed when reconciling was stopped
+// if runCleanup is true, the statuses of the subscriptions are left unchanged. Otherwise, BEB keeps dispatching the messages
+// until the subscription manager is started again.
+func (c *SubscriptionManager) StopDispatching(runCleanup bool) error {
+	if !runCleanup {
+		c.drainedSubscriptions = nil
+		return nil
+	}
+
+	bebBackend, ok := c.bebBackend.(*backendbeb.BEB)
+	if !ok {
+		return xerrors.Errorf("no BEB backend exists: convert backend handler to BEB handler failed")
+	}
+
+	dynamicClient := dynamic.NewForConfigOrDie(c.restCfg)
+	for i := range c.drainedSubscriptions {
+		sub := &c.drainedSubscriptions[i]
+		if apiRule := sub.Status.APIRuleName; apiRule != "" {
+			err := dynamicClient.Resource(utils.APIRuleGroupVersionResource()).Namespace(sub.Namespace).
+				Delete(context.Background(), apiRule, metav1.DeleteOptions{})
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return xerrors.Errorf("failed to delete APIRule %s/%s: %v", sub.Namespace, apiRule, err)
+			}
+		}
+		if err := bebBackend.DeleteSubscription(sub); err != nil {
+			return xerrors.Errorf("failed to delete BEB subscription %s/%s: %v", sub.Namespace, sub.Name, err)
+		}
+	}
+	c.drainedSubscriptions = nil
+	c.namedLogger().Info("Deleted drained BEB subscriptions")
+	return nil
+}
+
 // stopBebBackend stops and cleans all EventMesh backend (based on Subscription v1alpha1)
 func (c *SubscriptionManager) stopBebBackend(runCleanup bool) error {
 	dynamicClient := dynamic.NewForConfigOrDie(c.restCfg)
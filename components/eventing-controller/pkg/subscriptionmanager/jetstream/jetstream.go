@@ -11,6 +11,7 @@ import (
 
 	"golang.org/x/xerrors"
 
+	"github.com/nats-io/nats.go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -43,6 +44,9 @@ const (
 	subscriptionManagerName = "jetstream-subscription-manager"
 )
 
+// compile time check
+var _ subscriptionmanager.Migratable = &SubscriptionManager{}
+
 // AddToScheme adds all types of clientset and eventing into the given scheme.
 func AddToScheme(scheme *runtime.Scheme) error {
 	if err := clientgoscheme.AddToScheme(scheme); err != nil {
@@ -72,6 +76,8 @@ type SubscriptionManager struct {
 	backend          backendjetstream.Backend
 	backendv2        backendjetstreamv2.Backend
 	logger           *logger.Logger
+	// drainedSubscriptions are the subscriptions which existed when reconciling was stopped
+	drainedSubscriptions []eventingv1alpha1.Subscription
 }
 
 // NewSubscriptionManager creates the subscription manager for JetStream.
@@ -178,6 +184,65 @@ func (sm *SubscriptionManager) Stop(runCleanup bool) error {
 	return cleanup(sm.backend, dynamicClient, sm.namedLogger())
 }
 
+// PendingMessages returns the number of messages of all JetStream consumers which are not delivered or not acknowledged yet.
+func (sm *SubscriptionManager) PendingMessages() (int64, error) {
+	var jsCtx nats.JetStreamContext
+	if sm.envCfg.EnableNewCRDVersion && sm.backendv2 != nil {
+		jsCtx = sm.backendv2.GetJetStreamContext()
+	} else if !sm.envCfg.EnableNewCRDVersion && sm.backend != nil {
+		jsCtx = sm.backend.GetJetStreamContext()
+	}
+	if jsCtx == nil {
+		return 0, errors.New("JetStream subscription manager is not started")
+	}
+
+	var pending int64
+	for consumer := range jsCtx.Consumers(sm.envCfg.JSStreamName) {
+		pending += int64(consumer.NumPending) + int64(consumer.NumAckPending)
+	}
+	return pending, nil
+}
+
+// StopReconciling stops the JetStream subscription controller, but keeps the connection to the NATS server,
+// so the JetStream subscriptions keep dispatching the messages. Only the v1alpha1 subscriptions can be drained.
+func (sm *SubscriptionManager) StopReconciling() error {
+	if sm.envCfg.EnableNewCRDVersion {
+		return errors.New("JetStream subscription manager can't be drained with v1alpha2 subscriptions")
+	}
+
+	var subs eventingv1alpha1.SubscriptionList
+	if err := sm.mgr.GetClient().List(context.Background(), &subs); err != nil {
+		return fmt.Errorf("failed to get all subscription resources: %w", err)
+	}
+	sm.drainedSubscriptions = subs.Items
+	sm.cancel()
+	sm.namedLogger().Info("Stopped reconciling JetStream subscriptions")
+	return nil
+}
+
+// StopDispatching closes the connection to the NATS server. The consumers of the subscriptions which existed when
+// reconciling was stopped are deleted if runCleanup is true, the statuses of the subscriptions are left unchanged.
+func (sm *SubscriptionManager) StopDispatching(runCleanup bool) error {
+	jsBackend, ok := sm.backend.(*backendjetstream.JetStream)
+	if !ok {
+		return errors.New("converting backend handler to JetStream handler failed")
+	}
+
+	if runCleanup {
+		for i := range sm.drainedSubscriptions {
+			sub := &sm.drainedSubscriptions[i]
+			if err := jsBackend.DeleteSubscription(sub); err != nil {
+				return xerrors.Errorf("failed to delete JetStream subscription %s/%s: %v", sub.Namespace, sub.Name, err)
+			}
+		}
+	}
+
+	sm.drainedSubscriptions = nil
+	jsBackend.Shutdown()
+	sm.namedLogger().Infow("Stopped dispatching JetStream subscriptions", "cleanup", runCleanup)
+	return nil
+}
+
 // clean removes all JetStream artifacts.
 func cleanup(backend backendjetstream.Backend, dynamicClient dynamic.Interface, logger *zap.SugaredLogger) error {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,241 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	eventingv1alpha1 "github.com/kyma-project/kyma/components/eventing-controller/api/v1alpha1"
+	"github.com/kyma-project/kyma/components/eventing-controller/controllers/subscription/kafka"
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/application"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/eventtype"
+	backendkafka "github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/kafka"
+	backendmetrics "github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/metrics"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/sink"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/utils"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/env"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/subscriptionmanager"
+)
+
+const (
+	subscriptionManagerName = "kafka-subscription-manager"
+)
+
+// compile time check
+var (
+	_ subscriptionmanager.Drainer    = &SubscriptionManager{}
+	_ subscriptionmanager.Migratable = &SubscriptionManager{}
+)
+
+// AddToScheme adds all types of clientset and eventing into the given scheme.
+func AddToScheme(scheme *runtime.Scheme) error {
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := eventingv1alpha1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	return nil
+}
+
+type SubscriptionManager struct {
+	cancel           context.CancelFunc
+	envCfg           env.KafkaConfig
+	restCfg          *rest.Config
+	metricsAddr      string
+	metricsCollector *backendmetrics.Collector
+	mgr              manager.Manager
+	backend          backendkafka.Backend
+	logger           *logger.Logger
+	// drainedSubscriptions are the subscriptions which existed when reconciling was stopped
+	drainedSubscriptions []eventingv1alpha1.Subscription
+}
+
+// NewSubscriptionManager creates the subscription manager for Kafka.
+func NewSubscriptionManager(restCfg *rest.Config, kafkaConfig env.KafkaConfig, metricsAddr string, metricsCollector *backendmetrics.Collector, logger *logger.Logger) *SubscriptionManager {
+	return &SubscriptionManager{
+		envCfg:           kafkaConfig,
+		restCfg:          restCfg,
+		metricsAddr:      metricsAddr,
+		metricsCollector: metricsCollector,
+		logger:           logger,
+	}
+}
+
+// Init initialize the Kafka subscription manager.
+func (sm *SubscriptionManager) Init(mgr manager.Manager) error {
+	if !sm.envCfg.IsEnabled() {
+		return xerrors.Errorf("env var KAFKA_BROKERS must be a non-empty value")
+	}
+	sm.mgr = mgr
+	sm.namedLogger().Info("initialized Kafka subscription manager")
+	return nil
+}
+
+func (sm *SubscriptionManager) Start(defaultSubsConfig env.DefaultSubscriptionConfig, _ subscriptionmanager.Params) error {
+	// the Kafka backend supports the v1alpha1 subscriptions only
+	if sm.envCfg.EnableNewCRDVersion {
+		return xerrors.Errorf("the Kafka backend does not support the v1alpha2 subscriptions")
+	}
+
+	kafkaClient, err := backendkafka.NewSaramaClient(sm.envCfg)
+	if err != nil {
+		return xerrors.Errorf("failed to connect to the Kafka cluster: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sm.cancel = cancel
+
+	client := sm.mgr.GetClient()
+	recorder := sm.mgr.GetEventRecorderFor("eventing-controller-kafka")
+	dynamicClient := dynamic.NewForConfigOrDie(sm.restCfg)
+	applicationLister := application.NewLister(ctx, dynamicClient)
+
+	kafkaCleaner := eventtype.NewCleaner(sm.envCfg.EventTypePrefix, applicationLister, sm.logger)
+	kafkaHandler := backendkafka.NewKafka(sm.envCfg, kafkaClient, defaultSubsConfig, sm.metricsCollector, sm.logger)
+	kafkaReconciler := kafka.NewReconciler(
+		ctx,
+		client,
+		kafkaHandler,
+		sm.logger,
+		recorder,
+		kafkaCleaner,
+		defaultSubsConfig,
+		sink.NewValidator(ctx, client, recorder, sm.logger),
+	)
+	sm.backend = kafkaReconciler.Backend
+
+	// delete dangling invalid consumer groups here
+	var subs eventingv1alpha1.SubscriptionList
+	if err := client.List(context.Background(), &subs); err != nil {
+		return fmt.Errorf("failed to get all subscription resources: %w", err)
+	}
+	if err := kafkaHandler.DeleteInvalidConsumerGroups(subs.Items); err != nil {
+		return err
+	}
+
+	// start the subscription controller
+	if err := kafkaReconciler.SetupUnmanaged(sm.mgr); err != nil {
+		return xerrors.Errorf("unable to setup the Kafka subscription controller: %v", err)
+	}
+	sm.namedLogger().Info("Started Kafka subscription manager")
+	return nil
+}
+
+func (sm *SubscriptionManager) Stop(runCleanup bool) error {
+	if sm.cancel != nil {
+		sm.cancel()
+	}
+	if sm.backend == nil {
+		return nil
+	}
+	if runCleanup {
+		dynamicClient := dynamic.NewForConfigOrDie(sm.restCfg)
+		if err := cleanup(sm.backend, dynamicClient, sm.namedLogger()); err != nil {
+			return err
+		}
+	}
+	// unlike the NATS connection, the consumers of the consumer groups are not bound to the controller context
+	return sm.backend.Shutdown()
+}
+
+// PendingMessages returns the number of messages of all Kafka consumer groups which are not committed yet.
+func (sm *SubscriptionManager) PendingMessages() (int64, error) {
+	if sm.backend == nil {
+		return 0, errors.New("Kafka subscription manager is not started")
+	}
+	return sm.backend.PendingMessages()
+}
+
+// StopReconciling stops the Kafka subscription controller, but keeps the consumers of the consumer groups running,
+// so they keep dispatching the messages.
+func (sm *SubscriptionManager) StopReconciling() error {
+	var subs eventingv1alpha1.SubscriptionList
+	if err := sm.mgr.GetClient().List(context.Background(), &subs); err != nil {
+		return fmt.Errorf("failed to get all subscription resources: %w", err)
+	}
+	sm.drainedSubscriptions = subs.Items
+	sm.cancel()
+	sm.namedLogger().Info("Stopped reconciling Kafka subscriptions")
+	return nil
+}
+
+// StopDispatching stops the consumers and closes the connection to the Kafka cluster. The consumer groups of the subscriptions
+// which existed when reconciling was stopped are deleted if runCleanup is true, the statuses of the subscriptions are left unchanged.
+func (sm *SubscriptionManager) StopDispatching(runCleanup bool) error {
+	if sm.backend == nil {
+		return errors.New("Kafka subscription manager is not started")
+	}
+
+	if runCleanup {
+		for i := range sm.drainedSubscriptions {
+			sub := &sm.drainedSubscriptions[i]
+			if err := sm.backend.DeleteSubscription(sub); err != nil {
+				return xerrors.Errorf("failed to delete Kafka subscription %s/%s: %v", sub.Namespace, sub.Name, err)
+			}
+		}
+	}
+
+	sm.drainedSubscriptions = nil
+	if err := sm.backend.Shutdown(); err != nil {
+		return err
+	}
+	sm.namedLogger().Infow("Stopped dispatching Kafka subscriptions", "cleanup", runCleanup)
+	return nil
+}
+
+// cleanup removes all Kafka consumer groups of the subscriptions and resets their status.
+func cleanup(backend backendkafka.Backend, dynamicClient dynamic.Interface, logger *zap.SugaredLogger) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// fetch all subscriptions.
+	subscriptionsUnstructured, err := dynamicClient.Resource(utils.SubscriptionGroupVersionResource()).Namespace(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "list subscriptions failed")
+	}
+
+	subs, err := utils.ToSubscriptionList(subscriptionsUnstructured)
+	if err != nil {
+		return errors.Wrapf(err, "convert subscriptionList from unstructured list failed")
+	}
+
+	// clean all status.
+	isCleanupSuccessful := true
+	for _, v := range subs.Items {
+		sub := v
+		subKey := types.NamespacedName{Namespace: sub.Namespace, Name: sub.Name}
+		log := logger.With("key", subKey.String())
+
+		desiredSub := utils.ResetStatusToDefaults(sub)
+		if err := utils.UpdateSubscriptionStatus(ctx, dynamicClient, desiredSub); err != nil {
+			isCleanupSuccessful = false
+			log.Errorw("Failed to update Kafka subscription status", "error", err)
+		}
+
+		// clean subscriptions from Kafka.
+		if err := backend.DeleteSubscription(&sub); err != nil {
+			isCleanupSuccessful = false
+			log.Errorw("Failed to delete Kafka subscription", "error", err)
+		}
+	}
+
+	logger.Debugw("Finished cleanup process", "success", isCleanupSuccessful)
+	return nil
+}
+
+func (sm *SubscriptionManager) namedLogger() *zap.SugaredLogger {
+	return sm.logger.WithContext().Named(subscriptionManagerName)
+}
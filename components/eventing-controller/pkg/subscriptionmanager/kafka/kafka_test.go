@@ -0,0 +1,114 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1alpha1 "github.com/kyma-project/kyma/components/eventing-controller/api/v1alpha1"
+	"github.com/kyma-project/kyma/components/eventing-controller/logger"
+	backendkafka "github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/kafka"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/backend/metrics"
+	"github.com/kyma-project/kyma/components/eventing-controller/pkg/env"
+	controllertesting "github.com/kyma-project/kyma/components/eventing-controller/testing"
+)
+
+const (
+	subscriptionName      = "test"
+	subscriptionNamespace = "test"
+	waitTimeout           = 5 * time.Second
+	waitInterval          = 10 * time.Millisecond
+)
+
+func TestCleanup(t *testing.T) {
+	// given
+	ctx := context.Background()
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	kafkaClient := backendkafka.NewMemoryClient()
+	kafkaConfig := env.KafkaConfig{TopicPrefix: "kyma", ConsumerGroupPrefix: "kyma"}
+	kafkaBackend := backendkafka.NewKafka(kafkaConfig, kafkaClient, env.DefaultSubscriptionConfig{},
+		metrics.NewCollector(), defaultLogger)
+	require.NoError(t, kafkaBackend.Initialize())
+	defer func() { require.NoError(t, kafkaBackend.Shutdown()) }()
+
+	testSub := controllertesting.NewSubscription(
+		subscriptionName, subscriptionNamespace,
+		controllertesting.WithFakeSubscriptionStatus(),
+		controllertesting.WithOrderCreatedFilter(),
+		controllertesting.WithSinkURL("http://sink.test.svc.cluster.local"),
+	)
+	testSub.Status.CleanEventTypes = []string{controllertesting.OrderCreatedEventType}
+	require.NoError(t, kafkaBackend.SyncSubscription(testSub))
+	require.Eventually(t, func() bool {
+		groupIDs, err := kafkaClient.ListConsumerGroups()
+		return err == nil && len(groupIDs) == 1
+	}, waitTimeout, waitInterval)
+
+	dynamicClient, err := controllertesting.NewFakeSubscriptionClient(testSub)
+	require.NoError(t, err)
+
+	// when
+	err = cleanup(kafkaBackend, dynamicClient, defaultLogger.WithContext())
+
+	// then
+	require.NoError(t, err)
+	unstructuredSub, err := dynamicClient.Resource(controllertesting.SubscriptionGroupVersionResource()).Namespace(
+		subscriptionNamespace).Get(ctx, subscriptionName, metav1.GetOptions{})
+	require.NoError(t, err)
+	gotSub, err := controllertesting.ToSubscription(unstructuredSub)
+	require.NoError(t, err)
+	wantSubStatus := eventingv1alpha1.SubscriptionStatus{CleanEventTypes: []string{}}
+	require.Equal(t, wantSubStatus, gotSub.Status)
+	// test Kafka consumer groups are gone
+	groupIDs, err := kafkaClient.ListConsumerGroups()
+	require.NoError(t, err)
+	require.Empty(t, groupIDs)
+}
+
+func TestStopDispatching(t *testing.T) {
+	// given
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	kafkaClient := backendkafka.NewMemoryClient()
+	kafkaConfig := env.KafkaConfig{TopicPrefix: "kyma", ConsumerGroupPrefix: "kyma"}
+	kafkaBackend := backendkafka.NewKafka(kafkaConfig, kafkaClient, env.DefaultSubscriptionConfig{},
+		metrics.NewCollector(), defaultLogger)
+	require.NoError(t, kafkaBackend.Initialize())
+
+	testSub := controllertesting.NewSubscription(
+		subscriptionName, subscriptionNamespace,
+		controllertesting.WithFakeSubscriptionStatus(),
+		controllertesting.WithOrderCreatedFilter(),
+		controllertesting.WithSinkURL("http://sink.test.svc.cluster.local"),
+	)
+	testSub.Status.CleanEventTypes = []string{controllertesting.OrderCreatedEventType}
+	require.NoError(t, kafkaBackend.SyncSubscription(testSub))
+	require.Eventually(t, func() bool {
+		groupIDs, err := kafkaClient.ListConsumerGroups()
+		return err == nil && len(groupIDs) == 1
+	}, waitTimeout, waitInterval)
+
+	// the subscription is deleted while the backend is drained, so only the backend remembers it
+	subMgr := &SubscriptionManager{
+		backend:              kafkaBackend,
+		logger:               defaultLogger,
+		drainedSubscriptions: []eventingv1alpha1.Subscription{*testSub},
+	}
+
+	// when
+	err = subMgr.StopDispatching(true)
+
+	// then
+	require.NoError(t, err)
+	require.Nil(t, subMgr.drainedSubscriptions)
+	groupIDs, err := kafkaClient.ListConsumerGroups()
+	require.NoError(t, err)
+	require.Empty(t, groupIDs)
+}
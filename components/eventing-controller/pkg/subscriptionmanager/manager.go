@@ -19,3 +19,21 @@ type Manager interface {
 	// Stop tells the subscription manager instance to shut down and clean-up.
 	Stop(runCleanup bool) error
 }
+
+// Drainer defines the interface that subscription managers should implement if they can report the messages
+// which are not delivered to the subscribers yet.
+type Drainer interface {
+	// PendingMessages returns the number of messages waiting for the delivery or the acknowledgement.
+	PendingMessages() (int64, error)
+}
+
+// Migratable defines the interface that subscription managers should implement if their backend can be drained
+// while the subscription manager of another backend reconciles the Subscriptions.
+type Migratable interface {
+	// StopReconciling stops reconciling the Subscriptions, but the messages of the existing subscriptions are still dispatched.
+	StopReconciling() error
+
+	// StopDispatching stops dispatching the messages without changing the Subscriptions. If runCleanup is true, the backend
+	// artifacts of the subscriptions which existed when reconciling was stopped are deleted.
+	StopDispatching(runCleanup bool) error
+}
@@ -0,0 +1,136 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	cev2 "github.com/cloudevents/sdk-go/v2/event"
+	cev2protocol "github.com/cloudevents/sdk-go/v2/protocol"
+	cev2protocolhttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/kyma-project/kyma/components/eventing-controller"
+
+	dispatchSpanName = "eventing.dispatch"
+
+	// SubscriptionKey is the span attribute of the namespaced name of the subscription.
+	SubscriptionKey = attribute.Key("eventing.subscription")
+	// EventTypeKey is the span attribute of the event type.
+	EventTypeKey = attribute.Key("eventing.event.type")
+	// EventSourceKey is the span attribute of the event source.
+	EventSourceKey = attribute.Key("eventing.event.source")
+	// EventIDKey is the span attribute of the event ID.
+	EventIDKey = attribute.Key("eventing.event.id")
+	// SinkKey is the span attribute of the sink the event is dispatched to.
+	SinkKey = attribute.Key("eventing.sink")
+	// RedeliveryCountKey is the span attribute of how often the event was dispatched before.
+	RedeliveryCountKey = attribute.Key("eventing.redelivery.count")
+)
+
+// ShutdownFunc flushes the pending spans and stops exporting spans.
+type ShutdownFunc func(ctx context.Context) error
+
+// InitTracerProvider registers the global tracer provider which exports the spans to the given OTLP/HTTP endpoint,
+// for example "http://telemetry-otlp-traces.kyma-system:4318". If the endpoint is empty, no spans are recorded.
+// The W3C trace context and B3 headers are propagated in both cases. It's shared by the Eventing Controller
+// and the Event Publisher Proxy, so both of them export and propagate the spans in the same way.
+func InitTracerProvider(ctx context.Context, serviceName, endpoint string) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)),
+	))
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the OTLP endpoint %q: %w", endpoint, err)
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpointURL.Host)}
+	if endpointURL.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if endpointURL.Path != "" && endpointURL.Path != "/" {
+		opts = append(opts, otlptracehttp.WithURLPath(endpointURL.Path))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		// respect the sampling decision of the caller, for example the Istio sidecar or the Event Publisher Proxy
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// StartDispatchSpan starts the span of dispatching the CloudEvent to the sink of the subscription. The span continues
+// the trace of the tracing extensions of the CloudEvent, which are removed like in AddTracingHeadersToContext.
+// The returned context sends the tracing headers of the span to the sink.
+func StartDispatchSpan(ctx context.Context, ce *cev2.Event, subscription, sink string, redeliveries int64) (context.Context, trace.Span) {
+	traceHeader := tracingHeaders(ce)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(traceHeader))
+	ctx, span := otel.Tracer(tracerName).Start(ctx, dispatchSpanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			SubscriptionKey.String(subscription),
+			EventTypeKey.String(ce.Type()),
+			EventSourceKey.String(ce.Source()),
+			EventIDKey.String(ce.ID()),
+			SinkKey.String(sink),
+			RedeliveryCountKey.Int64(redeliveries),
+		),
+	)
+	traceHeader = InjectSpanContext(ctx, traceHeader)
+	if len(traceHeader) > 0 {
+		ctx = cev2protocolhttp.WithCustomHeader(ctx, traceHeader)
+	}
+	return ctx, span
+}
+
+// InjectSpanContext returns a copy of the headers in which the tracing headers continue the trace of the current span,
+// so the receiver is traced as a child of the span. The headers are returned unchanged if the span isn't recorded.
+func InjectSpanContext(ctx context.Context, header http.Header) http.Header {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return header
+	}
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	// the parent of the current span isn't propagated, so the parent span ID of the caller would be misleading
+	header.Del(b3ParentSpanIDKey)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+	return header
+}
+
+// EndDispatchSpan ends the span with the result of dispatching the CloudEvent to the sink.
+func EndDispatchSpan(span trace.Span, result cev2protocol.Result) {
+	var httpResult *cev2protocolhttp.Result
+	if errors.As(result, &httpResult) {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(httpResult.StatusCode))
+	}
+	if !cev2protocol.IsACK(result) {
+		span.RecordError(result)
+		span.SetStatus(codes.Error, result.Error())
+	}
+	span.End()
+}
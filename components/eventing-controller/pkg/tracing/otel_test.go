@@ -0,0 +1,206 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cev2protocol "github.com/cloudevents/sdk-go/v2/protocol"
+	cev2protocolhttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testTraceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentSpanID = "00f067aa0ba902b7"
+	testTraceParent  = "00-" + testTraceID + "-" + testParentSpanID + "-01"
+	testSink         = "http://sink.test.svc.cluster.local"
+)
+
+func TestStartDispatchSpan(t *testing.T) {
+	testCases := []struct {
+		name            string
+		givenExtensions map[string]string
+		wantTraceID     string
+	}{
+		{
+			name:            "should continue the trace of the w3c tracing extensions",
+			givenExtensions: map[string]string{traceParentCEExtensionsKey: testTraceParent},
+			wantTraceID:     testTraceID,
+		},
+		{
+			name: "should continue the trace of the b3 tracing extensions",
+			givenExtensions: map[string]string{
+				b3TraceIDCEExtensionsKey:      testTraceID,
+				b3ParentSpanIDCEExtensionsKey: "0000000000000001",
+				b3SpanIDCEExtensionsKey:       testParentSpanID,
+				b3SampledCEExtensionsKey:      "1",
+			},
+			wantTraceID: testTraceID,
+		},
+		{
+			name:            "should start a new trace without tracing extensions",
+			givenExtensions: map[string]string{"foo": "bar"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			recorder := setupTestTracerProvider(t)
+			event := NewEventWithExtensions(tc.givenExtensions)
+			event.SetType("sap.kyma.custom.commerce.order.created.v1")
+
+			// when
+			ctx, span := StartDispatchSpan(context.Background(), event, "test/sub", testSink, 2)
+			spanContext := span.SpanContext()
+			EndDispatchSpan(span, cev2protocolhttp.NewResult(http.StatusNoContent, "%w", cev2protocol.ResultACK))
+
+			// then
+			assert.Empty(t, getTracingExtensions(event))
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, dispatchSpanName, spans[0].Name())
+			assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+			assert.Equal(t, codes.Unset, spans[0].Status().Code)
+			assert.Contains(t, spans[0].Attributes(), SubscriptionKey.String("test/sub"))
+			assert.Contains(t, spans[0].Attributes(), EventTypeKey.String("sap.kyma.custom.commerce.order.created.v1"))
+			assert.Contains(t, spans[0].Attributes(), SinkKey.String(testSink))
+			assert.Contains(t, spans[0].Attributes(), RedeliveryCountKey.Int64(2))
+			assert.Contains(t, spans[0].Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusNoContent))
+
+			// the sink continues the trace of the dispatch span
+			header := cev2protocolhttp.HeaderFrom(ctx)
+			assert.Equal(t, "00-"+spanContext.TraceID().String()+"-"+spanContext.SpanID().String()+"-01", header.Get(traceParentKey))
+			assert.Equal(t, spanContext.SpanID().String(), header.Get(b3SpanIDKey))
+			assert.Empty(t, header.Get(b3ParentSpanIDKey))
+			if tc.wantTraceID == "" {
+				assert.False(t, spans[0].Parent().IsValid())
+				return
+			}
+			assert.Equal(t, tc.wantTraceID, spanContext.TraceID().String())
+			assert.Equal(t, testParentSpanID, spans[0].Parent().SpanID().String())
+		})
+	}
+}
+
+func TestStartDispatchSpanWithoutTracing(t *testing.T) {
+	// given
+	_, err := InitTracerProvider(context.Background(), "test", "")
+	require.NoError(t, err)
+	otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	event := NewEventWithExtensions(map[string]string{traceParentCEExtensionsKey: testTraceParent})
+
+	// when
+	ctx, span := StartDispatchSpan(context.Background(), event, "test/sub", testSink, 0)
+	defer span.End()
+
+	// then
+	assert.False(t, span.IsRecording())
+	assert.Equal(t, testTraceParent, cev2protocolhttp.HeaderFrom(ctx).Get(traceParentKey))
+	assert.Empty(t, getTracingExtensions(event))
+}
+
+func TestEndDispatchSpan(t *testing.T) {
+	testCases := []struct {
+		name           string
+		givenResult    cev2protocol.Result
+		wantStatusCode codes.Code
+	}{
+		{
+			name:           "should end the span of the dispatched event",
+			givenResult:    cev2protocolhttp.NewResult(http.StatusOK, "%w", cev2protocol.ResultACK),
+			wantStatusCode: codes.Unset,
+		},
+		{
+			name:           "should mark the span as failed if the sink rejects the event",
+			givenResult:    cev2protocolhttp.NewResult(http.StatusInternalServerError, "%w", cev2protocol.ResultNACK),
+			wantStatusCode: codes.Error,
+		},
+		{
+			name:           "should mark the span as failed if the sink is unreachable",
+			givenResult:    cev2protocol.NewReceipt(false, "connection refused"),
+			wantStatusCode: codes.Error,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			recorder := setupTestTracerProvider(t)
+			_, span := StartDispatchSpan(context.Background(), NewEventWithExtensions(nil), "test/sub", testSink, 0)
+
+			// when
+			EndDispatchSpan(span, tc.givenResult)
+
+			// then
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tc.wantStatusCode, spans[0].Status().Code)
+		})
+	}
+}
+
+func TestInjectSpanContext(t *testing.T) {
+	// given
+	setupTestTracerProvider(t)
+	header := http.Header{}
+	header.Set(b3TraceIDKey, testTraceID)
+	header.Set(b3ParentSpanIDKey, "0000000000000001")
+	header.Set(b3SpanIDKey, testParentSpanID)
+	header.Set(b3SampledKey, "1")
+	header.Set("foo", "bar")
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "test")
+	defer span.End()
+	spanID := span.SpanContext().SpanID().String()
+
+	// when
+	injected := InjectSpanContext(ctx, header)
+
+	// then
+	assert.Equal(t, "00-"+testTraceID+"-"+spanID+"-01", injected.Get(traceParentKey))
+	assert.Equal(t, testTraceID, injected.Get(b3TraceIDKey))
+	assert.Equal(t, spanID, injected.Get(b3SpanIDKey))
+	assert.Empty(t, injected.Get(b3ParentSpanIDKey))
+	assert.Equal(t, "bar", injected.Get("foo"))
+	// the given headers are not changed
+	assert.Equal(t, testParentSpanID, header.Get(b3SpanIDKey))
+}
+
+func TestInjectSpanContextWithoutTracing(t *testing.T) {
+	// given
+	_, err := InitTracerProvider(context.Background(), "test", "")
+	require.NoError(t, err)
+	otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	header := http.Header{}
+	header.Set(traceParentKey, testTraceParent)
+	ctx, span := otel.Tracer(tracerName).Start(context.Background(), "test")
+	defer span.End()
+
+	// when
+	injected := InjectSpanContext(ctx, header)
+
+	// then
+	assert.Equal(t, header, injected)
+}
+
+// setupTestTracerProvider registers a tracer provider which records all spans.
+func setupTestTracerProvider(t *testing.T) *tracetest.SpanRecorder {
+	_, err := InitTracerProvider(context.Background(), "test", "")
+	require.NoError(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+	return recorder
+}
@@ -28,6 +28,14 @@ const (
 )
 
 func AddTracingHeadersToContext(ctx context.Context, ce *cev2.Event) context.Context {
+	if traceHeader := tracingHeaders(ce); len(traceHeader) > 0 {
+		ctx = cev2protocolhttp.WithCustomHeader(ctx, traceHeader)
+	}
+	return ctx
+}
+
+// tracingHeaders returns the tracing headers of the tracing extensions of the CloudEvent and removes the extensions.
+func tracingHeaders(ce *cev2.Event) http.Header {
 	traceHeader := http.Header{}
 	if traceParent, ok := ce.Extensions()[traceParentCEExtensionsKey]; ok {
 		traceHeader.Add(traceParentKey, fmt.Sprintf("%v", traceParent))
@@ -60,10 +68,7 @@ func AddTracingHeadersToContext(ctx context.Context, ce *cev2.Event) context.Con
 		// CE extensions were added in publisher proxy to continue the trace from here. Hence, it needs to be deleted here.
 		removeCEExtension(ce, b3FlagsCEExtensionsKey)
 	}
-	if len(traceHeader) > 0 {
-		ctx = cev2protocolhttp.WithCustomHeader(ctx, traceHeader)
-	}
-	return ctx
+	return traceHeader
 }
 
 func removeCEExtension(e *cev2.Event, key string) {
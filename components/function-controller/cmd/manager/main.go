@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"os"
 	"time"
 
@@ -54,6 +56,13 @@ type config struct {
 	Kubernetes                k8s.Config
 	Function                  serverless.FunctionConfig
 	ConfigPath                string `envconfig:"default=/appdata/config.yaml"`
+	GitWebhook                gitWebhookConfig
+}
+
+type gitWebhookConfig struct {
+	Enabled bool   `envconfig:"default=false"`
+	Address string `envconfig:"default=:8081"`
+	Secret  string `envconfig:"optional"`
 }
 
 type healthzConfig struct {
@@ -152,6 +161,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	if config.GitWebhook.Enabled {
+		if config.GitWebhook.Secret == "" {
+			setupLog.Error(errors.New("APP_GIT_WEBHOOK_SECRET is empty"), "git webhook requires a secret")
+			os.Exit(1)
+		}
+
+		gitTriggers := serverless.NewGitTriggers()
+		fnRecon.SetGitTriggers(gitTriggers)
+
+		gitWebhookHandler, gitWebhookEventsCh := serverless.NewGitWebhookHandler(mgr.GetClient(), config.GitWebhook.Secret, gitTriggers, logWithCtx.Named("git-webhook"))
+		err = fnCtrl.Watch(&source.Channel{Source: gitWebhookEventsCh}, &handler.EnqueueRequestForObject{})
+		if err != nil {
+			setupLog.Error(err, "unable to watch git webhook events channel")
+			os.Exit(1)
+		}
+
+		if err := mgr.Add(newGitWebhookServer(config.GitWebhook.Address, gitWebhookHandler)); err != nil {
+			setupLog.Error(err, "unable to register git webhook server")
+			os.Exit(1)
+		}
+	}
+
 	if err := k8s.NewConfigMap(mgr.GetClient(), logWithCtx.Named("controllers.configmap"), config.Kubernetes, configMapSvc).
 		SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create ConfigMap controller")
@@ -186,6 +217,30 @@ func main() {
 	}
 }
 
+func newGitWebhookServer(address string, handler http.Handler) manager.RunnableFunc {
+	return func(ctx context.Context) error {
+		mux := http.NewServeMux()
+		mux.Handle("/webhooks/git", handler)
+		server := &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}
+}
+
 func loadConfig(prefix string) (config, error) {
 	cfg := config{}
 	err := envconfig.InitWithPrefix(&cfg, prefix)
@@ -33,6 +33,27 @@ func (_m *GitClient) Clone(path string, options git.Options) (string, error) {
 	return r0, r1
 }
 
+// HasChanges provides a mock function with given fields: options, baseDir, fromCommit, toCommit
+func (_m *GitClient) HasChanges(options git.Options, baseDir string, fromCommit string, toCommit string) (bool, error) {
+	ret := _m.Called(options, baseDir, fromCommit, toCommit)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(git.Options, string, string, string) bool); ok {
+		r0 = rf(options, baseDir, fromCommit, toCommit)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(git.Options, string, string, string) error); ok {
+		r1 = rf(options, baseDir, fromCommit, toCommit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LastCommit provides a mock function with given fields: options
 func (_m *GitClient) LastCommit(options git.Options) (string, error) {
 	ret := _m.Called(options)
@@ -13,6 +13,11 @@ type StatsCollector struct {
 	mock.Mock
 }
 
+// UpdateBuildCacheStats provides a mock function with given fields: f, populated
+func (_m *StatsCollector) UpdateBuildCacheStats(f *v1alpha2.Function, populated bool) {
+	_m.Called(f, populated)
+}
+
 // UpdateReconcileStats provides a mock function with given fields: f, cond
 func (_m *StatsCollector) UpdateReconcileStats(f *v1alpha2.Function, cond v1alpha2.Condition) {
 	_m.Called(f, cond)
@@ -0,0 +1,56 @@
+package serverless
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	fnRuntime "github.com/kyma-project/kyma/components/function-controller/internal/controllers/serverless/runtime"
+	serverlessv1alpha2 "github.com/kyma-project/kyma/components/function-controller/pkg/apis/serverless/v1alpha2"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+const (
+	cacheRepoArg        = "--cache-repo"
+	buildCacheKeyLength = 16
+
+	// buildCacheKeyAnnotation is set on build jobs to find out if other builds already populated the same cache
+	buildCacheKeyAnnotation = "serverless.kyma-project.io/build-cache-key"
+)
+
+// usesSharedBuildCache checks if the function build uses the dependency cache shared with other functions.
+// The dependency file of git functions is not known before the repository is cloned,
+// so they keep using the cache next to their own image instead.
+func (s *systemState) usesSharedBuildCache(cfg cfg) bool {
+	return cfg.fn.Build.DependencyCacheEnabled &&
+		!s.instance.TypeOf(serverlessv1alpha2.FunctionTypeGit) && s.instance.Spec.Source.Inline != nil
+}
+
+// buildCacheKey returns the key of the dependency cache shared by all inline functions
+// with the same runtime and dependency file
+func (s *systemState) buildCacheKey() string {
+	runtime := string(s.instance.Spec.Runtime)
+	rtm := fnRuntime.GetRuntime(s.instance.Spec.Runtime)
+	deps := strings.TrimSpace(rtm.SanitizeDependencies(s.instance.Spec.Source.Inline.Dependencies))
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(deps)))
+
+	return fmt.Sprintf("%s-%s", runtime, hash[:buildCacheKeyLength])
+}
+
+func (s *systemState) buildCacheRepoAddress(cfg cfg) string {
+	return fmt.Sprintf("%s/%s/%s", cfg.docker.PushAddress, cfg.fn.Build.DependencyCacheRepoName, s.buildCacheKey())
+}
+
+// buildCachePopulated checks if any successful build job populated the cache with the given key before,
+// it doesn't tell if kaniko actually reuses the cached layers
+func buildCachePopulated(jobs batchv1.JobList, cacheKey string) bool {
+	for _, job := range jobs.Items {
+		if job.GetAnnotations()[buildCacheKeyAnnotation] != cacheKey {
+			continue
+		}
+		if job.Status.Succeeded > 0 {
+			return true
+		}
+	}
+	return false
+}
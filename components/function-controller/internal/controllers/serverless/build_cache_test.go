@@ -0,0 +1,155 @@
+package serverless
+
+import (
+	"testing"
+
+	serverlessv1alpha2 "github.com/kyma-project/kyma/components/function-controller/pkg/apis/serverless/v1alpha2"
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSystemState_buildCacheKey(t *testing.T) {
+	inlineFunction := func(runtime serverlessv1alpha2.Runtime, deps string) systemState {
+		return systemState{
+			instance: serverlessv1alpha2.Function{
+				Spec: serverlessv1alpha2.FunctionSpec{
+					Runtime: runtime,
+					Source: serverlessv1alpha2.Source{
+						Inline: &serverlessv1alpha2.InlineSource{Dependencies: deps},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("functions with the same runtime and dependencies share the key", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		first := inlineFunction(serverlessv1alpha2.NodeJs16, `{"dependencies":{"lodash":"4.17.21"}}`)
+		second := inlineFunction(serverlessv1alpha2.NodeJs16, `{"dependencies":{"lodash":"4.17.21"}}`)
+
+		g.Expect(first.buildCacheKey()).To(gomega.Equal(second.buildCacheKey()))
+		g.Expect(first.buildCacheKey()).To(gomega.HavePrefix("nodejs16-"))
+		g.Expect(len(first.buildCacheKey())).To(gomega.BeNumerically("<=", 63))
+	})
+
+	t.Run("different dependencies or runtime result in different keys", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		base := inlineFunction(serverlessv1alpha2.NodeJs16, `{"dependencies":{"lodash":"4.17.21"}}`)
+		otherDeps := inlineFunction(serverlessv1alpha2.NodeJs16, `{"dependencies":{"axios":"0.27.2"}}`)
+		otherRuntime := inlineFunction(serverlessv1alpha2.NodeJs14, `{"dependencies":{"lodash":"4.17.21"}}`)
+
+		g.Expect(base.buildCacheKey()).NotTo(gomega.Equal(otherDeps.buildCacheKey()))
+		g.Expect(base.buildCacheKey()).NotTo(gomega.Equal(otherRuntime.buildCacheKey()))
+	})
+}
+
+func TestSystemState_buildJobWithDependencyCache(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	s := inlineFunctionState(serverlessv1alpha2.NodeJs16)
+	jobCfg := cfg{
+		docker: DockerConfig{PushAddress: "registry:5000"},
+		fn: FunctionConfig{
+			Build: BuildConfig{
+				DependencyCacheEnabled:  true,
+				DependencyCacheRepoName: "function-cache",
+			},
+		},
+	}
+
+	job := s.buildJob("cm-name", jobCfg)
+
+	cacheKey := s.buildCacheKey()
+	g.Expect(job.GetAnnotations()).To(gomega.HaveKeyWithValue(buildCacheKeyAnnotation, cacheKey))
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args).To(gomega.ContainElement("--cache-repo=registry:5000/function-cache/" + cacheKey))
+
+	jobCfg.fn.Build.DependencyCacheEnabled = false
+	job = s.buildJob("cm-name", jobCfg)
+
+	g.Expect(getArg(job.Spec.Template.Spec.Containers[0].Args, cacheRepoArg)).To(gomega.BeEmpty())
+}
+
+func TestSystemState_usesSharedBuildCache(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cacheCfg := cfg{fn: FunctionConfig{Build: BuildConfig{DependencyCacheEnabled: true}}}
+	inline := inlineFunctionState(serverlessv1alpha2.NodeJs16)
+	git := systemState{
+		instance: serverlessv1alpha2.Function{
+			Spec: serverlessv1alpha2.FunctionSpec{
+				Runtime: serverlessv1alpha2.Python39,
+				Source: serverlessv1alpha2.Source{
+					GitRepository: &serverlessv1alpha2.GitRepositorySource{URL: "repo-url"},
+				},
+			},
+		},
+	}
+
+	g.Expect(inline.usesSharedBuildCache(cacheCfg)).To(gomega.BeTrue())
+	g.Expect(inline.usesSharedBuildCache(cfg{})).To(gomega.BeFalse())
+	// the dependencies of git functions are known only after cloning the repository
+	g.Expect(git.usesSharedBuildCache(cacheCfg)).To(gomega.BeFalse())
+}
+
+func Test_buildCachePopulated(t *testing.T) {
+	jobWithKey := func(key string, succeeded int32) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{buildCacheKeyAnnotation: key},
+			},
+			Status: batchv1.JobStatus{Succeeded: succeeded},
+		}
+	}
+
+	tests := []struct {
+		name string
+		jobs []batchv1.Job
+		want bool
+	}{
+		{
+			name: "no jobs",
+			want: false,
+		},
+		{
+			name: "succeeded job with the same key",
+			jobs: []batchv1.Job{jobWithKey("other", 1), jobWithKey("key", 1)},
+			want: true,
+		},
+		{
+			name: "job with the same key still running",
+			jobs: []batchv1.Job{jobWithKey("key", 0)},
+			want: false,
+		},
+		{
+			name: "succeeded job with another key",
+			jobs: []batchv1.Job{jobWithKey("other", 1)},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(buildCachePopulated(batchv1.JobList{Items: tt.jobs}, "key")).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func inlineFunctionState(runtime serverlessv1alpha2.Runtime) systemState {
+	return systemState{
+		instance: serverlessv1alpha2.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-function", Namespace: "my-namespace"},
+			Spec: serverlessv1alpha2.FunctionSpec{
+				Runtime: runtime,
+				Source: serverlessv1alpha2.Source{
+					Inline: &serverlessv1alpha2.InlineSource{
+						Source:       "module.exports = {}",
+						Dependencies: `{"dependencies":{}}`,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,136 @@
+package serverless
+
+import (
+	"fmt"
+	"path"
+
+	fnRuntime "github.com/kyma-project/kyma/components/function-controller/internal/controllers/serverless/runtime"
+	serverlessv1alpha2 "github.com/kyma-project/kyma/components/function-controller/pkg/apis/serverless/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	baseImageKey = "baseImage"
+
+	// layerAppendScript packs the function sources into a single layer and appends it to the runtime base image.
+	// The destination is passed as the first argument to keep it comparable with the kaniko jobs (see equalJobs)
+	layerAppendScript = `set -e
+BASE_IMAGE="${BASE_IMAGE:-$(cat /workspace/baseImage)}"
+mkdir -p "/tmp/layer${FUNCTION_DIR}"
+cp -rL /workspace/src/. "/tmp/layer${FUNCTION_DIR}/"
+tar -C /tmp/layer -cf /tmp/layer.tar .
+crane append --insecure --base "${BASE_IMAGE}" --new_layer /tmp/layer.tar --new_tag "${1#--destination=}"`
+)
+
+// Builder describes the executor of the build job which builds the function image and pushes it to the registry
+type Builder interface {
+	// Supports returns false if the builder is not able to build the function image
+	Supports(s *systemState) bool
+	Volumes(s *systemState, cfg cfg) []corev1.Volume
+	Container(s *systemState, cfg cfg) corev1.Container
+	Annotations(s *systemState, cfg cfg) map[string]string
+}
+
+var (
+	_ Builder = kanikoBuilder{}
+	_ Builder = layerAppendBuilder{}
+)
+
+// builder returns the builder configured for the function runtime,
+// kaniko is used if the configured one can't build the function image
+func (s *systemState) builder(cfg cfg) Builder {
+	var builder Builder = kanikoBuilder{}
+	for _, runtime := range cfg.fn.Build.LayerAppendRuntimes {
+		if runtime == string(s.instance.Spec.Runtime) {
+			builder = layerAppendBuilder{}
+		}
+	}
+
+	if !builder.Supports(s) {
+		return kanikoBuilder{}
+	}
+	return builder
+}
+
+// kanikoBuilder builds the function image from the runtime Dockerfile
+type kanikoBuilder struct{}
+
+func (kanikoBuilder) Supports(_ *systemState) bool {
+	return true
+}
+
+func (kanikoBuilder) Volumes(s *systemState, cfg cfg) []corev1.Volume {
+	return []corev1.Volume{
+		buildRegistryConfigVolume(cfg),
+		s.buildJobRuntimeVolume(),
+	}
+}
+
+func (kanikoBuilder) Container(s *systemState, cfg cfg) corev1.Container {
+	if s.instance.TypeOf(serverlessv1alpha2.FunctionTypeGit) {
+		return s.buildJobExecutorContainer(cfg, s.getGitBuildJobVolumeMounts())
+	}
+	return s.buildJobExecutorContainer(cfg, s.getBuildJobVolumeMounts())
+}
+
+func (kanikoBuilder) Annotations(s *systemState, cfg cfg) map[string]string {
+	if !s.usesSharedBuildCache(cfg) {
+		return nil
+	}
+	return map[string]string{
+		buildCacheKeyAnnotation: s.buildCacheKey(),
+	}
+}
+
+// layerAppendBuilder appends the function sources as a new layer to the prebuilt runtime base image,
+// it doesn't run the dependency manager so it's used only for inline functions without dependencies
+type layerAppendBuilder struct{}
+
+func (layerAppendBuilder) Supports(s *systemState) bool {
+	if s.instance.TypeOf(serverlessv1alpha2.FunctionTypeGit) || s.instance.Spec.Source.Inline == nil {
+		return false
+	}
+
+	rtm := fnRuntime.GetRuntime(s.instance.Spec.Runtime)
+	return !rtm.HasDependencies(s.instance.Spec.Source.Inline.Dependencies)
+}
+
+func (layerAppendBuilder) Volumes(s *systemState, _ cfg) []corev1.Volume {
+	return []corev1.Volume{
+		s.buildJobRuntimeVolume(),
+	}
+}
+
+func (layerAppendBuilder) Container(s *systemState, cfg cfg) corev1.Container {
+	rtmCfg := fnRuntime.GetRuntimeConfig(s.instance.Spec.Runtime)
+	imageName := s.buildImageAddress(cfg.docker.PushAddress)
+
+	env := []corev1.EnvVar{
+		{Name: "DOCKER_CONFIG", Value: "/docker/.docker/"},
+		{Name: "FUNCTION_DIR", Value: rtmCfg.FunctionDir},
+	}
+	if s.instance.Spec.RuntimeImageOverride != "" {
+		env = append(env, corev1.EnvVar{Name: "BASE_IMAGE", Value: s.instance.Spec.RuntimeImageOverride})
+	}
+
+	return corev1.Container{
+		Name:    "executor",
+		Image:   cfg.fn.Build.LayerAppendImage,
+		Command: []string{"/busybox/sh", "-c", layerAppendScript, "layer-append"},
+		Args:    []string{fmt.Sprintf("%s=%s", destinationArg, imageName)},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "sources", ReadOnly: true, MountPath: path.Join(baseDir, rtmCfg.DependencyFile), SubPath: FunctionDepsKey},
+			{Name: "sources", ReadOnly: true, MountPath: path.Join(baseDir, rtmCfg.FunctionFile), SubPath: FunctionSourceKey},
+			{Name: "runtime", ReadOnly: true, MountPath: path.Join(workspaceMountPath, baseImageKey), SubPath: baseImageKey},
+			{Name: "credentials", ReadOnly: true, MountPath: "/docker"},
+		},
+		Resources:       getBuildResourceRequirements(s),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Env:             env,
+		SecurityContext: buildJobContainerSecurityContext(),
+	}
+}
+
+func (layerAppendBuilder) Annotations(_ *systemState, _ cfg) map[string]string {
+	return nil
+}
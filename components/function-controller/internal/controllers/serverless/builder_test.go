@@ -0,0 +1,102 @@
+package serverless
+
+import (
+	"testing"
+
+	serverlessv1alpha2 "github.com/kyma-project/kyma/components/function-controller/pkg/apis/serverless/v1alpha2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSystemState_builder(t *testing.T) {
+	layerAppendCfg := cfg{
+		fn: FunctionConfig{
+			Build: BuildConfig{
+				LayerAppendRuntimes: []string{string(serverlessv1alpha2.NodeJs16)},
+			},
+		},
+	}
+
+	t.Run("use kaniko by default", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		s := inlineFunctionState(serverlessv1alpha2.NodeJs16)
+
+		g.Expect(s.builder(cfg{})).To(gomega.Equal(kanikoBuilder{}))
+	})
+
+	t.Run("use layer append for configured runtime", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		s := inlineFunctionState(serverlessv1alpha2.NodeJs16)
+
+		g.Expect(s.builder(layerAppendCfg)).To(gomega.Equal(layerAppendBuilder{}))
+	})
+
+	t.Run("use kaniko for not configured runtime", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		s := inlineFunctionState(serverlessv1alpha2.Python39)
+
+		g.Expect(s.builder(layerAppendCfg)).To(gomega.Equal(kanikoBuilder{}))
+	})
+
+	t.Run("fallback to kaniko for function with dependencies", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		s := inlineFunctionState(serverlessv1alpha2.NodeJs16)
+		s.instance.Spec.Source.Inline.Dependencies = `{"dependencies":{"lodash":"4.17.21"}}`
+
+		g.Expect(s.builder(layerAppendCfg)).To(gomega.Equal(kanikoBuilder{}))
+	})
+
+	t.Run("fallback to kaniko for git function", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		s := systemState{
+			instance: serverlessv1alpha2.Function{
+				Spec: serverlessv1alpha2.FunctionSpec{
+					Runtime: serverlessv1alpha2.NodeJs16,
+					Source: serverlessv1alpha2.Source{
+						GitRepository: &serverlessv1alpha2.GitRepositorySource{URL: "repo-url"},
+					},
+				},
+			},
+		}
+
+		g.Expect(s.builder(layerAppendCfg)).To(gomega.Equal(kanikoBuilder{}))
+	})
+}
+
+func TestSystemState_buildJobWithLayerAppend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	s := inlineFunctionState(serverlessv1alpha2.NodeJs16)
+	s.instance.Spec.RuntimeImageOverride = "custom-base:1.0"
+	jobCfg := cfg{
+		docker: DockerConfig{PushAddress: "registry:5000"},
+		fn: FunctionConfig{
+			Build: BuildConfig{
+				LayerAppendRuntimes:    []string{string(serverlessv1alpha2.NodeJs16)},
+				LayerAppendImage:       "crane:debug",
+				DependencyCacheEnabled: true,
+			},
+		},
+	}
+
+	job := s.buildJob("cm-name", jobCfg)
+
+	g.Expect(job.GetAnnotations()).To(gomega.BeEmpty())
+	g.Expect(job.Spec.Template.Spec.Containers).To(gomega.HaveLen(1))
+	container := job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Image).To(gomega.Equal("crane:debug"))
+	g.Expect(getArg(container.Args, destinationArg)).To(gomega.Equal("--destination=" + s.buildImageAddress("registry:5000")))
+	g.Expect(container.Env).To(gomega.ContainElements(
+		corev1.EnvVar{Name: "FUNCTION_DIR", Value: "/usr/src/app/function"},
+		corev1.EnvVar{Name: "BASE_IMAGE", Value: "custom-base:1.0"},
+	))
+	assertVolumes(g, job.Spec.Template.Spec.Volumes, []expectedVolume{
+		{name: "sources", localObjectReference: "cm-name"},
+		{name: "runtime", localObjectReference: "dockerfile-nodejs16"},
+	})
+}
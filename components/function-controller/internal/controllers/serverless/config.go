@@ -16,14 +16,19 @@ type FunctionConfig struct {
 	RequeueDuration                             time.Duration `envconfig:"default=1m"`
 	FunctionReadyRequeueDuration                time.Duration `envconfig:"default=5m"`
 	GitFetchRequeueDuration                     time.Duration `envconfig:"default=30s"`
+	ArtifactFetcherImage                        string        `envconfig:"default=gcr.io/go-containerregistry/crane:debug"`
 	Build                                       BuildConfig
 }
 
 type BuildConfig struct {
-	ExecutorArgs        []string `envconfig:"default=--insecure;--skip-tls-verify;--skip-unused-stages;--log-format=text;--cache=true"`
-	ExecutorImage       string   `envconfig:"default=gcr.io/kaniko-project/executor:v0.22.0"`
-	RepoFetcherImage    string   `envconfig:"default=eu.gcr.io/kyma-project/function-build-init:305bee60"`
-	MaxSimultaneousJobs int      `envconfig:"default=5"`
+	ExecutorArgs            []string `envconfig:"default=--insecure;--skip-tls-verify;--skip-unused-stages;--log-format=text;--cache=true"`
+	ExecutorImage           string   `envconfig:"default=gcr.io/kaniko-project/executor:v0.22.0"`
+	RepoFetcherImage        string   `envconfig:"default=eu.gcr.io/kyma-project/function-build-init:305bee60"`
+	MaxSimultaneousJobs     int      `envconfig:"default=5"`
+	DependencyCacheEnabled  bool     `envconfig:"default=true"`
+	DependencyCacheRepoName string   `envconfig:"default=function-cache"`
+	LayerAppendRuntimes     []string `envconfig:"optional"`
+	LayerAppendImage        string   `envconfig:"default=gcr.io/go-containerregistry/crane:debug"`
 }
 
 type DockerConfig struct {
@@ -3,6 +3,7 @@ package serverless
 import (
 	"context"
 	"fmt"
+	"path"
 	"reflect"
 	"runtime"
 	"strings"
@@ -41,10 +42,12 @@ type out struct {
 }
 
 type reconciler struct {
-	cfg       cfg
-	fn        stateFn
-	log       *zap.SugaredLogger
-	gitClient GitClient
+	cfg         cfg
+	fn          stateFn
+	log         *zap.SugaredLogger
+	gitClient   GitClient
+	gitTriggers *GitTriggers
+	gitTrigger  uint64
 	k8s
 	out
 }
@@ -194,7 +197,7 @@ func stateFnGitCheckSources(ctx context.Context, r *reconciler, s *systemState)
 		Auth:      auth,
 	}
 
-	if skipGitSourceCheck(s.instance, r.cfg) {
+	if r.gitTrigger == 0 && skipGitSourceCheck(s.instance, r.cfg) {
 		r.log.Info(fmt.Sprintf("skipping function [%s] source check", s.instance.Name))
 		expectedJob := s.buildGitJob(options, r.cfg)
 		return buildStateFnCheckImageJob(expectedJob), nil
@@ -205,20 +208,21 @@ func stateFnGitCheckSources(ctx context.Context, r *reconciler, s *systemState)
 	revision, err = r.gitClient.LastCommit(options)
 	if err != nil {
 		r.log.Error(err, " while fetching last commit")
-		var errMsg string
-		r.result, errMsg = NextRequeue(err)
-		// TODO: This return masks the error from r.syncRevision() and doesn't pass it to the controller. This should be fixed in a follow up PR.
-		condition := serverlessv1alpha2.Condition{
-			Type:               serverlessv1alpha2.ConditionConfigurationReady,
-			Status:             corev1.ConditionFalse,
-			LastTransitionTime: metav1.Now(),
-			Reason:             serverlessv1alpha2.ConditionReasonSourceUpdateFailed,
-			Message:            errMsg,
-		}
-		return buildStatusUpdateStateFnWithCondition(condition), nil
+		return buildStateFnGitSourceUpdateFailed(r, err), nil
 	}
+	r.gitTriggers.done(types.NamespacedName{Namespace: s.instance.Namespace, Name: s.instance.Name}, r.gitTrigger)
 
 	srcChanged := s.gitFnSrcChanged(revision)
+	if srcChanged && !s.gitFnConfigChanged() && isMonorepoBaseDir(s.instance.Spec.Source.GitRepository.BaseDir) {
+		// the function lives in a subdirectory of the repository, rebuild it only if the new commit touches it.
+		// The commit stays the built one, it defines the image tag, and the repository fetched for the revision is reused
+		srcChanged, err = r.gitClient.HasChanges(options, s.instance.Spec.Source.GitRepository.BaseDir, s.instance.Status.Commit, revision)
+		if err != nil {
+			r.log.Error(err, " while comparing commits")
+			return buildStateFnGitSourceUpdateFailed(r, err), nil
+		}
+	}
+
 	if !srcChanged {
 		expectedJob := s.buildGitJob(options, r.cfg)
 		return buildStateFnCheckImageJob(expectedJob), nil
@@ -240,6 +244,24 @@ func stateFnGitCheckSources(ctx context.Context, r *reconciler, s *systemState)
 	return buildGenericStatusUpdateStateFn(condition, &repository, revision), nil
 }
 
+func buildStateFnGitSourceUpdateFailed(r *reconciler, err error) stateFn {
+	var errMsg string
+	r.result, errMsg = NextRequeue(err)
+	// TODO: This return masks the error from r.syncRevision() and doesn't pass it to the controller. This should be fixed in a follow up PR.
+	condition := serverlessv1alpha2.Condition{
+		Type:               serverlessv1alpha2.ConditionConfigurationReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             serverlessv1alpha2.ConditionReasonSourceUpdateFailed,
+		Message:            errMsg,
+	}
+	return buildStatusUpdateStateFnWithCondition(condition)
+}
+
+func isMonorepoBaseDir(baseDir string) bool {
+	return strings.Trim(path.Clean("/"+baseDir), "/") != ""
+}
+
 func stateFnInitialize(ctx context.Context, r *reconciler, s *systemState) (stateFn, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, errors.Wrap(err, "context error")
@@ -250,6 +272,10 @@ func stateFnInitialize(ctx context.Context, r *reconciler, s *systemState) (stat
 		return stateFnGitCheckSources, nil
 	}
 
+	if s.instance.TypeOf(serverlessv1alpha2.FunctionTypeImage) {
+		return stateFnImageCheckSources, nil
+	}
+
 	return stateFnInlineCheckSources, nil
 }
 
@@ -273,6 +273,7 @@ func createFakeStateReconcilerWithTestFunction(ctx context.Context, testFunction
 
 	statsCollector := &automock.StatsCollector{}
 	statsCollector.On("UpdateReconcileStats", mock.Anything, mock.Anything).Return()
+	statsCollector.On("UpdateBuildCacheStats", mock.Anything, mock.Anything).Return()
 
 	functionReconciler := NewFunctionReconciler(resourceClient, log, FunctionConfig{}, gitFactory, record.NewFakeRecorder(100), statsCollector, make(chan bool))
 	return &reconciler{
@@ -290,3 +291,72 @@ func createFakeStateReconcilerWithTestFunction(ctx context.Context, testFunction
 		gitClient: functionReconciler.gitFactory.GetGitClient(log),
 	}
 }
+
+func Test_stateFnGitCheckSourcesMonorepo(t *testing.T) {
+	newBuiltFunction := func() *serverlessv1alpha2.Function {
+		fn := newTestGitFunction("test-namespace", "test-git-func", nil, 1, 1, false)
+		fn.Spec.Source.GitRepository.BaseDir = "/functions/hello"
+		fn.Status = serverlessv1alpha2.FunctionStatus{
+			Repository: fn.Spec.Source.GitRepository.Repository,
+			Commit:     "built-commit",
+			Runtime:    fn.Spec.Runtime,
+			Conditions: []serverlessv1alpha2.Condition{
+				{
+					Type:               serverlessv1alpha2.ConditionConfigurationReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Time{Time: time.Now().Add(-time.Hour)}.Rfc3339Copy(),
+					Reason:             serverlessv1alpha2.ConditionReasonSourceUpdated,
+				},
+			},
+		}
+		return fn
+	}
+
+	t.Run("skip rebuild when base directory didn't change", func(t *testing.T) {
+		ctx := context.TODO()
+		testFunction := newBuiltFunction()
+		stateReconciler := createFakeStateReconcilerWithTestFunction(ctx, testFunction)
+
+		gitClient := automock.NewGitClient(t)
+		gitClient.On("LastCommit", mock.Anything).Return("head-commit", nil)
+		gitClient.On("HasChanges", mock.Anything, "/functions/hello", "built-commit", "head-commit").Return(false, nil)
+		stateReconciler.gitClient = gitClient
+
+		next, err := stateFnGitCheckSources(ctx, stateReconciler, &systemState{instance: *testFunction})
+
+		require.NoError(t, err)
+		require.Equal(t, "buildStateFnCheckImageJob", (&reconciler{fn: next}).stateFnName())
+	})
+
+	t.Run("rebuild when base directory changed", func(t *testing.T) {
+		ctx := context.TODO()
+		testFunction := newBuiltFunction()
+		stateReconciler := createFakeStateReconcilerWithTestFunction(ctx, testFunction)
+
+		gitClient := automock.NewGitClient(t)
+		gitClient.On("LastCommit", mock.Anything).Return("head-commit", nil)
+		gitClient.On("HasChanges", mock.Anything, "/functions/hello", "built-commit", "head-commit").Return(true, nil)
+		stateReconciler.gitClient = gitClient
+
+		next, err := stateFnGitCheckSources(ctx, stateReconciler, &systemState{instance: *testFunction})
+
+		require.NoError(t, err)
+		require.Equal(t, "buildGenericStatusUpdateStateFn", (&reconciler{fn: next}).stateFnName())
+	})
+
+	t.Run("don't compare commits when function config changed", func(t *testing.T) {
+		ctx := context.TODO()
+		testFunction := newBuiltFunction()
+		testFunction.Spec.Source.GitRepository.Reference = "other-branch"
+		stateReconciler := createFakeStateReconcilerWithTestFunction(ctx, testFunction)
+
+		gitClient := automock.NewGitClient(t)
+		gitClient.On("LastCommit", mock.Anything).Return("head-commit", nil)
+		stateReconciler.gitClient = gitClient
+
+		next, err := stateFnGitCheckSources(ctx, stateReconciler, &systemState{instance: *testFunction})
+
+		require.NoError(t, err)
+		require.Equal(t, "buildGenericStatusUpdateStateFn", (&reconciler{fn: next}).stateFnName())
+	})
+}
@@ -30,6 +30,7 @@ const (
 //go:generate mockery --name=GitClient --output=automock --outpkg=automock --case=underscore
 type GitClient interface {
 	LastCommit(options git.Options) (string, error)
+	HasChanges(options git.Options, baseDir, fromCommit, toCommit string) (bool, error)
 	Clone(path string, options git.Options) (string, error)
 }
 
@@ -41,6 +42,7 @@ type GitClientFactory interface {
 //go:generate mockery --name=StatsCollector --output=automock --outpkg=automock --case=underscore
 type StatsCollector interface {
 	UpdateReconcileStats(f *serverlessv1alpha2.Function, cond serverlessv1alpha2.Condition)
+	UpdateBuildCacheStats(f *serverlessv1alpha2.Function, populated bool)
 }
 
 type FunctionReconciler struct {
@@ -51,6 +53,7 @@ type FunctionReconciler struct {
 	gitFactory        GitClientFactory
 	statsCollector    StatsCollector
 	healthCh          chan bool
+	gitTriggers       *GitTriggers
 	initStateFunction stateFn
 }
 
@@ -67,6 +70,11 @@ func NewFunctionReconciler(client resource.Client, log *zap.SugaredLogger, confi
 	}
 }
 
+// SetGitTriggers makes the reconciler check the repository of git functions triggered by push webhooks
+func (r *FunctionReconciler) SetGitTriggers(triggers *GitTriggers) {
+	r.gitTriggers = triggers
+}
+
 func (r *FunctionReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("function-controller").
@@ -147,7 +155,9 @@ func (r *FunctionReconciler) Reconcile(ctx context.Context, request ctrl.Request
 			fn:     r.config,
 			docker: dockerCfg,
 		},
-		gitClient: r.gitFactory.GetGitClient(contextLogger),
+		gitClient:   r.gitFactory.GetGitClient(contextLogger),
+		gitTriggers: r.gitTriggers,
+		gitTrigger:  r.gitTriggers.get(request.NamespacedName),
 	}
 
 	stateReconciler.result = ctrl.Result{
@@ -144,6 +144,7 @@ func TestGitOpsWithContinuousGitCheckout(t *testing.T) {
 
 			statsCollector := &automock.StatsCollector{}
 			statsCollector.On("UpdateReconcileStats", mock.Anything, mock.Anything).Return()
+			statsCollector.On("UpdateBuildCacheStats", mock.Anything, mock.Anything).Return()
 
 			reconciler := &FunctionReconciler{
 				Log:               zap.NewNop().Sugar(),
@@ -468,6 +469,7 @@ func TestGitOpsWithoutContinuousGitCheckout(t *testing.T) {
 
 			statsCollector := &automock.StatsCollector{}
 			statsCollector.On("UpdateReconcileStats", mock.Anything, mock.Anything).Return()
+			statsCollector.On("UpdateBuildCacheStats", mock.Anything, mock.Anything).Return()
 
 			reconciler := &FunctionReconciler{
 				Log:               zap.NewNop().Sugar(),
@@ -736,6 +738,7 @@ func TestGitOps_GitErrorHandling(t *testing.T) {
 
 		prometheusCollector := &automock.StatsCollector{}
 		prometheusCollector.On("UpdateReconcileStats", mock.Anything, mock.Anything).Return()
+		prometheusCollector.On("UpdateBuildCacheStats", mock.Anything, mock.Anything).Return()
 		request := ctrl.Request{
 			NamespacedName: types.NamespacedName{
 				Namespace: function.GetNamespace(),
@@ -808,6 +811,7 @@ func Test_stateFnGitCheckSources(t *testing.T) {
 
 		prometheusCollector := &automock.StatsCollector{}
 		prometheusCollector.On("UpdateReconcileStats", mock.Anything, mock.Anything).Return()
+		prometheusCollector.On("UpdateBuildCacheStats", mock.Anything, mock.Anything).Return()
 		request := ctrl.Request{
 			NamespacedName: types.NamespacedName{
 				Namespace: function.GetNamespace(),
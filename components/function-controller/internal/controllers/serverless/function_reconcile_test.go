@@ -46,6 +46,7 @@ func TestFunctionReconciler_Reconcile_Scaling(t *testing.T) {
 	createDockerfileForRuntime(g, resourceClient, rtm)
 	statsCollector := &automock.StatsCollector{}
 	statsCollector.On("UpdateReconcileStats", mock.Anything, mock.Anything).Return()
+	statsCollector.On("UpdateBuildCacheStats", mock.Anything, mock.Anything).Return()
 
 	gitFactory := &automock.GitClientFactory{}
 	gitFactory.On("GetGitClient", mock.Anything).Return(nil)
@@ -286,6 +287,7 @@ func TestFunctionReconciler_Reconcile(t *testing.T) {
 	createDockerfileForRuntime(g, resourceClient, rtm)
 	statsCollector := &automock.StatsCollector{}
 	statsCollector.On("UpdateReconcileStats", mock.Anything, mock.Anything).Return()
+	statsCollector.On("UpdateBuildCacheStats", mock.Anything, mock.Anything).Return()
 
 	gitFactory := &automock.GitClientFactory{}
 	gitFactory.On("GetGitClient", mock.Anything).Return(nil)
@@ -743,6 +745,7 @@ func TestFunctionReconciler_Reconcile(t *testing.T) {
 		// Create new reconciler as this test modify reconciler configuration MaxSimultaneousJobs value
 		statsCollector := &automock.StatsCollector{}
 		statsCollector.On("UpdateReconcileStats", mock.Anything, mock.Anything).Return()
+		statsCollector.On("UpdateBuildCacheStats", mock.Anything, mock.Anything).Return()
 
 		gitFactory := &automock.GitClientFactory{}
 		gitFactory.On("GetGitClient", mock.Anything).Return(nil)
@@ -0,0 +1,275 @@
+package serverless
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	serverlessv1alpha2 "github.com/kyma-project/kyma/components/function-controller/pkg/apis/serverless/v1alpha2"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const (
+	githubEventHeader     = "X-GitHub-Event"
+	githubSignatureHeader = "X-Hub-Signature-256"
+	giteaEventHeader      = "X-Gitea-Event"
+	giteaSignatureHeader  = "X-Gitea-Signature"
+	gitlabEventHeader     = "X-Gitlab-Event"
+	gitlabTokenHeader     = "X-Gitlab-Token"
+
+	maxGitWebhookPayloadSize = 5 << 20
+	gitWebhookEnqueueTimeout = 5 * time.Second
+)
+
+// GitTriggers holds git functions which have to check the repository during their next reconciliation
+// even if the last check took place less than FunctionReadyRequeueDuration ago
+type GitTriggers struct {
+	mu        sync.Mutex
+	seq       uint64
+	functions map[types.NamespacedName]uint64
+}
+
+func NewGitTriggers() *GitTriggers {
+	return &GitTriggers{functions: map[types.NamespacedName]uint64{}}
+}
+
+func (t *GitTriggers) add(name types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seq++
+	t.functions[name] = t.seq
+}
+
+// get returns the last trigger of the function or zero if the function wasn't triggered
+func (t *GitTriggers) get(name types.NamespacedName) uint64 {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.functions[name]
+}
+
+// done forgets the trigger of the function after its repository was checked,
+// unless the function was triggered again in the meantime
+func (t *GitTriggers) done(name types.NamespacedName, trigger uint64) {
+	if t == nil || trigger == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.functions[name] == trigger {
+		delete(t.functions, name)
+	}
+}
+
+// GitWebhookHandler accepts GitHub, GitLab and Gitea push webhooks
+// and enqueues git functions which use the pushed repository reference
+type GitWebhookHandler struct {
+	client   client.Client
+	secret   []byte
+	triggers *GitTriggers
+	eventCh  chan event.GenericEvent
+	log      *zap.SugaredLogger
+}
+
+var _ http.Handler = &GitWebhookHandler{}
+
+func NewGitWebhookHandler(client client.Client, secret string, triggers *GitTriggers, logger *zap.SugaredLogger) (*GitWebhookHandler, chan event.GenericEvent) {
+	eventCh := make(chan event.GenericEvent)
+	return &GitWebhookHandler{
+		client:   client,
+		secret:   []byte(secret),
+		triggers: triggers,
+		eventCh:  eventCh,
+		log:      logger,
+	}, eventCh
+}
+
+type pushEvent struct {
+	Ref        string         `json:"ref"`
+	Repository pushRepository `json:"repository"`
+	Project    pushRepository `json:"project"`
+}
+
+type pushRepository struct {
+	CloneURL   string `json:"clone_url"`
+	SSHURL     string `json:"ssh_url"`
+	HTMLURL    string `json:"html_url"`
+	GitHTTPURL string `json:"git_http_url"`
+	GitSSHURL  string `json:"git_ssh_url"`
+	WebURL     string `json:"web_url"`
+}
+
+func (r pushRepository) urls() []string {
+	return []string{r.CloneURL, r.SSHURL, r.HTMLURL, r.GitHTTPURL, r.GitSSHURL, r.WebURL}
+}
+
+func (h *GitWebhookHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(req.Body, maxGitWebhookPayloadSize))
+	if err != nil {
+		h.log.Warnf("while reading webhook payload: %s", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !h.validRequest(req.Header, payload) {
+		h.log.Warn("webhook request with invalid signature rejected")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !isPushEvent(req.Header) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var push pushEvent
+	if err := json.Unmarshal(payload, &push); err != nil {
+		h.log.Warnf("while decoding push event: %s", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	names, err := h.affectedFunctions(req.Context(), push)
+	if err != nil {
+		h.log.Errorf("while looking for functions affected by push event: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	for _, name := range names {
+		if err := h.enqueue(req.Context(), name); err != nil {
+			h.log.Errorf("while enqueuing function %s: %s", name, err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	h.log.Infof("push to %s enqueued %d functions", push.Ref, len(names))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *GitWebhookHandler) validRequest(header http.Header, payload []byte) bool {
+	if len(h.secret) == 0 {
+		return false
+	}
+
+	if token := header.Get(gitlabTokenHeader); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), h.secret) == 1
+	}
+
+	signature := strings.TrimPrefix(header.Get(githubSignatureHeader), "sha256=")
+	if signature == "" {
+		signature = header.Get(giteaSignatureHeader)
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func isPushEvent(header http.Header) bool {
+	if e := header.Get(githubEventHeader); e != "" {
+		return e == "push"
+	}
+	if e := header.Get(giteaEventHeader); e != "" {
+		return e == "push"
+	}
+	e := header.Get(gitlabEventHeader)
+	return e == "Push Hook" || e == "Tag Push Hook"
+}
+
+func (h *GitWebhookHandler) affectedFunctions(ctx context.Context, push pushEvent) ([]types.NamespacedName, error) {
+	repoURLs := map[string]struct{}{}
+	for _, u := range append(push.Repository.urls(), push.Project.urls()...) {
+		if u != "" {
+			repoURLs[normalizeRepoURL(u)] = struct{}{}
+		}
+	}
+	reference := trimRefPrefix(push.Ref)
+
+	var functions serverlessv1alpha2.FunctionList
+	if err := h.client.List(ctx, &functions); err != nil {
+		return nil, err
+	}
+
+	var names []types.NamespacedName
+	for _, fn := range functions.Items {
+		repo := fn.Spec.Source.GitRepository
+		if repo == nil || repo.Reference != reference {
+			continue
+		}
+		if _, ok := repoURLs[normalizeRepoURL(repo.URL)]; ok {
+			names = append(names, types.NamespacedName{Namespace: fn.Namespace, Name: fn.Name})
+		}
+	}
+	return names, nil
+}
+
+func (h *GitWebhookHandler) enqueue(ctx context.Context, name types.NamespacedName) error {
+	h.triggers.add(name)
+
+	fn := &serverlessv1alpha2.Function{}
+	fn.SetNamespace(name.Namespace)
+	fn.SetName(name.Name)
+
+	select {
+	case h.eventCh <- event.GenericEvent{Object: fn}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(gitWebhookEnqueueTimeout):
+		return errors.New("timeout when sending event")
+	}
+}
+
+func trimRefPrefix(ref string) string {
+	for _, prefix := range []string{"refs/heads/", "refs/tags/"} {
+		if strings.HasPrefix(ref, prefix) {
+			return strings.TrimPrefix(ref, prefix)
+		}
+	}
+	return ref
+}
+
+// normalizeRepoURL makes the https and ssh addresses of the same repository comparable,
+// e.g. git@github.com:kyma-project/kyma.git and https://github.com/kyma-project/kyma both become github.com/kyma-project/kyma
+func normalizeRepoURL(repoURL string) string {
+	repoURL = strings.TrimSpace(repoURL)
+
+	if u, err := url.Parse(repoURL); err == nil && u.Host != "" {
+		repoURL = u.Hostname() + u.Path
+	} else if at := strings.Index(repoURL, "@"); at >= 0 {
+		// scp-like ssh address: user@host:path
+		repoURL = strings.Replace(repoURL[at+1:], ":", "/", 1)
+	}
+
+	repoURL = strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	return strings.ToLower(repoURL)
+}
@@ -0,0 +1,150 @@
+package serverless
+
+import (
+	"context"
+	"fmt"
+
+	fnRuntime "github.com/kyma-project/kyma/components/function-controller/internal/controllers/serverless/runtime"
+	serverlessv1alpha2 "github.com/kyma-project/kyma/components/function-controller/pkg/apis/serverless/v1alpha2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	artifactVolumeName        = "function-artifact"
+	artifactFetcherName       = "artifact-fetcher"
+	artifactFetcherMountPath  = "/function"
+	artifactFetcherScript     = `set -e; crane export --insecure "${ARTIFACT}" - | tar -xf - -C ` + artifactFetcherMountPath
+	prebuiltImageBuildMessage = "Function uses the prebuilt image"
+)
+
+// stateFnImageCheckSources skips the build Job for functions with the image source
+// and goes straight to the Deployment once the configuration and build conditions are reported
+func stateFnImageCheckSources(ctx context.Context, r *reconciler, s *systemState) (stateFn, error) {
+	source := s.instance.Spec.Source.Image
+
+	if source.IsArtifact() {
+		baseImage, err := r.runtimeBaseImage(ctx, s)
+		if err != nil {
+			r.log.Error(err, " while reading runtime base image")
+			condition := serverlessv1alpha2.Condition{
+				Type:               serverlessv1alpha2.ConditionConfigurationReady,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.Now(),
+				Reason:             serverlessv1alpha2.ConditionReasonImageConfigurationFailed,
+				Message:            err.Error(),
+			}
+			return buildStatusUpdateStateFnWithCondition(condition), nil
+		}
+		s.baseImage = baseImage
+	}
+
+	message := fmt.Sprintf("Image %s configured", source.Reference)
+	configured := s.instance.Status.Condition(serverlessv1alpha2.ConditionConfigurationReady)
+	if configured == nil || !configured.IsTrue() || configured.Message != message {
+		condition := serverlessv1alpha2.Condition{
+			Type:               serverlessv1alpha2.ConditionConfigurationReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             serverlessv1alpha2.ConditionReasonImageConfigured,
+			Message:            message,
+		}
+		return buildStatusUpdateStateFnWithCondition(condition), nil
+	}
+
+	built := s.instance.Status.Condition(serverlessv1alpha2.ConditionBuildReady)
+	if built == nil || !built.IsTrue() || built.Reason != serverlessv1alpha2.ConditionReasonBuildSkipped {
+		condition := serverlessv1alpha2.Condition{
+			Type:               serverlessv1alpha2.ConditionBuildReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             serverlessv1alpha2.ConditionReasonBuildSkipped,
+			Message:            prebuiltImageBuildMessage,
+		}
+		return buildStatusUpdateStateFnWithCondition(condition), nil
+	}
+
+	return stateFnCheckDeployments, nil
+}
+
+// runtimeBaseImage returns the image into which the artifact with the function code is unpacked
+func (r *reconciler) runtimeBaseImage(ctx context.Context, s *systemState) (string, error) {
+	if s.instance.Spec.RuntimeImageOverride != "" {
+		return s.instance.Spec.RuntimeImageOverride, nil
+	}
+
+	rtmCfg := fnRuntime.GetRuntimeConfig(s.instance.Spec.Runtime)
+	var configMap corev1.ConfigMap
+	key := client.ObjectKey{Namespace: s.instance.GetNamespace(), Name: rtmCfg.DockerfileConfigMapName}
+	if err := r.client.Get(ctx, key, &configMap); err != nil {
+		return "", errors.Wrapf(err, "while getting runtime ConfigMap %s", rtmCfg.DockerfileConfigMapName)
+	}
+
+	baseImage := configMap.Data[baseImageKey]
+	if baseImage == "" {
+		return "", fmt.Errorf("runtime ConfigMap %s doesn't contain the %s key", rtmCfg.DockerfileConfigMapName, baseImageKey)
+	}
+	return baseImage, nil
+}
+
+// functionImage returns the image run by the function Deployment
+func (s *systemState) functionImage(registryAddress string) string {
+	if !s.instance.TypeOf(serverlessv1alpha2.FunctionTypeImage) {
+		return s.buildImageAddress(registryAddress)
+	}
+
+	if s.instance.Spec.Source.Image.IsArtifact() {
+		return s.baseImage
+	}
+	return s.instance.Spec.Source.Image.Reference
+}
+
+// buildArtifactFetcher returns the volumes and the init container which unpacks the artifact with the function code,
+// the artifact volume has to be mounted in the function directory of the runtime
+func (s *systemState) buildArtifactFetcher(cfg buildDeploymentArgs) ([]corev1.Volume, corev1.Container) {
+	volumes := []corev1.Volume{
+		{
+			Name: artifactVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{Name: artifactVolumeName, MountPath: artifactFetcherMountPath},
+	}
+
+	if cfg.RegistryConfigSecretName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "credentials",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cfg.RegistryConfigSecretName,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  ".dockerconfigjson",
+							Path: ".docker/config.json",
+						},
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "credentials", ReadOnly: true, MountPath: "/docker"})
+	}
+
+	container := corev1.Container{
+		Name:    artifactFetcherName,
+		Image:   cfg.ArtifactFetcherImage,
+		Command: []string{"/busybox/sh", "-c", artifactFetcherScript},
+		Env: []corev1.EnvVar{
+			{Name: "ARTIFACT", Value: s.instance.Spec.Source.Image.Reference},
+			{Name: "DOCKER_CONFIG", Value: "/docker/.docker/"},
+		},
+		VolumeMounts:    volumeMounts,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: restrictiveContainerSecurityContext(),
+	}
+	return volumes, container
+}
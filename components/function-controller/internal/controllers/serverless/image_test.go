@@ -0,0 +1,152 @@
+package serverless
+
+import (
+	"context"
+	"testing"
+
+	serverlessv1alpha2 "github.com/kyma-project/kyma/components/function-controller/pkg/apis/serverless/v1alpha2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStateFnImageCheckSources(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("set configuration condition", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		fn := imageFunction(serverlessv1alpha2.ImageSourceTypeImage)
+		r := createFakeStateReconcilerWithTestFunction(ctx, fn)
+		s := &systemState{instance: *fn}
+
+		next, err := stateFnImageCheckSources(ctx, r, s)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect((&reconciler{fn: next}).stateFnName()).To(gomega.Equal("buildGenericStatusUpdateStateFn"))
+
+		_, err = next(ctx, r, s)
+		g.Expect(err).To(gomega.BeNil())
+
+		var updated serverlessv1alpha2.Function
+		g.Expect(r.client.Get(ctx, types.NamespacedName{Namespace: fn.Namespace, Name: fn.Name}, &updated)).To(gomega.Succeed())
+		condition := updated.Status.Condition(serverlessv1alpha2.ConditionConfigurationReady)
+		g.Expect(condition).NotTo(gomega.BeNil())
+		g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionTrue))
+		g.Expect(condition.Reason).To(gomega.Equal(serverlessv1alpha2.ConditionReasonImageConfigured))
+	})
+
+	t.Run("skip build and check deployments", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		fn := imageFunction(serverlessv1alpha2.ImageSourceTypeImage)
+		fn.Status.Conditions = []serverlessv1alpha2.Condition{
+			{
+				Type:    serverlessv1alpha2.ConditionConfigurationReady,
+				Status:  corev1.ConditionTrue,
+				Reason:  serverlessv1alpha2.ConditionReasonImageConfigured,
+				Message: "Image registry.example.com/functions/hello:1.0.0 configured",
+			},
+			{
+				Type:   serverlessv1alpha2.ConditionBuildReady,
+				Status: corev1.ConditionTrue,
+				Reason: serverlessv1alpha2.ConditionReasonBuildSkipped,
+			},
+		}
+		r := createFakeStateReconcilerWithTestFunction(ctx, fn)
+
+		next, err := stateFnImageCheckSources(ctx, r, &systemState{instance: *fn})
+
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect((&reconciler{fn: next}).stateFnName()).To(gomega.Equal("stateFnCheckDeployments"))
+	})
+
+	t.Run("fail configuration when runtime base image is missing", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		fn := imageFunction(serverlessv1alpha2.ImageSourceTypeArtifact)
+		r := createFakeStateReconcilerWithTestFunction(ctx, fn)
+		s := &systemState{instance: *fn}
+
+		next, err := stateFnImageCheckSources(ctx, r, s)
+		g.Expect(err).To(gomega.BeNil())
+
+		_, err = next(ctx, r, s)
+		g.Expect(err).To(gomega.BeNil())
+
+		var updated serverlessv1alpha2.Function
+		g.Expect(r.client.Get(ctx, types.NamespacedName{Namespace: fn.Namespace, Name: fn.Name}, &updated)).To(gomega.Succeed())
+		condition := updated.Status.Condition(serverlessv1alpha2.ConditionConfigurationReady)
+		g.Expect(condition).NotTo(gomega.BeNil())
+		g.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+		g.Expect(condition.Reason).To(gomega.Equal(serverlessv1alpha2.ConditionReasonImageConfigurationFailed))
+	})
+}
+
+func TestSystemState_buildDeploymentWithImageSource(t *testing.T) {
+	args := buildDeploymentArgs{
+		DockerPullAddress:        "registry:5000",
+		ArtifactFetcherImage:     "crane:debug",
+		RegistryConfigSecretName: "registry-config",
+	}
+
+	t.Run("run prebuilt image", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		s := systemState{instance: *imageFunction(serverlessv1alpha2.ImageSourceTypeImage)}
+
+		deployment := s.buildDeployment(args)
+
+		g.Expect(deployment.Spec.Template.Spec.InitContainers).To(gomega.BeEmpty())
+		g.Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(gomega.Equal("registry.example.com/functions/hello:1.0.0"))
+	})
+
+	t.Run("unpack artifact into runtime image", func(t *testing.T) {
+		g := gomega.NewGomegaWithT(t)
+
+		s := systemState{
+			instance:  *imageFunction(serverlessv1alpha2.ImageSourceTypeArtifact),
+			baseImage: "nodejs16-base:1.0",
+		}
+
+		deployment := s.buildDeployment(args)
+
+		podSpec := deployment.Spec.Template.Spec
+		g.Expect(podSpec.Containers[0].Image).To(gomega.Equal("nodejs16-base:1.0"))
+		g.Expect(podSpec.Containers[0].VolumeMounts).To(gomega.ContainElement(corev1.VolumeMount{
+			Name:      artifactVolumeName,
+			MountPath: "/usr/src/app/function",
+			ReadOnly:  true,
+		}))
+		g.Expect(podSpec.InitContainers).To(gomega.HaveLen(1))
+		g.Expect(podSpec.InitContainers[0].Image).To(gomega.Equal("crane:debug"))
+		g.Expect(podSpec.InitContainers[0].Env).To(gomega.ContainElement(corev1.EnvVar{Name: "ARTIFACT", Value: "registry.example.com/functions/hello:1.0.0"}))
+
+		changed := s
+		changed.instance.Spec.Source.Image = &serverlessv1alpha2.ImageSource{
+			Reference: "registry.example.com/functions/hello:2.0.0",
+			Type:      serverlessv1alpha2.ImageSourceTypeArtifact,
+		}
+		g.Expect(equalDeployments(deployment, changed.buildDeployment(args))).To(gomega.BeFalse())
+	})
+}
+
+func imageFunction(sourceType serverlessv1alpha2.ImageSourceType) *serverlessv1alpha2.Function {
+	return &serverlessv1alpha2.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "image-function", Namespace: "test-namespace"},
+		Spec: serverlessv1alpha2.FunctionSpec{
+			Runtime: serverlessv1alpha2.NodeJs16,
+			Source: serverlessv1alpha2.Source{
+				Image: &serverlessv1alpha2.ImageSource{
+					Reference: "registry.example.com/functions/hello:1.0.0",
+					Type:      sourceType,
+				},
+			},
+			ResourceConfiguration: &serverlessv1alpha2.ResourceConfiguration{
+				Function: &serverlessv1alpha2.ResourceRequirements{
+					Resources: &corev1.ResourceRequirements{},
+				},
+			},
+		},
+	}
+}
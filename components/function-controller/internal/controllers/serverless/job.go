@@ -111,6 +111,10 @@ func buildStateFnInlineCreateJob(expectedJob batchv1.Job) stateFn {
 			return nil, errors.Wrap(err, "while creating job")
 		}
 
+		if cacheKey, ok := expectedJob.GetAnnotations()[buildCacheKeyAnnotation]; ok {
+			r.statsCollector.UpdateBuildCacheStats(&s.instance, buildCachePopulated(allJobs, cacheKey))
+		}
+
 		condition := serverlessv1alpha2.Condition{
 			Type:               serverlessv1alpha2.ConditionBuildReady,
 			Status:             corev1.ConditionUnknown,
@@ -16,6 +16,10 @@ const (
 	NamespaceLabelKey = "namespace"
 	TypeLabelKey      = "type"
 	RuntimeLabelKey   = "runtime"
+	ResultLabelKey    = "result"
+
+	BuildCachePopulatedValue = "populated"
+	BuildCacheEmptyValue     = "empty"
 )
 
 type PrometheusStatsCollector struct {
@@ -24,6 +28,7 @@ type PrometheusStatsCollector struct {
 	FunctionConfiguredStatusGaugeVec *prometheus.GaugeVec
 	FunctionBuiltStatusGaugeVec      *prometheus.GaugeVec
 	FunctionRunningStatusGaugeVec    *prometheus.GaugeVec
+	FunctionBuildCacheCounterVec     *prometheus.CounterVec
 }
 
 func NewPrometheusStatsCollector() *PrometheusStatsCollector {
@@ -56,6 +61,14 @@ func NewPrometheusStatsCollector() *PrometheusStatsCollector {
 		RuntimeLabelKey,
 	})
 
+	functionBuildCacheCounterVec := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "function_build_dependency_cache_populated_total",
+		Help: "number of function builds started with the shared dependency cache already populated by another build or still empty",
+	}, []string{
+		RuntimeLabelKey,
+		ResultLabelKey,
+	})
+
 	instance := &PrometheusStatsCollector{
 		conditionGaugeSet: map[string]bool{},
 		conditionGauges: map[serverlessv1alpha2.ConditionType]*prometheus.GaugeVec{
@@ -66,6 +79,7 @@ func NewPrometheusStatsCollector() *PrometheusStatsCollector {
 		FunctionConfiguredStatusGaugeVec: functionConfiguredStatusGaugeVec,
 		FunctionBuiltStatusGaugeVec:      functionBuiltStatusGaugeVec,
 		FunctionRunningStatusGaugeVec:    functionRunningStatusGaugeVec,
+		FunctionBuildCacheCounterVec:     functionBuildCacheCounterVec,
 	}
 
 	return instance
@@ -75,7 +89,20 @@ func (p *PrometheusStatsCollector) Register() {
 	metrics.Registry.MustRegister(
 		p.FunctionConfiguredStatusGaugeVec,
 		p.FunctionBuiltStatusGaugeVec,
-		p.FunctionRunningStatusGaugeVec)
+		p.FunctionRunningStatusGaugeVec,
+		p.FunctionBuildCacheCounterVec)
+}
+
+func (p *PrometheusStatsCollector) UpdateBuildCacheStats(f *serverlessv1alpha2.Function, populated bool) {
+	result := BuildCacheEmptyValue
+	if populated {
+		result = BuildCachePopulatedValue
+	}
+
+	p.FunctionBuildCacheCounterVec.With(prometheus.Labels{
+		RuntimeLabelKey: string(f.Spec.Runtime),
+		ResultLabelKey:  result,
+	}).Inc()
 }
 
 func (p *PrometheusStatsCollector) UpdateReconcileStats(f *serverlessv1alpha2.Function, cond serverlessv1alpha2.Condition) {
@@ -115,6 +142,10 @@ func (p *PrometheusStatsCollector) createLabels(f *serverlessv1alpha2.Function)
 		functionType = serverlessv1alpha2.FunctionTypeGit
 	}
 
+	if f.TypeOf(serverlessv1alpha2.FunctionTypeImage) {
+		functionType = serverlessv1alpha2.FunctionTypeImage
+	}
+
 	return prometheus.Labels{
 		NameLabelKey:      f.Name,
 		NamespaceLabelKey: f.Namespace,
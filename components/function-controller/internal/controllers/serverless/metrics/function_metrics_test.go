@@ -129,3 +129,36 @@ func Test_UpdateFunctionStatusGauge(t *testing.T) {
 		require.False(t, stas.conditionGaugeSet[stas.createGaugeID(f.UID, cond.Type)])
 	})
 }
+
+func Test_UpdateBuildCacheStats(t *testing.T) {
+	t.Run("Count builds with populated and empty cache per runtime", func(t *testing.T) {
+		//GIVEN
+		stas := NewPrometheusStatsCollector()
+		f := &serverlessv1alpha2.Function{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "test-fn",
+				Namespace: "test-namespace",
+			},
+			Spec: serverlessv1alpha2.FunctionSpec{
+				Runtime: serverlessv1alpha2.NodeJs16,
+			},
+		}
+
+		//WHEN
+		stas.UpdateBuildCacheStats(f, true)
+		stas.UpdateBuildCacheStats(f, false)
+		stas.UpdateBuildCacheStats(f, true)
+
+		//THEN
+		populated := testutil.ToFloat64(stas.FunctionBuildCacheCounterVec.With(map[string]string{
+			RuntimeLabelKey: string(serverlessv1alpha2.NodeJs16),
+			ResultLabelKey:  BuildCachePopulatedValue,
+		}))
+		empty := testutil.ToFloat64(stas.FunctionBuildCacheCounterVec.With(map[string]string{
+			RuntimeLabelKey: string(serverlessv1alpha2.NodeJs16),
+			ResultLabelKey:  BuildCacheEmptyValue,
+		}))
+		require.Equal(t, float64(2), populated)
+		require.Equal(t, float64(1), empty)
+	})
+}
@@ -1,6 +1,7 @@
 package runtime
 
 import (
+	"encoding/json"
 	"strings"
 )
 
@@ -8,6 +9,10 @@ type nodejs struct {
 	Config
 }
 
+type packageJSON struct {
+	Dependencies map[string]interface{} `json:"dependencies"`
+}
+
 func (n nodejs) SanitizeDependencies(dependencies string) string {
 	result := "{}"
 	if strings.Trim(dependencies, " ") != "" {
@@ -17,4 +22,14 @@ func (n nodejs) SanitizeDependencies(dependencies string) string {
 	return result
 }
 
+func (n nodejs) HasDependencies(dependencies string) bool {
+	var pkg packageJSON
+	if err := json.Unmarshal([]byte(n.SanitizeDependencies(dependencies)), &pkg); err != nil {
+		// let npm decide what to do with the malformed package.json
+		return true
+	}
+
+	return len(pkg.Dependencies) != 0
+}
+
 var _ Runtime = nodejs{}
@@ -44,3 +44,44 @@ func TestNodejs_SanitizeDependencies(t *testing.T) {
 		})
 	}
 }
+
+func TestNodejs_HasDependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		deps string
+		want bool
+	}{
+		{
+			name: "empty deps",
+			deps: "",
+			want: false,
+		},
+		{
+			name: "package.json without dependencies",
+			deps: `{"name": "fn", "version": "1.0.0"}`,
+			want: false,
+		},
+		{
+			name: "package.json with empty dependencies",
+			deps: `{"name": "fn", "dependencies": {}}`,
+			want: false,
+		},
+		{
+			name: "package.json with dependencies",
+			deps: `{"name": "fn", "dependencies": {"lodash": "4.17.21"}}`,
+			want: true,
+		},
+		{
+			name: "malformed package.json",
+			deps: "random-string",
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			r := runtime.GetRuntime(v1alpha2.NodeJs16)
+			g.Expect(r.HasDependencies(tt.deps)).To(gomega.Equal(tt.want))
+		})
+	}
+}
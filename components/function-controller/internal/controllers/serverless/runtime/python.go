@@ -1,5 +1,9 @@
 package runtime
 
+import (
+	"strings"
+)
+
 type python struct {
 	Config
 }
@@ -8,4 +12,15 @@ func (p python) SanitizeDependencies(dependencies string) string {
 	return dependencies
 }
 
+func (p python) HasDependencies(dependencies string) bool {
+	for _, line := range strings.Split(dependencies, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return true
+		}
+	}
+
+	return false
+}
+
 var _ Runtime = python{}
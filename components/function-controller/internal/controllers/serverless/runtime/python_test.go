@@ -0,0 +1,40 @@
+package runtime_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/kyma/components/function-controller/internal/controllers/serverless/runtime"
+	"github.com/kyma-project/kyma/components/function-controller/pkg/apis/serverless/v1alpha2"
+	"github.com/onsi/gomega"
+)
+
+func TestPython_HasDependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		deps string
+		want bool
+	}{
+		{
+			name: "empty requirements",
+			deps: "",
+			want: false,
+		},
+		{
+			name: "only comments and blank lines",
+			deps: "# no dependencies\n\n   \n",
+			want: false,
+		},
+		{
+			name: "requirements with packages",
+			deps: "# http client\nrequests==2.28.1\n",
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			r := runtime.GetRuntime(v1alpha2.Python39)
+			g.Expect(r.HasDependencies(tt.deps)).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -9,12 +9,14 @@ import (
 
 type Runtime interface {
 	SanitizeDependencies(dependencies string) string
+	HasDependencies(dependencies string) bool
 }
 
 type Config struct {
 	Runtime                 serverlessv1alpha2.Runtime
 	DependencyFile          string
 	FunctionFile            string
+	FunctionDir             string
 	DockerfileConfigMapName string
 	RuntimeEnvs             []corev1.EnvVar
 }
@@ -50,13 +52,20 @@ func fillConfigEnvVars(runtime serverlessv1alpha2.Runtime, config *Config) {
 
 func fillConfigFileNames(runtime serverlessv1alpha2.Runtime, config *Config) {
 	switch runtime {
-	case serverlessv1alpha2.NodeJs14, serverlessv1alpha2.NodeJs16:
+	case serverlessv1alpha2.NodeJs14:
+		config.DependencyFile = "package.json"
+		config.FunctionFile = "handler.js"
+		config.FunctionDir = "/kubeless"
+		return
+	case serverlessv1alpha2.NodeJs16:
 		config.DependencyFile = "package.json"
 		config.FunctionFile = "handler.js"
+		config.FunctionDir = "/usr/src/app/function"
 		return
 	case serverlessv1alpha2.Python39:
 		config.DependencyFile = "requirements.txt"
 		config.FunctionFile = "handler.py"
+		config.FunctionDir = "/kubeless"
 		return
 	}
 }
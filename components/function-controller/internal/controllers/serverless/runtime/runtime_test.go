@@ -22,6 +22,7 @@ func TestGetRuntimeConfig(t *testing.T) {
 				Runtime:                 serverlessv1alpha2.Python39,
 				DependencyFile:          "requirements.txt",
 				FunctionFile:            "handler.py",
+				FunctionDir:             "/kubeless",
 				DockerfileConfigMapName: "dockerfile-python39",
 				RuntimeEnvs: []corev1.EnvVar{{Name: "PYTHONPATH", Value: "$(KUBELESS_INSTALL_VOLUME)/lib.python3.9/site-packages:$(KUBELESS_INSTALL_VOLUME)"},
 					{Name: "FUNC_RUNTIME", Value: "python39"},
@@ -35,6 +36,7 @@ func TestGetRuntimeConfig(t *testing.T) {
 				Runtime:                 serverlessv1alpha2.NodeJs14,
 				DependencyFile:          "package.json",
 				FunctionFile:            "handler.js",
+				FunctionDir:             "/kubeless",
 				DockerfileConfigMapName: "dockerfile-nodejs14",
 				RuntimeEnvs: []corev1.EnvVar{{Name: "NODE_PATH", Value: "$(KUBELESS_INSTALL_VOLUME)/node_modules"},
 					{Name: "FUNC_RUNTIME", Value: "nodejs14"}},
@@ -47,6 +49,7 @@ func TestGetRuntimeConfig(t *testing.T) {
 				Runtime:                 serverlessv1alpha2.NodeJs16,
 				DependencyFile:          "package.json",
 				FunctionFile:            "handler.js",
+				FunctionDir:             "/usr/src/app/function",
 				DockerfileConfigMapName: "dockerfile-nodejs16",
 				RuntimeEnvs: []corev1.EnvVar{
 					{Name: "FUNC_RUNTIME", Value: "nodejs16"}},
@@ -25,6 +25,7 @@ type SystemState interface{}
 type systemState struct {
 	instance    serverlessv1alpha2.Function
 	image       string               // TODO make sure this is needed
+	baseImage   string               // runtime image for functions with the artifact source
 	configMaps  corev1.ConfigMapList // TODO create issue to refactor this (only 1 config map should be here)
 	deployments appsv1.DeploymentList
 	jobs        batchv1.JobList
@@ -136,61 +137,64 @@ var (
 )
 
 func (s *systemState) buildGitJob(gitOptions git.Options, cfg cfg) batchv1.Job {
+	builder := s.builder(cfg)
+
+	volumes := []corev1.Volume{buildJobCredentialsVolume(cfg)}
+	volumes = append(volumes, builder.Volumes(s, cfg)...)
+	volumes = append(volumes, corev1.Volume{
+		Name:         "workspace",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+
 	templateSpec := corev1.PodSpec{
-		Volumes: []corev1.Volume{
-			buildJobCredentialsVolume(cfg),
-			buildRegistryConfigVolume(cfg),
-			s.buildJobRuntimeVolume(),
-			{
-				Name:         "workspace",
-				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
-			},
-		},
+		Volumes: volumes,
 		InitContainers: []corev1.Container{
 			s.buildGitJobRepoFetcherContainer(gitOptions, cfg),
 		},
 		Containers: []corev1.Container{
-			s.buildJobExecutorContainer(cfg, s.getGitBuildJobVolumeMounts()),
+			builder.Container(s, cfg),
 		},
 		RestartPolicy: corev1.RestartPolicyNever,
 	}
 	enrichPodSpecWithSecurityContext(&templateSpec, rootUser, rootUserGroup)
 
-	return s.buildJobJob(templateSpec)
+	return s.buildJobJob(templateSpec, builder.Annotations(s, cfg))
 }
 
 func (s *systemState) buildJob(configMapName string, cfg cfg) batchv1.Job {
-	templateSpec := corev1.PodSpec{
-		Volumes: []corev1.Volume{
-			buildJobCredentialsVolume(cfg),
-			buildRegistryConfigVolume(cfg),
-			s.buildJobRuntimeVolume(),
-			{
-				Name: "sources",
-				VolumeSource: corev1.VolumeSource{
-					ConfigMap: &corev1.ConfigMapVolumeSource{
-						LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
-					},
-				},
+	builder := s.builder(cfg)
+
+	volumes := []corev1.Volume{buildJobCredentialsVolume(cfg)}
+	volumes = append(volumes, builder.Volumes(s, cfg)...)
+	volumes = append(volumes, corev1.Volume{
+		Name: "sources",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
 			},
 		},
+	})
+
+	templateSpec := corev1.PodSpec{
+		Volumes: volumes,
 		Containers: []corev1.Container{
-			s.buildJobExecutorContainer(cfg, s.getBuildJobVolumeMounts()),
+			builder.Container(s, cfg),
 		},
 		RestartPolicy: corev1.RestartPolicyNever,
 	}
 	enrichPodSpecWithSecurityContext(&templateSpec, rootUser, rootUserGroup)
 
-	return s.buildJobJob(templateSpec)
+	return s.buildJobJob(templateSpec, builder.Annotations(s, cfg))
 }
 
-func (s *systemState) buildJobJob(templateSpec corev1.PodSpec) batchv1.Job {
+func (s *systemState) buildJobJob(templateSpec corev1.PodSpec, annotations map[string]string) batchv1.Job {
 	labels := s.functionLabels()
 	return batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: fmt.Sprintf("%s-build-", s.instance.GetName()),
 			Namespace:    s.instance.GetNamespace(),
 			Labels:       labels,
+			Annotations:  annotations,
 		},
 		Spec: batchv1.JobSpec{
 			Parallelism:           pointer.Int32(1),
@@ -233,6 +237,10 @@ func (s *systemState) buildJobExecutorContainer(cfg cfg, volumeMounts []corev1.V
 		args = append(args,
 			fmt.Sprintf("--build-arg=base_image=%s", s.instance.Spec.RuntimeImageOverride))
 	}
+	if s.usesSharedBuildCache(cfg) {
+		args = append(args,
+			fmt.Sprintf("%s=%s", cacheRepoArg, s.buildCacheRepoAddress(cfg)))
+	}
 
 	resourceRequirements := getBuildResourceRequirements(s)
 
@@ -350,16 +358,18 @@ func (s *systemState) podLabels() map[string]string {
 }
 
 type buildDeploymentArgs struct {
-	DockerPullAddress      string
-	JaegerServiceEndpoint  string
-	TraceCollectorEndpoint string
-	PublisherProxyAddress  string
-	ImagePullAccountName   string
+	DockerPullAddress        string
+	JaegerServiceEndpoint    string
+	TraceCollectorEndpoint   string
+	PublisherProxyAddress    string
+	ImagePullAccountName     string
+	ArtifactFetcherImage     string
+	RegistryConfigSecretName string
 }
 
 func (s *systemState) buildDeployment(cfg buildDeploymentArgs) appsv1.Deployment {
 
-	imageName := s.buildImageAddress(cfg.DockerPullAddress)
+	imageName := s.functionImage(cfg.DockerPullAddress)
 	deploymentLabels := s.functionLabels()
 	podLabels := s.podLabels()
 
@@ -407,8 +417,21 @@ func (s *systemState) buildDeployment(cfg buildDeploymentArgs) appsv1.Deployment
 	}
 	volumeMounts = append(volumeMounts, secretVolumeMounts...)
 
+	var initContainers []corev1.Container
+	if s.instance.TypeOf(serverlessv1alpha2.FunctionTypeImage) && s.instance.Spec.Source.Image.IsArtifact() {
+		artifactVolumes, artifactFetcher := s.buildArtifactFetcher(cfg)
+		volumes = append(volumes, artifactVolumes...)
+		initContainers = append(initContainers, artifactFetcher)
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      artifactVolumeName,
+			MountPath: rtmCfg.FunctionDir,
+			ReadOnly:  true,
+		})
+	}
+
 	templateSpec := corev1.PodSpec{
-		Volumes: volumes,
+		Volumes:        volumes,
+		InitContainers: initContainers,
 		Containers: []corev1.Container{
 			{
 				Name:         functionContainerName,
@@ -652,13 +675,16 @@ func (s *systemState) equalHorizontalPodAutoscalers(expected autoscalingv1.Horiz
 }
 
 func (s *systemState) gitFnSrcChanged(commit string) bool {
+	return commit != s.instance.Status.Commit || s.gitFnConfigChanged()
+}
+
+// gitFnConfigChanged returns true if the function has to be rebuilt regardless of the repository content
+func (s *systemState) gitFnConfigChanged() bool {
 	return s.instance.Status.Commit == "" ||
-		commit != s.instance.Status.Commit ||
 		s.instance.Spec.Source.GitRepository.Reference != s.instance.Status.Reference ||
 		s.instance.Spec.Runtime != s.instance.Status.Runtime ||
 		s.instance.Spec.Source.GitRepository.BaseDir != s.instance.Status.BaseDir ||
 		getConditionStatus(s.instance.Status.Conditions, serverlessv1alpha2.ConditionConfigurationReady) == corev1.ConditionFalse
-
 }
 
 func (s *systemState) jobFailed(p func(reason string) bool) bool {
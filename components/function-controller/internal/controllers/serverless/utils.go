@@ -216,7 +216,20 @@ func equalDeployments(existing appsv1.Deployment, expected appsv1.Deployment) bo
 		mapsEqual(existing.Spec.Template.GetLabels(), expected.Spec.Template.GetLabels()) &&
 		equalResources(existing.Spec.Template.Spec.Containers[0].Resources, expected.Spec.Template.Spec.Containers[0].Resources) &&
 		equalInt32Pointer(existing.Spec.Replicas, expected.Spec.Replicas) &&
-		equalSecretMounts(existing.Spec.Template.Spec, expected.Spec.Template.Spec)
+		equalSecretMounts(existing.Spec.Template.Spec, expected.Spec.Template.Spec) &&
+		equalInitContainers(existing.Spec.Template.Spec.InitContainers, expected.Spec.Template.Spec.InitContainers)
+}
+
+func equalInitContainers(existing, expected []corev1.Container) bool {
+	if len(existing) != len(expected) {
+		return false
+	}
+	for i := range existing {
+		if existing[i].Image != expected[i].Image || !envsEqual(existing[i].Env, expected[i].Env) {
+			return false
+		}
+	}
+	return true
 }
 
 func equalServices(existing corev1.Service, expected corev1.Service) bool {
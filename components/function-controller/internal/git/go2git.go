@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path"
+	"regexp"
 	"strings"
 
 	git2go "github.com/libgit2/git2go/v31"
@@ -18,8 +19,11 @@ const (
 	branchRefPattern = "refs/remotes/origin"
 )
 
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
 type GitClient interface {
 	LastCommit(options Options) (string, error)
+	HasChanges(options Options, baseDir, fromCommit, toCommit string) (bool, error)
 	Clone(path string, options Options) (string, error)
 }
 
@@ -93,12 +97,80 @@ func (g *git2GoClient) LastCommit(options Options) (string, error) {
 	}
 	//tag
 	commit, err := g.lookupTag(repo, options.Reference)
-	if err != nil {
+	if err == nil {
+		return commit.Id().String(), nil
+	}
+	if !git2go.IsErrorCode(err, git2go.ErrNotFound) || !commitSHAPattern.MatchString(options.Reference) {
 		return "", errors.Wrap(err, "while lookup tag")
 	}
+	//abbreviated commit
+	commit, err = g.lookupCommit(repo, options.Reference)
+	if err != nil {
+		return "", errors.Wrap(err, "while lookup commit")
+	}
+	defer commit.Free()
 	return commit.Id().String(), nil
 }
 
+// HasChanges returns true if any file in the baseDir differs between the given commits.
+// The fromCommit missing in the repository (e.g. after force push) is reported as a change
+func (g *git2GoClient) HasChanges(options Options, baseDir, fromCommit, toCommit string) (bool, error) {
+	repo, err := g.openFetchedRepo(options, toCommit)
+	if err != nil {
+		return false, err
+	}
+	defer repo.Free()
+
+	from, err := g.lookupCommit(repo, fromCommit)
+	if git2go.IsErrorCode(err, git2go.ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "while lookup commit %s", fromCommit)
+	}
+	defer from.Free()
+
+	to, err := g.lookupCommit(repo, toCommit)
+	if err != nil {
+		return false, errors.Wrapf(err, "while lookup commit %s", toCommit)
+	}
+	defer to.Free()
+
+	fromTree, err := from.Tree()
+	if err != nil {
+		return false, errors.Wrapf(err, "while getting tree of commit %s", fromCommit)
+	}
+	defer fromTree.Free()
+
+	toTree, err := to.Tree()
+	if err != nil {
+		return false, errors.Wrapf(err, "while getting tree of commit %s", toCommit)
+	}
+	defer toTree.Free()
+
+	diffOpts, err := git2go.DefaultDiffOptions()
+	if err != nil {
+		return false, errors.Wrap(err, "while creating diff options")
+	}
+	if dir := strings.Trim(path.Clean("/"+baseDir), "/"); dir != "" {
+		diffOpts.Pathspec = []string{dir}
+	}
+
+	diff, err := repo.DiffTreeToTree(fromTree, toTree, &diffOpts)
+	if err != nil {
+		return false, errors.Wrap(err, "while comparing commits")
+	}
+	defer func() {
+		_ = diff.Free()
+	}()
+
+	deltas, err := diff.NumDeltas()
+	if err != nil {
+		return false, errors.Wrap(err, "while counting changed files")
+	}
+	return deltas > 0, nil
+}
+
 func (g *git2GoClient) Clone(path string, options Options) (string, error) {
 	repo, err := g.cloneRepo(options, path)
 	if err != nil {
@@ -129,6 +201,31 @@ func (g *git2GoClient) Clone(path string, options Options) (string, error) {
 	return ref.Target().String(), nil
 }
 
+// openFetchedRepo reuses the repository fetched by LastCommit. The repository is fetched again only if it doesn't contain the commit,
+// e.g. when the reference is a commit id for which LastCommit doesn't fetch the repository
+func (g *git2GoClient) openFetchedRepo(options Options, commitID string) (*git2go.Repository, error) {
+	repoDir, err := mkRepoDir(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating temporary directory")
+	}
+
+	repo, err := git2go.OpenRepository(repoDir)
+	if err == nil {
+		commit, err := g.lookupCommit(repo, commitID)
+		if err == nil {
+			commit.Free()
+			return repo, nil
+		}
+		repo.Free()
+	}
+
+	repo, err = g.fetchRepo(options, repoDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "while fetching the repository")
+	}
+	return repo, nil
+}
+
 func (g *git2GoClient) cloneRepo(opts Options, path string) (*git2go.Repository, error) {
 	authCallbacks, err := GetAuth(opts.Auth)
 	if err != nil {
@@ -205,6 +302,17 @@ func (g *git2GoClient) lookupTag(repo *git2go.Repository, tagName string) (*git2
 	return commit, nil
 }
 
+// lookupCommit resolves the full or abbreviated commit id
+func (g *git2GoClient) lookupCommit(repo *git2go.Repository, commitID string) (*git2go.Commit, error) {
+	obj, err := repo.RevparseSingle(commitID)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Free()
+
+	return obj.AsCommit()
+}
+
 func removeDir(path string) {
 	if os.RemoveAll(path) != nil {
 		log.Printf("Error while deleting directory: %s", path)
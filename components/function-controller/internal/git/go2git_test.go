@@ -52,6 +52,11 @@ func TestNewGit2Go_LastCommit(t *testing.T) {
 			refName:          tagCommit,
 			expectedCommitID: tagCommit,
 		},
+		{
+			name:             "Success abbreviated commit",
+			refName:          tagCommit[:7],
+			expectedCommitID: tagCommit,
+		},
 		{
 			name:             "Success, tricky tag name from bitbucket",
 			refName:          trickyTagName,
@@ -102,6 +107,81 @@ func TestNewGit2Go_LastCommitWithAzureTag(t *testing.T) {
 	assert.Equal(t, azureCommit, commitID)
 }
 
+func TestGo2GitClient_HasChanges(t *testing.T) {
+	//GIVEN
+	testCases := []struct {
+		name       string
+		baseDir    string
+		fromCommit string
+		expected   bool
+	}{
+		{
+			name:       "Changes in the repository root",
+			baseDir:    "/",
+			fromCommit: secondCommitID,
+			expected:   true,
+		},
+		{
+			name:       "No changes in the base directory",
+			baseDir:    "/functions/hello",
+			fromCommit: secondCommitID,
+			expected:   false,
+		},
+		{
+			name:       "Same commit",
+			baseDir:    "/",
+			fromCommit: tagCommit,
+			expected:   false,
+		},
+		{
+			name:       "Base commit not found",
+			baseDir:    "/functions/hello",
+			fromCommit: "11111705dabc65c12583ff5feb2e5300983afc3",
+			expected:   true,
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			repoPath := prepareRepo(t)
+			defer deleteTmpRepo(t, repoPath)
+			fetcher := &git2goFetcherMock{repoPath: repoPath}
+
+			opts := Options{Reference: tagName, URL: repoPath}
+			client := git2GoClient{fetcher: fetcher}
+			//WHEN
+			changed, err := client.HasChanges(opts, testcase.baseDir, testcase.fromCommit, tagCommit)
+
+			//THEN
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, changed)
+		})
+	}
+}
+
+func TestGo2GitClient_HasChangesReusesFetchedRepository(t *testing.T) {
+	//GIVEN
+	opts := Options{Reference: branchName, URL: "https://github.com/kyma-project/reused-repo"}
+	repoDir, err := mkRepoDir(opts)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(repoDir))
+	require.NoError(t, os.Rename(prepareRepo(t), repoDir))
+	defer deleteTmpRepo(t, repoDir)
+	fetcher := &git2goFetcherMock{repoPath: repoDir}
+	client := git2GoClient{fetcher: fetcher}
+
+	revision, err := client.LastCommit(opts)
+	require.NoError(t, err)
+
+	//WHEN
+	changed, err := client.HasChanges(opts, "/", secondCommitID, revision)
+
+	//THEN
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 1, fetcher.fetches)
+}
+
 func TestGo2GitClient_Clone(t *testing.T) {
 	//GIVEN
 	repoPath := prepareRepo(t)
@@ -129,9 +209,11 @@ func (g *git2goClonerMock) git2goClone(url, outputPath string, remoteCallbacks g
 
 type git2goFetcherMock struct {
 	repoPath string
+	fetches  int
 }
 
 func (g *git2goFetcherMock) git2goFetch(url, outputPath string, remoteCallbacks git2go.RemoteCallbacks) (*git2go.Repository, error) {
+	g.fetches++
 	return git2go.OpenRepository(g.repoPath)
 }
 
@@ -31,6 +31,7 @@ type ConvertingWebhook struct {
 const (
 	v1alpha1GitRepoNameAnnotation  = "serverless.kyma-project.io/v1alpha1GitRepoName"
 	v1alpha1SecretMountsAnnotation = "serverless.kyma-project.io/v1alpha1SecretMounts"
+	v1alpha1ImageSourceAnnotation  = "serverless.kyma-project.io/v1alpha1ImageSource"
 )
 
 var _ http.Handler = &ConvertingWebhook{}
@@ -146,7 +147,7 @@ func (w *ConvertingWebhook) convertFunctionV1Alpha1ToV1Alpha2(src, dst runtime.O
 		return fmt.Errorf("unsupported convert destination version %s ", dstGVK)
 	}
 
-	out.ObjectMeta = in.ObjectMeta
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
 	applyV1Alpha1ToV1Alpha2Annotations(in, out)
 
 	if err := w.convertSpecV1Alpha1ToV1Alpha2(in, out); err != nil {
@@ -256,6 +257,14 @@ func convertResourcesV1Alpha1ToV1Alpha2(in *serverlessv1alpha1.Function, out *se
 }
 
 func (w *ConvertingWebhook) convertSourceV1Alpha1ToV1Alpha2(in *serverlessv1alpha1.Function, out *serverlessv1alpha2.Function) error {
+	// v1alpha1 has no image source, the function created as v1alpha2 keeps it in the annotation
+	if jsonImageSource, ok := in.ObjectMeta.Annotations[v1alpha1ImageSourceAnnotation]; ok {
+		delete(out.ObjectMeta.Annotations, v1alpha1ImageSourceAnnotation)
+		out.Spec.Source = serverlessv1alpha2.Source{
+			Image: &serverlessv1alpha2.ImageSource{},
+		}
+		return json.Unmarshal([]byte(jsonImageSource), out.Spec.Source.Image)
+	}
 	if in.Spec.Type == serverlessv1alpha1.SourceTypeGit {
 		return w.convertGitRepositoryV1Alpha1ToV1Alpha2(in, out)
 	}
@@ -326,7 +335,7 @@ func (w *ConvertingWebhook) convertFunctionV1Alpha2ToV1Alpha1(src, dst runtime.O
 		return fmt.Errorf("unsupported convert destination version %s ", dstGVK)
 	}
 
-	out.ObjectMeta = in.ObjectMeta
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
 
 	if err := w.convertSpecV1Alpha2ToV1Alpha1(in, out); err != nil {
 		return errors.Wrap(err, "while converting function spec from v1alpha2 to v1alpha1")
@@ -425,6 +434,9 @@ func convertFunctionResourcesProfileV1Alpha2ToV1Alpha1(in *serverlessv1alpha2.Fu
 }
 
 func (w *ConvertingWebhook) convertSourceV1Alpha2ToV1Alpha1(in *serverlessv1alpha2.Function, out *serverlessv1alpha1.Function) error {
+	if in.Spec.Source.Image != nil {
+		return convertImageSourceV1Alpha2ToV1Alpha1(in, out)
+	}
 	if in.Spec.Source.Inline != nil {
 		out.Spec.Source = in.Spec.Source.Inline.Source
 		out.Spec.Deps = in.Spec.Source.Inline.Dependencies
@@ -446,6 +458,18 @@ func (w *ConvertingWebhook) convertSourceV1Alpha2ToV1Alpha1(in *serverlessv1alph
 	return nil
 }
 
+func convertImageSourceV1Alpha2ToV1Alpha1(in *serverlessv1alpha2.Function, out *serverlessv1alpha1.Function) error {
+	jsonImageSource, err := json.Marshal(in.Spec.Source.Image)
+	if err != nil {
+		return err
+	}
+	if out.ObjectMeta.Annotations == nil {
+		out.ObjectMeta.Annotations = map[string]string{}
+	}
+	out.ObjectMeta.Annotations[v1alpha1ImageSourceAnnotation] = string(jsonImageSource)
+	return nil
+}
+
 func (w *ConvertingWebhook) convertStatusV1Alpha2ToV1Alpha1(in *serverlessv1alpha2.FunctionStatus, outSource string, out *serverlessv1alpha1.FunctionStatus) {
 	out.Repository = serverlessv1alpha1.Repository(in.Repository)
 	out.Commit = in.Commit
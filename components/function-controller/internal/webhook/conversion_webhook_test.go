@@ -1351,6 +1351,35 @@ func TestConvertingWebhook_convertFunction_toAndFrom(t *testing.T) {
 				require.Equal(t, srcSecretMounts, againSecretMounts)
 			},
 		},
+		{
+			name: "v1alpha2 to v1alpha1 and back - with image source",
+			src: &serverlessv1alpha2.Function{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Spec: serverlessv1alpha2.FunctionSpec{
+					Runtime: serverlessv1alpha2.NodeJs16,
+					Source: serverlessv1alpha2.Source{
+						Image: &serverlessv1alpha2.ImageSource{
+							Reference: "registry.example.com/functions/hello:1.0.0",
+							Type:      serverlessv1alpha2.ImageSourceTypeArtifact,
+						},
+					},
+				},
+			},
+			srcVersion: serverlessv1alpha2.GroupVersion.String(),
+			dstVersion: serverlessv1alpha1.GroupVersion.String(),
+			assertion: func(t *testing.T, src, dst, again runtime.Object) {
+				dstAnnotations := dst.(*serverlessv1alpha1.Function).ObjectMeta.Annotations
+				require.Contains(t, dstAnnotations, v1alpha1ImageSourceAnnotation)
+
+				srcImageSource := src.(*serverlessv1alpha2.Function).Spec.Source
+				againImageSource := again.(*serverlessv1alpha2.Function).Spec.Source
+				require.Equal(t, srcImageSource, againImageSource)
+				require.NotContains(t, again.(*serverlessv1alpha2.Function).ObjectMeta.Annotations, v1alpha1ImageSourceAnnotation)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
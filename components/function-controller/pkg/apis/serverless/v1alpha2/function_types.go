@@ -35,16 +35,22 @@ type FunctionType string
 const (
 	FunctionTypeInline FunctionType = "inline"
 	FunctionTypeGit    FunctionType = "git"
+	FunctionTypeImage  FunctionType = "image"
 )
 
 type Source struct {
-	// GitRepository defines Function as git-sourced. Can't be used at the same time with Inline.
+	// GitRepository defines Function as git-sourced. Can't be used at the same time with Inline or Image.
 	// +optional
 	GitRepository *GitRepositorySource `json:"gitRepository,omitempty"`
 
-	// Inline defines Function as the inline Function. Can't be used at the same time with GitRepository.
+	// Inline defines Function as the inline Function. Can't be used at the same time with GitRepository or Image.
 	// +optional
 	Inline *InlineSource `json:"inline,omitempty"`
+
+	// Image defines Function as the prebuilt image or the OCI artifact with the Function's code, which is deployed without the build Job.
+	// Can't be used at the same time with GitRepository or Inline.
+	// +optional
+	Image *ImageSource `json:"image,omitempty"`
 }
 
 type InlineSource struct {
@@ -56,6 +62,33 @@ type InlineSource struct {
 	Dependencies string `json:"dependencies,omitempty"`
 }
 
+type ImageSource struct {
+	// +kubebuilder:validation:Required
+
+	// Reference provides the address of the prebuilt Function image or the OCI artifact,
+	// for example `registry.example.com/functions/hello:1.0.0`.
+	Reference string `json:"reference"`
+
+	// Type specifies whether Reference points to the complete Function image (`image`), which is run as it is,
+	// or to the OCI artifact (`artifact`) with the Function's code and dependencies, which is unpacked into the runtime image.
+	// +optional
+	Type ImageSourceType `json:"type,omitempty"`
+}
+
+// ImageSourceType is the enum of available image source types
+// +kubebuilder:validation:Enum=image;artifact
+type ImageSourceType string
+
+const (
+	ImageSourceTypeImage    ImageSourceType = "image"
+	ImageSourceTypeArtifact ImageSourceType = "artifact"
+)
+
+// IsArtifact returns true if the image source contains only the Function's code
+func (s *ImageSource) IsArtifact() bool {
+	return s.Type == ImageSourceTypeArtifact
+}
+
 type GitRepositorySource struct {
 	// +kubebuilder:validation:Required
 
@@ -217,6 +250,9 @@ const (
 	ConditionReasonHorizontalPodAutoscalerCreated ConditionReason = "HorizontalPodAutoscalerCreated"
 	ConditionReasonHorizontalPodAutoscalerUpdated ConditionReason = "HorizontalPodAutoscalerUpdated"
 	ConditionReasonMinReplicasNotAvailable        ConditionReason = "MinReplicasNotAvailable"
+	ConditionReasonImageConfigured                ConditionReason = "ImageConfigured"
+	ConditionReasonImageConfigurationFailed       ConditionReason = "ImageConfigurationFailed"
+	ConditionReasonBuildSkipped                   ConditionReason = "BuildSkipped"
 )
 
 type Condition struct {
@@ -293,6 +329,9 @@ func (f *Function) TypeOf(t FunctionType) bool {
 	case FunctionTypeGit:
 		return f.Spec.Source.GitRepository != nil
 
+	case FunctionTypeImage:
+		return f.Spec.Source.Image != nil
+
 	default:
 		return false
 	}
@@ -80,6 +80,10 @@ func (fn *Function) Validate(vc *ValidationConfig) error {
 		validations = append(validations, gitAuthValidators...)
 		return runValidations(vc, validations...)
 
+	case fn.TypeOf(FunctionTypeImage):
+		validations = append(validations, fn.Spec.validateImageReference, fn.Spec.validateImageType)
+		return runValidations(vc, validations...)
+
 	default:
 		validations = append(validations, unknownFunctionTypeValidator)
 		return runValidations(vc, validations...)
@@ -132,6 +136,28 @@ func (spec *FunctionSpec) validateInlineDeps(_ *ValidationConfig) error {
 	return nil
 }
 
+func (spec *FunctionSpec) validateImageReference(_ *ValidationConfig) error {
+	reference := spec.Source.Image.Reference
+	if strings.TrimSpace(reference) == "" {
+		return errors.New("spec.source.image.reference is required")
+	}
+	if strings.ContainsAny(reference, " \t\n") || strings.Contains(reference, "://") {
+		return fmt.Errorf("invalid spec.source.image.reference value: %s", reference)
+	}
+	return nil
+}
+
+var ErrInvalidImageSourceType = fmt.Errorf("invalid image source type")
+
+func (spec *FunctionSpec) validateImageType(_ *ValidationConfig) error {
+	switch spec.Source.Image.Type {
+	case "", ImageSourceTypeImage, ImageSourceTypeArtifact:
+		return nil
+	default:
+		return ErrInvalidImageSourceType
+	}
+}
+
 func (spec *FunctionSpec) gitAuthValidations() []validationFunction {
 	if spec.Source.GitRepository.Auth == nil {
 		return []validationFunction{
@@ -220,6 +246,10 @@ func (spec *FunctionSpec) validateSources(vc *ValidationConfig) error {
 	if spec.Source.Inline != nil {
 		sources++
 	}
+
+	if spec.Source.Image != nil {
+		sources++
+	}
 	if sources == 1 {
 		return nil
 	}
@@ -727,6 +727,57 @@ func TestFunctionSpec_validateResources(t *testing.T) {
 				gomega.ContainSubstring("secretNames should be unique"),
 			),
 		},
+		"Should validate image source without error": {
+			givenFunc: Function{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: FunctionSpec{
+					Runtime: NodeJs16,
+					Source: Source{
+						Image: &ImageSource{
+							Reference: "registry.example.com/functions/hello:1.0.0",
+							Type:      ImageSourceTypeArtifact,
+						},
+					},
+				},
+			},
+			expectedError: gomega.BeNil(),
+		},
+		"Should return error when image source reference is empty": {
+			givenFunc: Function{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: FunctionSpec{
+					Runtime: NodeJs16,
+					Source: Source{
+						Image: &ImageSource{
+							Type: "unknown",
+						},
+					},
+				},
+			},
+			expectedError: gomega.HaveOccurred(),
+			specifiedExpectedError: gomega.And(
+				gomega.ContainSubstring("spec.source.image.reference is required"),
+				gomega.ContainSubstring("invalid image source type"),
+			),
+		},
+		"Should return error when image source is used with inline source": {
+			givenFunc: Function{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: FunctionSpec{
+					Runtime: NodeJs16,
+					Source: Source{
+						Inline: &InlineSource{
+							Source: "test-source",
+						},
+						Image: &ImageSource{
+							Reference: "registry.example.com/functions/hello:1.0.0",
+						},
+					},
+				},
+			},
+			expectedError:          gomega.HaveOccurred(),
+			specifiedExpectedError: gomega.ContainSubstring("spec.source should contains only 1 configuration of function"),
+		},
 		"Should return error when validate empty mountPath in secretMounts": {
 			givenFunc: Function{
 				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},